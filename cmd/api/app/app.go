@@ -7,24 +7,198 @@ import (
 	"github.com/google/wire"
 	"github.com/labstack/echo/v4"
 	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/app/providers"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/controllers"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/mailer"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/middlewares"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/rusty"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/secrets"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+)
+
+var configSet = wire.NewSet(
+	providers.ProviderConfig,
+	wire.FieldsOf(new(*config.Config),
+		"Server",
+		"Secrets",
+		"Database",
+		"Rusty",
+		"Auth",
+		"WebAuthn",
+		"IdentityProviders",
+		"Lockout",
+		"RateLimitPolicies",
+		"PasswordPolicy",
+		"PasswordHashing",
+		"Mailer",
+		"Messaging",
+	),
+)
+
+var secretSet = wire.NewSet(
+	providers.ProviderSecretProvider,
+	wire.Bind(new(secrets.ISecretProvider), new(*secrets.CachedProvider)),
 )
 
 var databaseSet = wire.NewSet(
 	providers.DatabaseConnectionPostgres,
 )
 
+var mailerSet = wire.NewSet(
+	providers.ProviderMailer,
+	wire.Bind(new(mailer.IMailer), new(*mailer.OutboxMailer)),
+)
+
+var messagingSet = wire.NewSet(
+	providers.ProviderMessenger,
+)
+
+var repositorySet = wire.NewSet(
+	repositories.NewTransactor,
+	wire.Bind(new(repositories.ITransactor), new(*repositories.Transactor)),
+	repositories.NewUserRepository,
+	wire.Bind(new(repositories.IUserRepository), new(*repositories.UserRepository)),
+	repositories.NewVerificationCodeRepository,
+	wire.Bind(new(repositories.IVerificationCodeRepository), new(*repositories.VerificationCodeRepository)),
+	repositories.NewRefreshTokenRepository,
+	wire.Bind(new(repositories.IRefreshTokenRepository), new(*repositories.RefreshTokenRepository)),
+	repositories.NewSigningKeyRepository,
+	wire.Bind(new(repositories.ISigningKeyRepository), new(*repositories.SigningKeyRepository)),
+	repositories.NewRevokedTokenRepository,
+	wire.Bind(new(repositories.IRevokedTokenRepository), new(*repositories.RevokedTokenRepository)),
+	repositories.NewRoleRepository,
+	wire.Bind(new(repositories.IRoleRepository), new(*repositories.RoleRepository)),
+	repositories.NewPermissionRepository,
+	wire.Bind(new(repositories.IPermissionRepository), new(*repositories.PermissionRepository)),
+	repositories.NewUserRoleRepository,
+	wire.Bind(new(repositories.IUserRoleRepository), new(*repositories.UserRoleRepository)),
+	repositories.NewTOTPCredentialRepository,
+	wire.Bind(new(repositories.ITOTPCredentialRepository), new(*repositories.TOTPCredentialRepository)),
+	repositories.NewRecoveryCodeRepository,
+	wire.Bind(new(repositories.IRecoveryCodeRepository), new(*repositories.RecoveryCodeRepository)),
+	repositories.NewWebAuthnCredentialRepository,
+	wire.Bind(new(repositories.IWebAuthnCredentialRepository), new(*repositories.WebAuthnCredentialRepository)),
+	repositories.NewWebAuthnSessionRepository,
+	wire.Bind(new(repositories.IWebAuthnSessionRepository), new(*repositories.WebAuthnSessionRepository)),
+	repositories.NewOAuthClientRepository,
+	wire.Bind(new(repositories.IOAuthClientRepository), new(*repositories.OAuthClientRepository)),
+	repositories.NewOAuthAuthorizationCodeRepository,
+	wire.Bind(new(repositories.IOAuthAuthorizationCodeRepository), new(*repositories.OAuthAuthorizationCodeRepository)),
+	repositories.NewOAuthConsentRepository,
+	wire.Bind(new(repositories.IOAuthConsentRepository), new(*repositories.OAuthConsentRepository)),
+	repositories.NewExternalIdentityRepository,
+	wire.Bind(new(repositories.IExternalIdentityRepository), new(*repositories.ExternalIdentityRepository)),
+	repositories.NewFederatedLoginStateRepository,
+	wire.Bind(new(repositories.IFederatedLoginStateRepository), new(*repositories.FederatedLoginStateRepository)),
+	repositories.NewSessionRepository,
+	wire.Bind(new(repositories.ISessionRepository), new(*repositories.SessionRepository)),
+	repositories.NewLoginAttemptRepository,
+	wire.Bind(new(repositories.ILoginAttemptRepository), new(*repositories.LoginAttemptRepository)),
+	repositories.NewRateLimitRepository,
+	wire.Bind(new(repositories.IRateLimitRepository), new(*repositories.RateLimitRepository)),
+	repositories.NewPasswordHistoryRepository,
+	wire.Bind(new(repositories.IPasswordHistoryRepository), new(*repositories.PasswordHistoryRepository)),
+	repositories.NewOutboxEmailRepository,
+	wire.Bind(new(repositories.IOutboxEmailRepository), new(*repositories.OutboxEmailRepository)),
+	providers.ProviderBreachedPasswordRepository,
+	wire.Bind(new(repositories.IBreachedPasswordRepository), new(*repositories.BreachedPasswordFileRepository)),
+)
+
+var serviceSet = wire.NewSet(
+	services.NewRoleService,
+	wire.Bind(new(services.IRoleService), new(*services.RoleService)),
+	services.NewUserService,
+	wire.Bind(new(services.IUserService), new(*services.UserService)),
+	services.NewVerificationService,
+	wire.Bind(new(services.IVerificationService), new(*services.VerificationService)),
+	services.NewPasswordHasher,
+	wire.Bind(new(services.IPasswordHasher), new(*services.PasswordHasher)),
+	services.NewPasswordPolicyService,
+	wire.Bind(new(services.IPasswordPolicyService), new(*services.PasswordPolicyService)),
+	providers.ProviderLockoutService,
+	wire.Bind(new(services.ILockoutService), new(*services.LockoutService)),
+	services.NewPasswordService,
+	wire.Bind(new(services.IPasswordService), new(*services.PasswordService)),
+	providers.ProviderKeyService,
+	wire.Bind(new(services.IKeyService), new(*services.KeyService)),
+	services.NewTokenService,
+	wire.Bind(new(services.ITokenService), new(*services.TokenService)),
+	services.NewRefreshTokenService,
+	wire.Bind(new(services.IRefreshTokenService), new(*services.RefreshTokenService)),
+	services.NewSessionService,
+	wire.Bind(new(services.ISessionService), new(*services.SessionService)),
+	services.NewMFAService,
+	wire.Bind(new(services.IMFAService), new(*services.MFAService)),
+	providers.ProviderWebAuthn,
+	services.NewWebAuthnService,
+	wire.Bind(new(services.IWebAuthnService), new(*services.WebAuthnService)),
+	services.NewPhoneService,
+	wire.Bind(new(services.IPhoneService), new(*services.PhoneService)),
+	services.NewPasswordlessService,
+	wire.Bind(new(services.IPasswordlessService), new(*services.PasswordlessService)),
+	services.NewFederatedLoginService,
+	wire.Bind(new(services.IFederatedLoginService), new(*services.FederatedLoginService)),
+	services.NewAuthService,
+	wire.Bind(new(services.IAuthService), new(*services.AuthService)),
+	providers.ProviderRevocationService,
+	wire.Bind(new(services.IRevocationService), new(*services.RevocationService)),
+	services.NewIntrospectionService,
+	wire.Bind(new(services.IIntrospectionService), new(*services.IntrospectionService)),
+	wire.Bind(new(middlewares.TokenValidator), new(*services.IntrospectionService)),
+	services.NewOAuthClientService,
+	wire.Bind(new(services.IOAuthClientService), new(*services.OAuthClientService)),
+	services.NewOAuthService,
+	wire.Bind(new(services.IOAuthService), new(*services.OAuthService)),
+	services.NewOIDCService,
+	wire.Bind(new(services.IOIDCService), new(*services.OIDCService)),
+)
+
+var controllerSet = wire.NewSet(
+	controllers.NewUserController,
+	controllers.NewPasswordController,
+	controllers.NewAuthController,
+	controllers.NewWellKnownController,
+	controllers.NewOAuthController,
+	controllers.NewRoleController,
+	controllers.NewMFAController,
+	controllers.NewWebAuthnController,
+	controllers.NewPasswordlessController,
+	controllers.NewOAuthClientController,
+	controllers.NewFederatedController,
+	controllers.NewSessionController,
+	controllers.NewLockoutController,
+	controllers.NewPhoneController,
+)
+
+var middlewareSet = wire.NewSet(
+	middlewares.NewAuthenticator,
+	providers.ProviderRateLimiter,
+)
+
 var ClientRouterSet = wire.NewSet()
 
-var RustyClientSet = wire.NewSet()
+var RustyClientSet = wire.NewSet(
+	providers.GetRustyClient,
+	wire.Bind(new(rusty.IRustyClient), new(*rusty.RustyClient)),
+)
 
 var routerSet = wire.NewSet(
 	ClientRouterSet,
+	controllerSet,
+	middlewareSet,
 	providers.ProviderRouter,
 )
 
 func Start() (*echo.Echo, error) {
 	panic(wire.Build(
+		configSet,
+		secretSet,
 		databaseSet,
+		mailerSet,
+		messagingSet,
+		repositorySet,
+		serviceSet,
 		routerSet,
 		RustyClientSet,
 	))
@@ -0,0 +1,12 @@
+package providers
+
+import (
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+)
+
+// ProviderBreachedPasswordRepository carga la lista de contraseñas filtradas al arrancar; si el archivo configurado
+// no existe o está mal formado el servicio no arranca, para no aceptar en silencio contraseñas filtradas.
+func ProviderBreachedPasswordRepository(policy config.PasswordPolicyConfig) (*repositories.BreachedPasswordFileRepository, error) {
+	return repositories.NewBreachedPasswordFileRepository(policy.BreachedPasswordsFile)
+}
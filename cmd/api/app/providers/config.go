@@ -0,0 +1,9 @@
+package providers
+
+import "github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+
+// ProviderConfig carga y valida la configuración antes de armar el resto de la aplicación: una clave faltante
+// corta el arranque con todos los errores juntos.
+func ProviderConfig() (*config.Config, error) {
+	return config.Load()
+}
@@ -1,21 +1,26 @@
 package providers
 
 import (
+	"context"
 	"fmt"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/stdlib"
 	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/secrets"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	"os"
 )
 
-func DatabaseConnectionPostgres() (*gorm.DB, error) {
+func DatabaseConnectionPostgres(dbConfig config.ConnectionConfig, secretProvider secrets.ISecretProvider) (*gorm.DB, error) {
 	var (
 		db  *gorm.DB
 		err error
 	)
 
-	for retry := 0; retry < config.MaxConnectionRetries; retry++ {
-		if db, err = GetDBConnectionPostgres(); err != nil {
+	for retry := 0; retry < dbConfig.MaxRetries; retry++ {
+		if db, err = GetDBConnectionPostgres(dbConfig, secretProvider); err != nil {
 			continue
 		}
 
@@ -26,18 +31,36 @@ func DatabaseConnectionPostgres() (*gorm.DB, error) {
 		}
 	}
 
+	if err == nil {
+		err = models.AutoMigrate(db)
+	}
+
 	return db, err
 }
 
-func GetDBConnectionPostgres() (*gorm.DB, error) {
-	connString := fmt.Sprintf("host=%s user=%s password=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Shanghai",
-		config.DBConfig.Host,
-		config.DBConfig.Username,
-		config.DBConfig.Password,
-		config.DBConfig.Name,
-		config.DBConfig.Port)
+// GetDBConnectionPostgres pide la contraseña en cada conexión nueva en lugar de fijarla en el DSN: cuando se rota
+// el secreto, las conexiones que abra el pool a partir de ese momento usan la contraseña nueva.
+func GetDBConnectionPostgres(dbConfig config.ConnectionConfig, secretProvider secrets.ISecretProvider) (*gorm.DB, error) {
+	connString := fmt.Sprintf("host=%s user=%s dbname=%s port=%s sslmode=disable TimeZone=Asia/Shanghai",
+		dbConfig.Host,
+		dbConfig.Username,
+		dbConfig.Name,
+		dbConfig.Port)
+
+	connConfig, err := pgx.ParseConfig(connString)
+	if err != nil {
+		return nil, err
+	}
+	connector := stdlib.OpenDB(*connConfig, stdlib.OptionBeforeConnect(func(ctx context.Context, connConfig *pgx.ConnConfig) error {
+		password, err := databasePassword(ctx, dbConfig, secretProvider)
+		if err != nil {
+			return err
+		}
+		connConfig.Password = password
+		return nil
+	}))
 
-	conn, err := gorm.Open(postgres.Open(connString), &gorm.Config{PrepareStmt: true, QueryFields: true})
+	conn, err := gorm.Open(postgres.New(postgres.Config{Conn: connector}), &gorm.Config{PrepareStmt: true, QueryFields: true, TranslateError: true})
 	if err != nil {
 		//utils.GetLogger(context.TODO()).Error(fmt.Sprintf("Error abriendo la conexión a la base de datos: %v", err))
 		return nil, err
@@ -48,10 +71,10 @@ func GetDBConnectionPostgres() (*gorm.DB, error) {
 		//utils.GetLogger(context.TODO()).Error(fmt.Sprintf("Error obteniendo la base de datos: %v", err))
 		return nil, err
 	}
-	sqlDB.SetConnMaxLifetime(config.DBConfig.ConnMaxLifetime)
-	sqlDB.SetConnMaxIdleTime(config.DBConfig.ConnMaxIdleTime)
-	sqlDB.SetMaxIdleConns(config.DBConfig.MaxIdleConnections)
-	sqlDB.SetMaxOpenConns(config.DBConfig.MaxOpenConnections)
+	sqlDB.SetConnMaxLifetime(dbConfig.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(dbConfig.ConnMaxIdleTime)
+	sqlDB.SetMaxIdleConns(dbConfig.MaxIdleConnections)
+	sqlDB.SetMaxOpenConns(dbConfig.MaxOpenConnections)
 
 	//utils.GetLogger(context.TODO()).Info(fmt.Sprintf("Conexiones abiertas: %v", sqlDB.Stats().OpenConnections))
 
@@ -62,3 +85,11 @@ func GetDBConnectionPostgres() (*gorm.DB, error) {
 
 	return conn, err
 }
+
+// databasePassword usa la contraseña fija si está configurada y si no la del proveedor de secretos.
+func databasePassword(ctx context.Context, dbConfig config.ConnectionConfig, secretProvider secrets.ISecretProvider) (string, error) {
+	if dbConfig.Password != "" {
+		return dbConfig.Password, nil
+	}
+	return secretProvider.Secret(ctx, dbConfig.PasswordSecret)
+}
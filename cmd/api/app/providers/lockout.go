@@ -0,0 +1,21 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+)
+
+// ProviderLockoutService arranca la purga periódica de los contadores de logins fallidos.
+func ProviderLockoutService(
+	loginAttemptRepository repositories.ILoginAttemptRepository,
+	userRepository repositories.IUserRepository,
+	lockoutConfig config.LoginLockoutConfig,
+) *services.LockoutService {
+	lockoutService := services.NewLockoutService(loginAttemptRepository, userRepository, lockoutConfig)
+
+	go lockoutService.Run(context.Background(), lockoutConfig.FailureWindow)
+	return lockoutService
+}
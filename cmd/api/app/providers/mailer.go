@@ -0,0 +1,40 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/mailer"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+)
+
+// ProviderMailer encola los emails en el outbox y arranca el proceso que los entrega con el transporte
+// configurado. Las plantillas se parsean al arrancar para que una plantilla rota no espere al primer envío.
+func ProviderMailer(
+	outboxRepository repositories.IOutboxEmailRepository,
+	mailConfig config.MailDeliveryConfig,
+) (*mailer.OutboxMailer, error) {
+	renderer, err := mailer.NewRenderer(mailConfig.AppURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var transport mailer.ITransport
+	switch mailConfig.Transport {
+	case constants.MailTransportSMTP:
+		transport = mailer.NewSMTPTransport(mailConfig.SMTPHost, mailConfig.SMTPPort,
+			mailConfig.SMTPUsername, mailConfig.SMTPPassword, mailConfig.SMTPTimeout)
+	case constants.MailTransportFile:
+		transport = mailer.NewFileTransport(mailConfig.OutputDir)
+	case constants.MailTransportLog:
+		transport = mailer.NewLogTransport()
+	default:
+		return nil, fmt.Errorf("unsupported mail transport %q", mailConfig.Transport)
+	}
+
+	dispatcher := mailer.NewOutboxDispatcher(outboxRepository, transport, renderer, mailConfig)
+	go dispatcher.Run(context.Background(), mailConfig.OutboxInterval)
+	return mailer.NewOutboxMailer(outboxRepository, renderer), nil
+}
@@ -0,0 +1,23 @@
+package providers
+
+import (
+	"fmt"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/messaging"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/rusty"
+)
+
+// ProviderMessenger elige el proveedor de SMS y WhatsApp configurado.
+func ProviderMessenger(rustyClient rusty.IRustyClient, messagingConfig config.MessageDeliveryConfig) (messaging.IMessenger, error) {
+	switch messagingConfig.Provider {
+	case constants.MessagingProviderHTTP:
+		return messaging.NewHTTPMessenger(rustyClient, messagingConfig.BaseURL,
+			messagingConfig.APIKey, messagingConfig.SenderID), nil
+	case constants.MessagingProviderLog:
+		return messaging.NewLogMessenger(), nil
+	default:
+		return nil, fmt.Errorf("unsupported messaging provider %q", messagingConfig.Provider)
+	}
+}
@@ -0,0 +1,23 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/middlewares"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+)
+
+// ProviderRateLimiter arranca la purga periódica de los buckets. Los del límite de códigos por teléfono comparten
+// la tabla y se recargan en CodeWindow, así que la retención también lo cubre.
+func ProviderRateLimiter(
+	store repositories.IRateLimitRepository,
+	policies map[string]config.RateLimitPolicy,
+	messagingConfig config.MessageDeliveryConfig,
+) *middlewares.RateLimiter {
+	rateLimiter := middlewares.NewRateLimiter(store, policies)
+	rateLimiter.Retention = max(rateLimiter.Retention, messagingConfig.CodeWindow)
+
+	go rateLimiter.Run(context.Background(), rateLimiter.Retention)
+	return rateLimiter
+}
@@ -0,0 +1,22 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+)
+
+// ProviderRevocationService arranca la purga periódica de la lista de revocación.
+func ProviderRevocationService(
+	revokedTokenRepository repositories.IRevokedTokenRepository,
+	tokenService services.ITokenService,
+	refreshTokenService services.IRefreshTokenService,
+	authConfig config.AuthTokenConfig,
+) *services.RevocationService {
+	revocationService := services.NewRevocationService(revokedTokenRepository, tokenService, refreshTokenService, authConfig)
+
+	go revocationService.Run(context.Background(), authConfig.AccessTokenTTL)
+	return revocationService
+}
@@ -1,23 +1,151 @@
 package providers
 
 import (
+	"net"
+
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	echoSwagger "github.com/swaggo/echo-swagger"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/controllers"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/middlewares"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
 )
 
-func ProviderRouter() *echo.Echo {
+func ProviderRouter(
+	userController *controllers.UserController,
+	passwordController *controllers.PasswordController,
+	authController *controllers.AuthController,
+	wellKnownController *controllers.WellKnownController,
+	oauthController *controllers.OAuthController,
+	roleController *controllers.RoleController,
+	mfaController *controllers.MFAController,
+	webAuthnController *controllers.WebAuthnController,
+	passwordlessController *controllers.PasswordlessController,
+	oauthClientController *controllers.OAuthClientController,
+	federatedController *controllers.FederatedController,
+	sessionController *controllers.SessionController,
+	lockoutController *controllers.LockoutController,
+	phoneController *controllers.PhoneController,
+	authenticator *middlewares.Authenticator,
+	rateLimiter *middlewares.RateLimiter,
+	serverConfig config.ServerConfig,
+) *echo.Echo {
 	router := echo.New()
+	router.IPExtractor = ipExtractor(serverConfig.TrustedProxies)
+	router.Validator = utils.NewRequestValidator()
+	router.HTTPErrorHandler = utils.HTTPErrorHandler
 
 	router.GET("/swagger/*", echoSwagger.WrapHandler)
 
 	router.Use(middleware.Recover())
 	router.Use(middleware.Logger())
+	router.Use(middlewares.ClientInfo())
+
+	limitLogin := rateLimiter.Limit(constants.RateLimitPolicyLogin)
+	limitSignup := rateLimiter.Limit(constants.RateLimitPolicySignup)
+	limitPassword := rateLimiter.Limit(constants.RateLimitPolicyPassword)
+	limitCodeResend := rateLimiter.Limit(constants.RateLimitPolicyCodeResend)
+	limitMFA := rateLimiter.Limit(constants.RateLimitPolicyMFA)
+	limitOAuthToken := rateLimiter.Limit(constants.RateLimitPolicyOAuthToken)
+	limitPublicKeys := rateLimiter.Limit(constants.RateLimitPolicyPublicKeys)
+	limitRefresh := rateLimiter.Limit(constants.RateLimitPolicyRefresh)
 
-	//api := router.Group("/v1/api")
+	router.GET("/.well-known/jwks.json", wellKnownController.JWKS, limitPublicKeys)
+	router.GET("/.well-known/openid-configuration", wellKnownController.OpenIDConfiguration, limitPublicKeys)
+
+	oauth := router.Group("/oauth")
 	{
-		//users := api.Group("/users	")
+		oauth.GET("/authorize", oauthController.Authorize, authenticator.Authenticate(), middlewares.RequireFirstParty())
+		oauth.POST("/authorize", oauthController.Consent, authenticator.Authenticate(), middlewares.RequireFirstParty())
+		oauth.POST("/token", oauthController.Token, limitOAuthToken)
+		oauth.GET("/userinfo", oauthController.UserInfo, authenticator.Authenticate(constants.OAuthScopeOpenID))
+		oauth.POST("/userinfo", oauthController.UserInfo, authenticator.Authenticate(constants.OAuthScopeOpenID))
+		oauth.POST("/introspect", oauthController.Introspect, limitOAuthToken)
+		oauth.POST("/revoke", oauthController.Revoke, limitOAuthToken)
+	}
+
+	api := router.Group("/v1/api")
+	{
+		users := api.Group("/users")
+		users.POST("", userController.Signup, limitSignup)
+		users.POST("/verify-email", userController.VerifyEmail, limitLogin)
+		users.POST("/verify-email/resend", userController.ResendVerification, limitCodeResend)
+
+		me := users.Group("/me", authenticator.Authenticate(), middlewares.RequireFirstParty())
+		me.GET("", userController.Me)
+		me.PATCH("", userController.UpdateMe)
+		me.POST("/password", passwordController.ChangePassword, limitPassword)
+		me.POST("/phone", phoneController.StartVerification, limitCodeResend)
+		me.POST("/phone/verify", phoneController.ConfirmVerification, limitMFA)
+		me.GET("/mfa", mfaController.Status)
+		me.POST("/mfa/totp", mfaController.EnrollTOTP)
+		me.POST("/mfa/totp/confirm", mfaController.ConfirmTOTP, limitMFA)
+		me.POST("/mfa/totp/disable", mfaController.DisableTOTP, limitMFA)
+		me.POST("/mfa/recovery-codes", mfaController.RegenerateRecoveryCodes, limitMFA)
+		me.POST("/webauthn/registration/begin", webAuthnController.BeginRegistration)
+		me.POST("/webauthn/registration/finish", webAuthnController.FinishRegistration)
+		me.GET("/webauthn/credentials", webAuthnController.ListCredentials)
+		me.DELETE("/webauthn/credentials/:id", webAuthnController.DeleteCredential)
+		me.GET("/identities", federatedController.ListIdentities)
+		me.POST("/identities/:provider/begin", federatedController.BeginLink)
+		me.POST("/identities/:provider", federatedController.Link)
+		me.DELETE("/identities/:provider", federatedController.Unlink)
+		me.GET("/sessions", sessionController.ListSessions)
+		me.DELETE("/sessions", sessionController.RevokeAllSessions)
+		me.DELETE("/sessions/:id", sessionController.RevokeSession)
 
+		auth := api.Group("/auth")
+		auth.POST("/login", authController.Login, limitLogin)
+		auth.POST("/login/mfa", authController.LoginMFA, limitLogin)
+		auth.POST("/webauthn/login/begin", webAuthnController.BeginLogin, limitLogin)
+		auth.POST("/webauthn/login/finish", webAuthnController.FinishLogin, limitLogin)
+		auth.POST("/passwordless/start", passwordlessController.Start, limitCodeResend)
+		auth.POST("/passwordless/phone/start", phoneController.StartLogin, limitCodeResend)
+		auth.POST("/passwordless/login", passwordlessController.Login, limitLogin)
+		auth.GET("/social/providers", federatedController.ListProviders)
+		auth.POST("/social/:provider/begin", federatedController.BeginLogin, limitLogin)
+		auth.POST("/social/:provider/login", federatedController.Login, limitLogin)
+		auth.POST("/refresh", authController.Refresh, limitRefresh)
+		auth.POST("/password/forgot", passwordController.ForgotPassword, limitPassword)
+		auth.POST("/password/reset", passwordController.ResetPassword, limitPassword)
+
+		admin := api.Group("/admin", authenticator.Authenticate())
+		manageRoles := middlewares.RequirePermissions(constants.PermissionRolesManage)
+		manageUsers := middlewares.RequirePermissions(constants.PermissionUsersManage)
+		manageClients := middlewares.RequirePermissions(constants.PermissionClientsManage)
+		admin.GET("/roles", roleController.ListRoles, manageRoles)
+		admin.POST("/roles", roleController.CreateRole, manageRoles)
+		admin.PUT("/roles/:id", roleController.UpdateRole, manageRoles)
+		admin.DELETE("/roles/:id", roleController.DeleteRole, manageRoles)
+		admin.GET("/permissions", roleController.ListPermissions, manageRoles)
+		admin.POST("/permissions", roleController.CreatePermission, manageRoles)
+		admin.GET("/users/:id/roles", roleController.UserRoles, manageRoles)
+		admin.POST("/users/:id/roles", roleController.AssignRole, manageRoles)
+		admin.DELETE("/users/:id/roles/:role", roleController.RemoveRole, manageRoles)
+		admin.DELETE("/users/:id/mfa", mfaController.Reset, manageUsers)
+		admin.DELETE("/users/:id/lockout", lockoutController.Unlock, manageUsers)
+		admin.GET("/oauth/clients", oauthClientController.ListClients, manageClients)
+		admin.POST("/oauth/clients", oauthClientController.CreateClient, manageClients)
+		admin.DELETE("/oauth/clients/:client_id", oauthClientController.DeleteClient, manageClients)
 	}
 	return router
 }
+
+// ipExtractor confía en X-Forwarded-For solo cuando la conexión llega desde trustedProxies; si no, el cliente
+// podría elegir su IP y esquivar los límites y bloqueos por IP.
+func ipExtractor(trustedProxies []string) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, cidr := range trustedProxies {
+		// Los rangos ya se validaron al cargar la configuración.
+		if _, ipRange, err := net.ParseCIDR(cidr); err == nil {
+			options = append(options, echo.TrustIPRange(ipRange))
+		}
+	}
+	return echo.ExtractIPFromXFFHeader(options...)
+}
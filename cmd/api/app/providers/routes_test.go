@@ -0,0 +1,73 @@
+package providers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/middlewares"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+)
+
+type fakeTokenValidator map[string]*services.AccessTokenClaims
+
+func (v fakeTokenValidator) ValidateAccessToken(_ context.Context, token string) (*services.AccessTokenClaims, error) {
+	claims, ok := v[token]
+	if !ok {
+		return nil, services.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func TestProviderRouter(t *testing.T) {
+	authenticator := middlewares.NewAuthenticator(fakeTokenValidator{
+		"third-party": {
+			Scope:            "openid",
+			ClientID:         "partner-app",
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "7", ID: "jti-1"},
+		},
+	})
+	rateLimiter := middlewares.NewRateLimiter(repositories.NewMemoryRateLimitRepository(), config.Defaults("test").RateLimitPolicies)
+	// Los controllers quedan en nil: las peticiones rechazadas por los middlewares nunca llegan al handler.
+	router := ProviderRouter(nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, authenticator, rateLimiter, config.ServerConfig{})
+
+	t.Run("third-party client token cannot manage the account", func(t *testing.T) {
+		for _, path := range []string{"/v1/api/users/me/webauthn/registration/begin", "/v1/api/users/me/identities/google/begin", "/v1/api/users/me/mfa/totp"} {
+			request := httptest.NewRequest(http.MethodPost, path, nil)
+			request.Header.Set("Authorization", "Bearer third-party")
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != http.StatusForbidden {
+				t.Errorf("%s: unexpected status: got %d, want %d", path, recorder.Code, http.StatusForbidden)
+			}
+		}
+	})
+
+	t.Run("forwarded for header is only trusted from proxies", func(t *testing.T) {
+		testCases := []struct {
+			name           string
+			trustedProxies []string
+			remoteAddr     string
+			expectedIP     string
+		}{
+			{name: "no trusted proxies", remoteAddr: "203.0.113.7:41000", expectedIP: "203.0.113.7"},
+			{name: "untrusted peer", trustedProxies: []string{"10.0.0.0/8"}, remoteAddr: "203.0.113.7:41000", expectedIP: "203.0.113.7"},
+			{name: "trusted proxy", trustedProxies: []string{"10.0.0.0/8"}, remoteAddr: "10.1.2.3:41000", expectedIP: "198.51.100.20"},
+		}
+
+		for _, tc := range testCases {
+			request := httptest.NewRequest(http.MethodPost, "/v1/api/auth/login", nil)
+			request.RemoteAddr = tc.remoteAddr
+			request.Header.Set("X-Forwarded-For", "198.51.100.20")
+
+			if ip := ipExtractor(tc.trustedProxies)(request); ip != tc.expectedIP {
+				t.Errorf("%s: unexpected ip: got %s, want %s", tc.name, ip, tc.expectedIP)
+			}
+		}
+	})
+}
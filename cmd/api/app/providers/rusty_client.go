@@ -1,7 +1,10 @@
 package providers
 
-import "github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/rusty"
+import (
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/rusty"
+)
 
-func GetRustyClient() *rusty.RustyClient {
-	return &rusty.RustyClient{}
+func GetRustyClient(rustyConfig config.RustyClientConfig) *rusty.RustyClient {
+	return rusty.NewRustyClient(rustyConfig)
 }
@@ -0,0 +1,30 @@
+package providers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/rusty"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/secrets"
+)
+
+// ProviderSecretProvider elige el proveedor de secretos configurado, con caché, y arranca la relectura periódica.
+func ProviderSecretProvider(secretsConfig config.SecretProviderConfig, rustyClient rusty.IRustyClient) (*secrets.CachedProvider, error) {
+	var provider secrets.ISecretProvider
+	switch secretsConfig.Provider {
+	case constants.SecretProviderEnv:
+		provider = secrets.NewEnvProvider()
+	case constants.SecretProviderFile:
+		provider = secrets.NewFileProvider(secretsConfig.Directory)
+	case constants.SecretProviderVault:
+		provider = secrets.NewVaultProvider(rustyClient, secretsConfig.VaultURL, secretsConfig.VaultToken, secretsConfig.VaultMount)
+	default:
+		return nil, fmt.Errorf("unsupported secret provider %q", secretsConfig.Provider)
+	}
+
+	cachedProvider := secrets.NewCachedProvider(provider, secretsConfig.CacheTTL)
+	go cachedProvider.Run(context.Background(), secretsConfig.RefreshInterval)
+	return cachedProvider, nil
+}
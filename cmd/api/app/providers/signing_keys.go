@@ -0,0 +1,27 @@
+package providers
+
+import (
+	"context"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/secrets"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+)
+
+// ProviderKeyService garantiza que exista una clave de firma antes de atender tráfico y arranca la rotación
+// periódica.
+func ProviderKeyService(
+	signingKeyRepository repositories.ISigningKeyRepository,
+	transactor repositories.ITransactor,
+	secretProvider secrets.ISecretProvider,
+	authConfig config.AuthTokenConfig,
+) (*services.KeyService, error) {
+	keyService := services.NewKeyService(signingKeyRepository, transactor, secretProvider, authConfig)
+	if err := keyService.RotateIfDue(context.Background()); err != nil {
+		return nil, err
+	}
+
+	go keyService.Run(context.Background(), authConfig.KeyRotationCheckInterval)
+	return keyService, nil
+}
@@ -0,0 +1,26 @@
+package providers
+
+import (
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+)
+
+// ProviderWebAuthn configura el relying party; solo se aceptan passkeys detectables con verificación de usuario
+// porque reemplazan a la contraseña.
+func ProviderWebAuthn(webAuthnConfig config.WebAuthnRelyingPartyConfig) (*webauthn.WebAuthn, error) {
+	requireResidentKey := true
+	timeout := webauthn.TimeoutConfig{Timeout: webAuthnConfig.Timeout, TimeoutUVD: webAuthnConfig.Timeout}
+
+	return webauthn.New(&webauthn.Config{
+		RPID:          webAuthnConfig.RPID,
+		RPDisplayName: webAuthnConfig.RPDisplayName,
+		RPOrigins:     webAuthnConfig.RPOrigins,
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			RequireResidentKey: &requireResidentKey,
+			ResidentKey:        protocol.ResidentKeyRequirementRequired,
+			UserVerification:   protocol.VerificationRequired,
+		},
+		Timeouts: webauthn.TimeoutsConfig{Login: timeout, Registration: timeout},
+	})
+}
@@ -1,31 +1,61 @@
 package config
 
-import (
-	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
-	"os"
-	"time"
-)
+import "time"
 
-var (
-	RustyConfig          RustyClientConfig
-	DBConfig             ConnectionConfig
-	MaxIdleConnections   int
-	MaxOpenConnections   int
-	ConnMaxLifetime      time.Duration
-	ConnMaxIdleTime      time.Duration
-	MaxBatchSize         int
-	MaxConnectionRetries int
-)
+// Config es la configuración completa del servicio. La arma Load al arrancar y Wire inyecta cada sección en
+// quien la necesita.
+type Config struct {
+	Environment       string
+	Server            ServerConfig
+	Secrets           SecretProviderConfig
+	Database          ConnectionConfig
+	Rusty             RustyClientConfig
+	Auth              AuthTokenConfig
+	WebAuthn          WebAuthnRelyingPartyConfig
+	IdentityProviders map[string]IdentityProviderConfig
+	Lockout           LoginLockoutConfig
+	RateLimitPolicies map[string]RateLimitPolicy
+	PasswordPolicy    PasswordPolicyConfig
+	PasswordHashing   PasswordHashingConfig
+	Mailer            MailDeliveryConfig
+	Messaging         MessageDeliveryConfig
+}
+
+// ServerConfig.TrustedProxies son los rangos CIDR de los balanceadores y proxies delante del servicio. La IP del
+// cliente solo se toma de X-Forwarded-For cuando la conexión llega desde uno de esos rangos; sin rangos se usa la
+// IP de la conexión.
+type ServerConfig struct {
+	TrustedProxies []string
+}
+
+// SecretProviderConfig elige de dónde salen los secretos que pueden rotar sin redeploy: Provider es "env" (una
+// variable de entorno por secreto), "file" (un archivo por secreto en Directory, como los secretos de Docker y
+// Kubernetes) o "vault" (el motor KV v2 montado en VaultMount). Los valores se guardan CacheTTL y se releen cada
+// RefreshInterval.
+type SecretProviderConfig struct {
+	Provider        string
+	Directory       string
+	VaultURL        string
+	VaultToken      string
+	VaultMount      string
+	CacheTTL        time.Duration
+	RefreshInterval time.Duration
+}
 
+// ConnectionConfig.MaxRetries son los intentos de conexión a la base al arrancar. Password es una contraseña fija,
+// pensada para desarrollo; si está vacía cada conexión nueva pide al proveedor de secretos el secreto
+// PasswordSecret, así que rotar la contraseña no requiere redeploy.
 type ConnectionConfig struct {
 	Username           string
 	Password           string
+	PasswordSecret     string
 	Host               string
 	Name               string
 	Port               string
 	MaxIdleConnections int
 	MaxOpenConnections int
 	MaxBatchSize       int
+	MaxRetries         int
 	ConnMaxLifetime    time.Duration
 	ConnMaxIdleTime    time.Duration
 }
@@ -35,65 +65,125 @@ type RustyClientConfig struct {
 	RetryCount     int
 }
 
-func init() {
+// AuthTokenConfig.PublicURL es la URL pública del servicio: el issuer de OpenID Connect y la base de los
+// endpoints publicados en el discovery. Con SigningKeySecret las claves de firma no se generan: se importa la
+// clave PEM guardada en ese secreto cada vez que cambia, y KeyRotationInterval no aplica.
+type AuthTokenConfig struct {
+	Issuer                   string
+	PublicURL                string
+	AccessTokenTTL           time.Duration
+	RefreshTokenTTL          time.Duration
+	SigningAlgorithm         string
+	SigningKeySecret         string
+	KeyRotationInterval      time.Duration
+	KeyPublishAhead          time.Duration
+	KeyRotationCheckInterval time.Duration
+}
 
-	// DB.
-	MaxIdleConnections = 500
-	MaxOpenConnections = 500
-	ConnMaxLifetime = 600 * time.Second
-	ConnMaxIdleTime = 600 * time.Second
-	MaxBatchSize = 100
-	MaxConnectionRetries = 3
+// WebAuthnRelyingPartyConfig identifica al servicio ante los autenticadores; RPID debe ser el dominio (o un
+// dominio padre) de todos los orígenes permitidos.
+type WebAuthnRelyingPartyConfig struct {
+	RPID          string
+	RPDisplayName string
+	RPOrigins     []string
+	Timeout       time.Duration
+}
 
-	// Rusty client
-	RustyConfig.DefaultTimeOut = 11 * time.Second
-	RustyConfig.RetryCount = 3
+// LoginLockoutConfig limita los intentos de login fallidos por cuenta y por IP. A partir de DelayAfterFailures
+// fallos cada intento debe esperar BaseDelay duplicado por fallo, hasta MaxDelay; al llegar a Max*Failures la
+// cuenta o la IP queda bloqueada LockoutDuration. Los fallos más viejos que FailureWindow se olvidan.
+type LoginLockoutConfig struct {
+	MaxAccountFailures int
+	MaxIPFailures      int
+	DelayAfterFailures int
+	BaseDelay          time.Duration
+	MaxDelay           time.Duration
+	FailureWindow      time.Duration
+	LockoutDuration    time.Duration
+}
 
-	if os.Getenv("GO_ENVIRONMENT") == "" ||
-		os.Getenv("GO_ENVIRONMENT") == "test" ||
-		os.Getenv("GO_ENVIRONMENT") == constants.ScopeLocal {
+// RateLimitPolicy permite Requests peticiones por Period y ráfagas de hasta Burst (Requests si es 0). KeyBy indica si
+// el bucket es por IP, por usuario autenticado o por cliente OAuth; sin usuario o cliente se usa la IP.
+type RateLimitPolicy struct {
+	Requests int
+	Period   time.Duration
+	Burst    int
+	KeyBy    string
+}
+
+// PasswordPolicyConfig son las reglas de las contraseñas nuevas. MinCharacterClasses cuenta cuántas de minúsculas,
+// mayúsculas, dígitos y símbolos deben aparecer; HistorySize cuántas contraseñas anteriores no se pueden repetir.
+// BreachedPasswordsFile es la lista local de hashes SHA-1 filtrados; vacío deshabilita el chequeo.
+type PasswordPolicyConfig struct {
+	MinLength             int
+	MaxLength             int
+	MinCharacterClasses   int
+	DisallowPersonalInfo  bool
+	HistorySize           int
+	BreachedPasswordsFile string
+}
 
-		DBConfig = ConnectionConfig{
-			Username:           "postgres",
-			Password:           "root",
-			Host:               "localhost",
-			Name:               "local_tareaya",
-			Port:               "5432",
-			MaxIdleConnections: MaxIdleConnections,
-			MaxOpenConnections: MaxOpenConnections,
-			ConnMaxLifetime:    time.Second * ConnMaxLifetime,
-			ConnMaxIdleTime:    time.Second * ConnMaxIdleTime,
-			MaxBatchSize:       MaxBatchSize,
-		}
-	}
+// PasswordHashingConfig elige el algoritmo de las contraseñas nuevas. Los hashes guardados con otro algoritmo o
+// con parámetros más débiles se recalculan en el siguiente login correcto. Argon2Memory en KiB.
+type PasswordHashingConfig struct {
+	Algorithm         string
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+	Argon2SaltLength  uint32
+	Argon2KeyLength   uint32
+	BcryptCost        int
+}
 
-	if os.Getenv("GO_ENVIRONMENT") == constants.ScopeBeta {
-		DBConfig = ConnectionConfig{
-			Username:           "beta_user",
-			Password:           "beta_password",
-			Host:               "beta-host:3306",
-			Name:               "beta_database",
-			Port:               "5432",
-			MaxIdleConnections: MaxIdleConnections,
-			MaxOpenConnections: MaxOpenConnections,
-			ConnMaxLifetime:    time.Second * ConnMaxLifetime,
-			ConnMaxIdleTime:    time.Second * ConnMaxIdleTime,
-			MaxBatchSize:       MaxBatchSize,
-		}
-	}
+// MailDeliveryConfig elige cómo salen los emails: Transport es "smtp", "file" (un .eml por email en OutputDir) o
+// "log". Los emails se encolan en la tabla outbox y un proceso en segundo plano los envía cada OutboxInterval,
+// reintentando con espera exponencial desde RetryBaseDelay hasta MaxAttempts. AppURL es la base de los enlaces.
+// SMTPTimeout acota cada envío por SMTP, desde la conexión hasta el QUIT.
+type MailDeliveryConfig struct {
+	Transport       string
+	From            string
+	FromName        string
+	AppURL          string
+	SMTPHost        string
+	SMTPPort        string
+	SMTPUsername    string
+	SMTPPassword    string
+	SMTPTimeout     time.Duration
+	OutputDir       string
+	OutboxInterval  time.Duration
+	OutboxBatchSize int
+	MaxAttempts     int
+	RetryBaseDelay  time.Duration
+}
+
+// MessageDeliveryConfig elige cómo salen los códigos por SMS y WhatsApp: Provider es "http" (la API del proveedor
+// en BaseURL) o "log". DefaultCountryCode completa los números escritos sin prefijo internacional; vacío exige
+// el prefijo. Cada número recibe como máximo MaxCodesPerNumber códigos por CodeWindow.
+type MessageDeliveryConfig struct {
+	Provider           string
+	BaseURL            string
+	APIKey             string
+	SenderID           string
+	DefaultCountryCode string
+	MaxCodesPerNumber  int
+	CodeWindow         time.Duration
+}
 
-	if os.Getenv("GO_ENVIRONMENT") == constants.ScopeProd {
-		DBConfig = ConnectionConfig{
-			Username:           "prod_user",
-			Password:           "prod_password",
-			Host:               "prod-host:3306",
-			Name:               "prod_database",
-			Port:               "5432",
-			MaxIdleConnections: MaxIdleConnections,
-			MaxOpenConnections: MaxOpenConnections,
-			ConnMaxLifetime:    time.Second * ConnMaxLifetime,
-			ConnMaxIdleTime:    time.Second * ConnMaxIdleTime,
-			MaxBatchSize:       MaxBatchSize,
-		}
-	}
+// IdentityProviderConfig describe un proveedor externo de login social. SubjectClaim es el campo del userinfo con
+// el identificador estable del usuario; TrustEmail da el email por verificado aunque el proveedor no publique
+// email_verified. LinkVerifiedEmail permite vincular la identidad a una cuenta local con el mismo email verificado
+// y AllowSignup crear cuentas nuevas. Un proveedor sin ClientID queda deshabilitado.
+type IdentityProviderConfig struct {
+	ClientID          string
+	ClientSecret      string
+	AuthorizationURL  string
+	TokenURL          string
+	UserInfoURL       string
+	UserInfoParams    map[string]string
+	RedirectURL       string
+	Scopes            []string
+	SubjectClaim      string
+	TrustEmail        bool
+	LinkVerifiedEmail bool
+	AllowSignup       bool
 }
@@ -0,0 +1,177 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+)
+
+// Defaults devuelve los valores por defecto de cada entorno; "" y "test" usan los de local salvo el transporte de
+// email. Ningún secreto tiene valor por defecto fuera de local: llegan por variables de entorno, por el archivo de
+// configuración o por archivos de secretos.
+func Defaults(environment string) *Config {
+	cfg := &Config{
+		Environment: environment,
+		Secrets: SecretProviderConfig{
+			Provider:        constants.SecretProviderEnv,
+			Directory:       "/run/secrets",
+			VaultMount:      "secret",
+			CacheTTL:        5 * time.Minute,
+			RefreshInterval: time.Minute,
+		},
+		Database: ConnectionConfig{
+			Port:               "5432",
+			MaxIdleConnections: 500,
+			MaxOpenConnections: 500,
+			MaxBatchSize:       100,
+			MaxRetries:         3,
+			ConnMaxLifetime:    600 * time.Second,
+			ConnMaxIdleTime:    600 * time.Second,
+		},
+		Rusty: RustyClientConfig{
+			DefaultTimeOut: 11 * time.Second,
+			RetryCount:     3,
+		},
+		Auth: AuthTokenConfig{
+			Issuer:                   constants.NameApp,
+			AccessTokenTTL:           time.Duration(constants.ShortTermUserExpirationTime) * time.Hour,
+			RefreshTokenTTL:          time.Duration(constants.LongTermUserExpirationTime) * time.Second,
+			SigningAlgorithm:         "RS256",
+			KeyRotationInterval:      30 * 24 * time.Hour,
+			KeyPublishAhead:          10 * time.Minute,
+			KeyRotationCheckInterval: 10 * time.Minute,
+		},
+		WebAuthn: WebAuthnRelyingPartyConfig{
+			RPDisplayName: "Tareaya",
+			Timeout:       5 * time.Minute,
+		},
+		IdentityProviders: identityProviders(),
+		Lockout: LoginLockoutConfig{
+			MaxAccountFailures: 10,
+			MaxIPFailures:      50,
+			DelayAfterFailures: 3,
+			BaseDelay:          time.Second,
+			MaxDelay:           time.Minute,
+			FailureWindow:      30 * time.Minute,
+			LockoutDuration:    15 * time.Minute,
+		},
+		RateLimitPolicies: map[string]RateLimitPolicy{
+			constants.RateLimitPolicyLogin:      {Requests: 20, Period: time.Minute, KeyBy: constants.RateLimitKeyByIP},
+			constants.RateLimitPolicySignup:     {Requests: 10, Period: time.Hour, KeyBy: constants.RateLimitKeyByIP},
+			constants.RateLimitPolicyPassword:   {Requests: 5, Period: 15 * time.Minute, KeyBy: constants.RateLimitKeyByIP},
+			constants.RateLimitPolicyCodeResend: {Requests: 3, Period: 10 * time.Minute, KeyBy: constants.RateLimitKeyByIP},
+			constants.RateLimitPolicyMFA:        {Requests: 10, Period: time.Minute, KeyBy: constants.RateLimitKeyByUser},
+			constants.RateLimitPolicyOAuthToken: {Requests: 120, Period: time.Minute, Burst: 30, KeyBy: constants.RateLimitKeyByClient},
+			constants.RateLimitPolicyPublicKeys: {Requests: 600, Period: time.Minute, KeyBy: constants.RateLimitKeyByIP},
+			constants.RateLimitPolicyRefresh:    {Requests: 30, Period: time.Minute, KeyBy: constants.RateLimitKeyByIP},
+		},
+		PasswordPolicy: PasswordPolicyConfig{
+			MinLength:            10,
+			MaxLength:            72,
+			MinCharacterClasses:  3,
+			DisallowPersonalInfo: true,
+			HistorySize:          5,
+		},
+		PasswordHashing: PasswordHashingConfig{
+			Algorithm:         constants.PasswordHashArgon2id,
+			Argon2Memory:      64 * 1024,
+			Argon2Iterations:  3,
+			Argon2Parallelism: 2,
+			Argon2SaltLength:  16,
+			Argon2KeyLength:   32,
+			BcryptCost:        12,
+		},
+		Mailer: MailDeliveryConfig{
+			Transport:       constants.MailTransportLog,
+			From:            constants.UserNameSender,
+			FromName:        "Tareaya",
+			SMTPPort:        "587",
+			SMTPUsername:    constants.EmailFromNotifications,
+			SMTPTimeout:     30 * time.Second,
+			OutboxInterval:  5 * time.Second,
+			OutboxBatchSize: 50,
+			MaxAttempts:     8,
+			RetryBaseDelay:  30 * time.Second,
+		},
+		Messaging: MessageDeliveryConfig{
+			Provider:          constants.MessagingProviderLog,
+			SenderID:          "Tareaya",
+			MaxCodesPerNumber: 3,
+			CodeWindow:        15 * time.Minute,
+		},
+	}
+
+	switch environment {
+	case constants.ScopeBeta:
+		cfg.Secrets.Provider = constants.SecretProviderFile
+		cfg.Database.Host = "beta-host"
+		cfg.Database.Name = "beta_database"
+		cfg.Database.Username = "beta_user"
+		cfg.Database.PasswordSecret = "db_password"
+		cfg.Auth.PublicURL = "https://auth.beta.tareaya.com"
+		cfg.WebAuthn.RPID = "beta.tareaya.com"
+		cfg.WebAuthn.RPOrigins = []string{"https://beta.tareaya.com"}
+		cfg.Mailer.Transport = constants.MailTransportSMTP
+		cfg.Mailer.SMTPHost = "smtp.gmail.com"
+		cfg.Mailer.AppURL = "https://beta.tareaya.com"
+		cfg.Messaging.Provider = constants.MessagingProviderHTTP
+	case constants.ScopeProd:
+		cfg.Secrets.Provider = constants.SecretProviderFile
+		cfg.Database.Host = "prod-host"
+		cfg.Database.Name = "prod_database"
+		cfg.Database.Username = "prod_user"
+		cfg.Database.PasswordSecret = "db_password"
+		cfg.Auth.PublicURL = "https://auth.tareaya.com"
+		cfg.WebAuthn.RPID = "tareaya.com"
+		cfg.WebAuthn.RPOrigins = []string{"https://tareaya.com", "https://app.tareaya.com"}
+		cfg.Mailer.Transport = constants.MailTransportSMTP
+		cfg.Mailer.SMTPHost = "smtp.gmail.com"
+		cfg.Mailer.AppURL = "https://tareaya.com"
+		cfg.Messaging.Provider = constants.MessagingProviderHTTP
+	default:
+		cfg.Database.Host = "localhost"
+		cfg.Database.Name = "local_tareaya"
+		cfg.Database.Username = "postgres"
+		cfg.Database.Password = "root"
+		cfg.Auth.PublicURL = "http://localhost:8080"
+		cfg.WebAuthn.RPID = "localhost"
+		cfg.WebAuthn.RPOrigins = []string{"http://localhost:3000", "http://localhost:8080"}
+		cfg.Mailer.AppURL = "http://localhost:3000"
+		if environment == constants.ScopeLocal {
+			cfg.Mailer.Transport = constants.MailTransportFile
+			cfg.Mailer.OutputDir = filepath.Join(os.TempDir(), "tareaya-mail")
+		}
+		cfg.PasswordPolicy.MinLength = 8
+		cfg.PasswordPolicy.MinCharacterClasses = 1
+		cfg.PasswordPolicy.HistorySize = 0
+	}
+	return cfg
+}
+
+// identityProviders arma los proveedores sociales sin credenciales; el RedirectURL lo completa Load a partir de
+// la URL del frontend, que recibe el callback y lo reenvía al servicio.
+func identityProviders() map[string]IdentityProviderConfig {
+	return map[string]IdentityProviderConfig{
+		constants.IdentityProviderGoogle: {
+			AuthorizationURL:  "https://accounts.google.com/o/oauth2/v2/auth",
+			TokenURL:          "https://oauth2.googleapis.com/token",
+			UserInfoURL:       "https://openidconnect.googleapis.com/v1/userinfo",
+			Scopes:            []string{"openid", "email", "profile"},
+			SubjectClaim:      "sub",
+			LinkVerifiedEmail: true,
+			AllowSignup:       true,
+		},
+		constants.IdentityProviderFacebook: {
+			AuthorizationURL: "https://www.facebook.com/v19.0/dialog/oauth",
+			TokenURL:         "https://graph.facebook.com/v19.0/oauth/access_token",
+			UserInfoURL:      "https://graph.facebook.com/v19.0/me",
+			UserInfoParams:   map[string]string{"fields": "id,name,email"},
+			Scopes:           []string{"email", "public_profile"},
+			SubjectClaim:     "id",
+			TrustEmail:       true,
+			AllowSignup:      true,
+		},
+	}
+}
@@ -0,0 +1,335 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"gopkg.in/yaml.v2"
+)
+
+const (
+	environmentKey   = "GO_ENVIRONMENT"
+	configFileKey    = "CONFIG_FILE"
+	secretFileSuffix = "_FILE"
+)
+
+// setting asocia una clave de configuración con el campo que pisa.
+type setting struct {
+	key   string
+	apply func(cfg *Config, value string) error
+}
+
+// settings son todas las claves aceptadas, tanto en el archivo como en las variables de entorno.
+var settings = slices.Concat([]setting{
+	bind("TRUSTED_PROXIES", parseList, func(c *Config) *[]string { return &c.Server.TrustedProxies }),
+
+	bind("SECRETS_PROVIDER", parseString, func(c *Config) *string { return &c.Secrets.Provider }),
+	bind("SECRETS_DIR", parseString, func(c *Config) *string { return &c.Secrets.Directory }),
+	bind("SECRETS_CACHE_TTL", time.ParseDuration, func(c *Config) *time.Duration { return &c.Secrets.CacheTTL }),
+	bind("SECRETS_REFRESH_INTERVAL", time.ParseDuration, func(c *Config) *time.Duration { return &c.Secrets.RefreshInterval }),
+	bind("VAULT_ADDR", parseString, func(c *Config) *string { return &c.Secrets.VaultURL }),
+	bind("VAULT_TOKEN", parseString, func(c *Config) *string { return &c.Secrets.VaultToken }),
+	bind("VAULT_MOUNT", parseString, func(c *Config) *string { return &c.Secrets.VaultMount }),
+
+	bind("DB_HOST", parseString, func(c *Config) *string { return &c.Database.Host }),
+	bind("DB_PORT", parseString, func(c *Config) *string { return &c.Database.Port }),
+	bind("DB_NAME", parseString, func(c *Config) *string { return &c.Database.Name }),
+	bind("DB_USERNAME", parseString, func(c *Config) *string { return &c.Database.Username }),
+	bind("DB_PASSWORD", parseString, func(c *Config) *string { return &c.Database.Password }),
+	bind("DB_PASSWORD_SECRET", parseString, func(c *Config) *string { return &c.Database.PasswordSecret }),
+	bind("DB_MAX_IDLE_CONNECTIONS", strconv.Atoi, func(c *Config) *int { return &c.Database.MaxIdleConnections }),
+	bind("DB_MAX_OPEN_CONNECTIONS", strconv.Atoi, func(c *Config) *int { return &c.Database.MaxOpenConnections }),
+	bind("DB_MAX_BATCH_SIZE", strconv.Atoi, func(c *Config) *int { return &c.Database.MaxBatchSize }),
+	bind("DB_MAX_RETRIES", strconv.Atoi, func(c *Config) *int { return &c.Database.MaxRetries }),
+	bind("DB_CONN_MAX_LIFETIME", time.ParseDuration, func(c *Config) *time.Duration { return &c.Database.ConnMaxLifetime }),
+	bind("DB_CONN_MAX_IDLE_TIME", time.ParseDuration, func(c *Config) *time.Duration { return &c.Database.ConnMaxIdleTime }),
+
+	bind("RUSTY_TIMEOUT", time.ParseDuration, func(c *Config) *time.Duration { return &c.Rusty.DefaultTimeOut }),
+	bind("RUSTY_RETRY_COUNT", strconv.Atoi, func(c *Config) *int { return &c.Rusty.RetryCount }),
+
+	bind("AUTH_ISSUER", parseString, func(c *Config) *string { return &c.Auth.Issuer }),
+	bind("AUTH_PUBLIC_URL", parseString, func(c *Config) *string { return &c.Auth.PublicURL }),
+	bind("AUTH_ACCESS_TOKEN_TTL", time.ParseDuration, func(c *Config) *time.Duration { return &c.Auth.AccessTokenTTL }),
+	bind("AUTH_REFRESH_TOKEN_TTL", time.ParseDuration, func(c *Config) *time.Duration { return &c.Auth.RefreshTokenTTL }),
+	bind("AUTH_SIGNING_ALGORITHM", parseString, func(c *Config) *string { return &c.Auth.SigningAlgorithm }),
+	bind("AUTH_SIGNING_KEY_SECRET", parseString, func(c *Config) *string { return &c.Auth.SigningKeySecret }),
+	bind("AUTH_KEY_ROTATION_INTERVAL", time.ParseDuration, func(c *Config) *time.Duration { return &c.Auth.KeyRotationInterval }),
+	bind("AUTH_KEY_PUBLISH_AHEAD", time.ParseDuration, func(c *Config) *time.Duration { return &c.Auth.KeyPublishAhead }),
+	bind("AUTH_KEY_ROTATION_CHECK_INTERVAL", time.ParseDuration, func(c *Config) *time.Duration { return &c.Auth.KeyRotationCheckInterval }),
+
+	bind("WEBAUTHN_RP_ID", parseString, func(c *Config) *string { return &c.WebAuthn.RPID }),
+	bind("WEBAUTHN_RP_DISPLAY_NAME", parseString, func(c *Config) *string { return &c.WebAuthn.RPDisplayName }),
+	bind("WEBAUTHN_RP_ORIGINS", parseList, func(c *Config) *[]string { return &c.WebAuthn.RPOrigins }),
+	bind("WEBAUTHN_TIMEOUT", time.ParseDuration, func(c *Config) *time.Duration { return &c.WebAuthn.Timeout }),
+
+	bindIdentityProvider("GOOGLE_CLIENT_ID", constants.IdentityProviderGoogle, func(p *IdentityProviderConfig) *string { return &p.ClientID }),
+	bindIdentityProvider("GOOGLE_CLIENT_SECRET", constants.IdentityProviderGoogle, func(p *IdentityProviderConfig) *string { return &p.ClientSecret }),
+	bindIdentityProvider("FACEBOOK_CLIENT_ID", constants.IdentityProviderFacebook, func(p *IdentityProviderConfig) *string { return &p.ClientID }),
+	bindIdentityProvider("FACEBOOK_CLIENT_SECRET", constants.IdentityProviderFacebook, func(p *IdentityProviderConfig) *string { return &p.ClientSecret }),
+
+	bind("LOCKOUT_MAX_ACCOUNT_FAILURES", strconv.Atoi, func(c *Config) *int { return &c.Lockout.MaxAccountFailures }),
+	bind("LOCKOUT_MAX_IP_FAILURES", strconv.Atoi, func(c *Config) *int { return &c.Lockout.MaxIPFailures }),
+	bind("LOCKOUT_DELAY_AFTER_FAILURES", strconv.Atoi, func(c *Config) *int { return &c.Lockout.DelayAfterFailures }),
+	bind("LOCKOUT_BASE_DELAY", time.ParseDuration, func(c *Config) *time.Duration { return &c.Lockout.BaseDelay }),
+	bind("LOCKOUT_MAX_DELAY", time.ParseDuration, func(c *Config) *time.Duration { return &c.Lockout.MaxDelay }),
+	bind("LOCKOUT_FAILURE_WINDOW", time.ParseDuration, func(c *Config) *time.Duration { return &c.Lockout.FailureWindow }),
+	bind("LOCKOUT_DURATION", time.ParseDuration, func(c *Config) *time.Duration { return &c.Lockout.LockoutDuration }),
+
+	bind("PASSWORD_MIN_LENGTH", strconv.Atoi, func(c *Config) *int { return &c.PasswordPolicy.MinLength }),
+	bind("PASSWORD_MAX_LENGTH", strconv.Atoi, func(c *Config) *int { return &c.PasswordPolicy.MaxLength }),
+	bind("PASSWORD_MIN_CHARACTER_CLASSES", strconv.Atoi, func(c *Config) *int { return &c.PasswordPolicy.MinCharacterClasses }),
+	bind("PASSWORD_DISALLOW_PERSONAL_INFO", strconv.ParseBool, func(c *Config) *bool { return &c.PasswordPolicy.DisallowPersonalInfo }),
+	bind("PASSWORD_HISTORY_SIZE", strconv.Atoi, func(c *Config) *int { return &c.PasswordPolicy.HistorySize }),
+	bind("BREACHED_PASSWORDS_FILE", parseString, func(c *Config) *string { return &c.PasswordPolicy.BreachedPasswordsFile }),
+
+	bind("PASSWORD_HASH_ALGORITHM", parseString, func(c *Config) *string { return &c.PasswordHashing.Algorithm }),
+	bind("ARGON2_MEMORY", parseUint32, func(c *Config) *uint32 { return &c.PasswordHashing.Argon2Memory }),
+	bind("ARGON2_ITERATIONS", parseUint32, func(c *Config) *uint32 { return &c.PasswordHashing.Argon2Iterations }),
+	bind("ARGON2_PARALLELISM", parseUint8, func(c *Config) *uint8 { return &c.PasswordHashing.Argon2Parallelism }),
+	bind("ARGON2_SALT_LENGTH", parseUint32, func(c *Config) *uint32 { return &c.PasswordHashing.Argon2SaltLength }),
+	bind("ARGON2_KEY_LENGTH", parseUint32, func(c *Config) *uint32 { return &c.PasswordHashing.Argon2KeyLength }),
+	bind("BCRYPT_COST", strconv.Atoi, func(c *Config) *int { return &c.PasswordHashing.BcryptCost }),
+
+	bind("MAILER_TRANSPORT", parseString, func(c *Config) *string { return &c.Mailer.Transport }),
+	bind("MAILER_FROM", parseString, func(c *Config) *string { return &c.Mailer.From }),
+	bind("MAILER_FROM_NAME", parseString, func(c *Config) *string { return &c.Mailer.FromName }),
+	bind("MAILER_APP_URL", parseString, func(c *Config) *string { return &c.Mailer.AppURL }),
+	bind("MAILER_OUTPUT_DIR", parseString, func(c *Config) *string { return &c.Mailer.OutputDir }),
+	bind("MAILER_OUTBOX_INTERVAL", time.ParseDuration, func(c *Config) *time.Duration { return &c.Mailer.OutboxInterval }),
+	bind("MAILER_OUTBOX_BATCH_SIZE", strconv.Atoi, func(c *Config) *int { return &c.Mailer.OutboxBatchSize }),
+	bind("MAILER_MAX_ATTEMPTS", strconv.Atoi, func(c *Config) *int { return &c.Mailer.MaxAttempts }),
+	bind("MAILER_RETRY_BASE_DELAY", time.ParseDuration, func(c *Config) *time.Duration { return &c.Mailer.RetryBaseDelay }),
+	bind("SMTP_HOST", parseString, func(c *Config) *string { return &c.Mailer.SMTPHost }),
+	bind("SMTP_PORT", parseString, func(c *Config) *string { return &c.Mailer.SMTPPort }),
+	bind("SMTP_USERNAME", parseString, func(c *Config) *string { return &c.Mailer.SMTPUsername }),
+	bind("SMTP_PASSWORD", parseString, func(c *Config) *string { return &c.Mailer.SMTPPassword }),
+	bind("SMTP_TIMEOUT", time.ParseDuration, func(c *Config) *time.Duration { return &c.Mailer.SMTPTimeout }),
+
+	bind("MESSAGING_PROVIDER", parseString, func(c *Config) *string { return &c.Messaging.Provider }),
+	bind("MESSAGING_PROVIDER_URL", parseString, func(c *Config) *string { return &c.Messaging.BaseURL }),
+	bind("MESSAGING_API_KEY", parseString, func(c *Config) *string { return &c.Messaging.APIKey }),
+	bind("MESSAGING_SENDER_ID", parseString, func(c *Config) *string { return &c.Messaging.SenderID }),
+	bind("MESSAGING_DEFAULT_COUNTRY_CODE", parseString, func(c *Config) *string { return &c.Messaging.DefaultCountryCode }),
+	bind("MESSAGING_MAX_CODES_PER_NUMBER", strconv.Atoi, func(c *Config) *int { return &c.Messaging.MaxCodesPerNumber }),
+	bind("MESSAGING_CODE_WINDOW", time.ParseDuration, func(c *Config) *time.Duration { return &c.Messaging.CodeWindow }),
+}, rateLimitSettings())
+
+// Load arma la configuración en tres capas, de menor a mayor prioridad: los valores por defecto de
+// GO_ENVIRONMENT, el archivo YAML o JSON indicado en CONFIG_FILE y las variables de entorno. El archivo usa las
+// mismas claves que las variables. Cada clave admite además la variante <CLAVE>_FILE con la ruta de un archivo
+// cuyo contenido es el valor, para los secretos de Docker y Kubernetes. Un valor vacío cuenta como no definido.
+// Devuelve juntos todos los errores de lectura y de validación.
+func Load() (*Config, error) {
+	return load(os.LookupEnv)
+}
+
+func load(lookupEnv func(string) (string, bool)) (*Config, error) {
+	environment, _ := lookupEnv(environmentKey)
+	switch environment {
+	case "", "test", constants.ScopeLocal, constants.ScopeBeta, constants.ScopeProd:
+	default:
+		return nil, fmt.Errorf("unknown %s %q", environmentKey, environment)
+	}
+	cfg := Defaults(environment)
+
+	fileValues := map[string]string{}
+	if path, ok := lookupEnv(configFileKey); ok && path != "" {
+		var err error
+		if fileValues, err = readConfigFile(path); err != nil {
+			return nil, err
+		}
+	}
+	lookupFile := func(key string) (string, bool) {
+		value, ok := fileValues[key]
+		return value, ok
+	}
+
+	var errs []error
+	known := make(map[string]bool, len(settings))
+	for _, s := range settings {
+		known[s.key] = true
+
+		value, found, err := resolve(s.key, lookupEnv, lookupFile)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !found {
+			continue
+		}
+		if err := s.apply(cfg, value); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for key := range fileValues {
+		if !known[strings.TrimSuffix(key, secretFileSuffix)] {
+			errs = append(errs, fmt.Errorf("%s: unknown key %s", configFileKey, key))
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	for name, provider := range cfg.IdentityProviders {
+		provider.RedirectURL = strings.TrimSuffix(cfg.Mailer.AppURL, "/") + "/auth/callback/" + name
+		cfg.IdentityProviders[name] = provider
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// resolve busca la clave en las fuentes en orden de prioridad y se queda con la primera que la define, como valor
+// o como archivo de secreto. Definir ambas variantes en la misma fuente es ambiguo y se rechaza.
+func resolve(key string, sources ...func(string) (string, bool)) (string, bool, error) {
+	for _, lookup := range sources {
+		value, _ := lookup(key)
+		path, _ := lookup(key + secretFileSuffix)
+
+		switch {
+		case value != "" && path != "":
+			return "", false, fmt.Errorf("%s and %s%s are both set", key, key, secretFileSuffix)
+		case value != "":
+			return value, true, nil
+		case path != "":
+			secret, err := os.ReadFile(path)
+			if err != nil {
+				return "", false, fmt.Errorf("%s%s: %w", key, secretFileSuffix, err)
+			}
+			return strings.TrimRight(string(secret), "\r\n"), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// readConfigFile lee un archivo plano de claves; JSON es un subconjunto de YAML, así que sirve el mismo parser.
+// Las listas se aceptan como arreglos o como texto separado por comas.
+func readConfigFile(path string) (map[string]string, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", configFileKey, err)
+	}
+
+	var raw map[string]interface{}
+	if err := yaml.Unmarshal(data, &raw); err != nil {
+		return nil, fmt.Errorf("%s: %w", configFileKey, err)
+	}
+
+	values := make(map[string]string, len(raw))
+	for key, value := range raw {
+		text, err := fileValue(value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", configFileKey, key, err)
+		}
+		values[key] = text
+	}
+	return values, nil
+}
+
+func fileValue(value interface{}) (string, error) {
+	switch v := value.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	case bool, int, int64, uint64, float64:
+		return fmt.Sprint(v), nil
+	case []interface{}:
+		items := make([]string, 0, len(v))
+		for _, item := range v {
+			text, err := fileValue(item)
+			if err != nil {
+				return "", err
+			}
+			items = append(items, text)
+		}
+		return strings.Join(items, ","), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", value)
+	}
+}
+
+func bind[T any](key string, parse func(string) (T, error), field func(cfg *Config) *T) setting {
+	return setting{key: key, apply: func(cfg *Config, value string) error {
+		parsed, err := parse(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		*field(cfg) = parsed
+		return nil
+	}}
+}
+
+// bindIdentityProvider pisa un campo de un proveedor social; los valores del mapa no son direccionables.
+func bindIdentityProvider(key, name string, field func(provider *IdentityProviderConfig) *string) setting {
+	return setting{key: key, apply: func(cfg *Config, value string) error {
+		provider := cfg.IdentityProviders[name]
+		*field(&provider) = value
+		cfg.IdentityProviders[name] = provider
+		return nil
+	}}
+}
+
+// rateLimitSettings expone cada política de rate limiting como RATE_LIMIT_<POLÍTICA>_REQUESTS, _PERIOD, _BURST y
+// _KEY_BY; por ejemplo RATE_LIMIT_OAUTH_TOKEN_BURST.
+func rateLimitSettings() []setting {
+	var result []setting
+	for _, name := range slices.Sorted(maps.Keys(Defaults("").RateLimitPolicies)) {
+		prefix := "RATE_LIMIT_" + strings.ToUpper(name) + "_"
+		result = append(result,
+			bindRateLimitPolicy(prefix+"REQUESTS", name, strconv.Atoi, func(p *RateLimitPolicy) *int { return &p.Requests }),
+			bindRateLimitPolicy(prefix+"PERIOD", name, time.ParseDuration, func(p *RateLimitPolicy) *time.Duration { return &p.Period }),
+			bindRateLimitPolicy(prefix+"BURST", name, strconv.Atoi, func(p *RateLimitPolicy) *int { return &p.Burst }),
+			bindRateLimitPolicy(prefix+"KEY_BY", name, parseString, func(p *RateLimitPolicy) *string { return &p.KeyBy }),
+		)
+	}
+	return result
+}
+
+// bindRateLimitPolicy pisa un campo de una política; como en los proveedores sociales, los valores del mapa no
+// son direccionables.
+func bindRateLimitPolicy[T any](key, name string, parse func(string) (T, error), field func(policy *RateLimitPolicy) *T) setting {
+	return setting{key: key, apply: func(cfg *Config, value string) error {
+		parsed, err := parse(value)
+		if err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+		policy := cfg.RateLimitPolicies[name]
+		*field(&policy) = parsed
+		cfg.RateLimitPolicies[name] = policy
+		return nil
+	}}
+}
+
+func parseString(value string) (string, error) {
+	return value, nil
+}
+
+func parseList(value string) ([]string, error) {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+func parseUint32(value string) (uint32, error) {
+	parsed, err := strconv.ParseUint(value, 10, 32)
+	return uint32(parsed), err
+}
+
+func parseUint8(value string) (uint8, error) {
+	parsed, err := strconv.ParseUint(value, 10, 8)
+	return uint8(parsed), err
+}
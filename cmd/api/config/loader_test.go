@@ -0,0 +1,203 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+)
+
+func lookupFrom(env map[string]string) func(string) (string, bool) {
+	return func(key string) (string, bool) {
+		value, ok := env[key]
+		return value, ok
+	}
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+// prodSecrets son los secretos que producción exige; sin ellos Load falla. La contraseña de la base no está:
+// se lee del proveedor de secretos.
+func prodSecrets() map[string]string {
+	return map[string]string{
+		"GO_ENVIRONMENT":         constants.ScopeProd,
+		"SMTP_PASSWORD":          "smtp-secret",
+		"MESSAGING_PROVIDER_URL": "https://sms.example.com",
+		"MESSAGING_API_KEY":      "sms-key",
+	}
+}
+
+func TestLoad(t *testing.T) {
+
+	t.Run("local works with the defaults", func(t *testing.T) {
+		cfg, err := load(lookupFrom(map[string]string{"GO_ENVIRONMENT": constants.ScopeLocal}))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Database.Host != "localhost" || cfg.Mailer.Transport != constants.MailTransportFile {
+			t.Errorf("unexpected local defaults: %+v", cfg)
+		}
+		if got := cfg.IdentityProviders[constants.IdentityProviderGoogle].RedirectURL; got != "http://localhost:3000/auth/callback/google" {
+			t.Errorf("unexpected redirect url: %q", got)
+		}
+	})
+
+	t.Run("production requires its secrets", func(t *testing.T) {
+		_, err := load(lookupFrom(map[string]string{"GO_ENVIRONMENT": constants.ScopeProd}))
+		if err == nil {
+			t.Fatal("expected an error")
+		}
+		for _, key := range []string{"SMTP_PASSWORD", "MESSAGING_PROVIDER_URL", "MESSAGING_API_KEY"} {
+			if !strings.Contains(err.Error(), key) {
+				t.Errorf("expected %s in %q", key, err)
+			}
+		}
+
+		cfg, err := load(lookupFrom(prodSecrets()))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Database.Password != "" || cfg.Database.PasswordSecret != "db_password" || cfg.Secrets.Provider != constants.SecretProviderFile {
+			t.Errorf("expected the database password to come from the secret provider: %+v", cfg.Database)
+		}
+	})
+
+	t.Run("environment variables override the file and the file overrides the defaults", func(t *testing.T) {
+		env := prodSecrets()
+		env["CONFIG_FILE"] = writeFile(t, "config.yaml", strings.Join([]string{
+			"DB_HOST: db.internal",
+			"DB_NAME: from_file",
+			"DB_MAX_RETRIES: 5",
+			"MAILER_OUTBOX_INTERVAL: 30s",
+			"WEBAUTHN_RP_ORIGINS: [https://tareaya.com, https://m.tareaya.com]",
+		}, "\n"))
+		env["DB_NAME"] = "from_env"
+
+		cfg, err := load(lookupFrom(env))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Database.Host != "db.internal" || cfg.Database.Name != "from_env" || cfg.Database.MaxRetries != 5 {
+			t.Errorf("unexpected database config: %+v", cfg.Database)
+		}
+		if cfg.Mailer.OutboxInterval != 30*time.Second || cfg.Database.Username != "prod_user" {
+			t.Errorf("expected the file to keep the remaining defaults: %+v", cfg.Mailer)
+		}
+		if len(cfg.WebAuthn.RPOrigins) != 2 || cfg.WebAuthn.RPOrigins[1] != "https://m.tareaya.com" {
+			t.Errorf("unexpected origins: %v", cfg.WebAuthn.RPOrigins)
+		}
+	})
+
+	t.Run("reads json files", func(t *testing.T) {
+		env := prodSecrets()
+		env["CONFIG_FILE"] = writeFile(t, "config.json", `{"RUSTY_RETRY_COUNT": 1, "PASSWORD_DISALLOW_PERSONAL_INFO": false}`)
+
+		cfg, err := load(lookupFrom(env))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Rusty.RetryCount != 1 || cfg.PasswordPolicy.DisallowPersonalInfo {
+			t.Errorf("unexpected config: %+v %+v", cfg.Rusty, cfg.PasswordPolicy)
+		}
+	})
+
+	t.Run("overrides rate limit policies", func(t *testing.T) {
+		env := prodSecrets()
+		env["CONFIG_FILE"] = writeFile(t, "config.yaml", strings.Join([]string{
+			"RATE_LIMIT_LOGIN_REQUESTS: 50",
+			"RATE_LIMIT_OAUTH_TOKEN_BURST: 60",
+		}, "\n"))
+		env["RATE_LIMIT_LOGIN_PERIOD"] = "30s"
+		env["RATE_LIMIT_MFA_KEY_BY"] = constants.RateLimitKeyByIP
+
+		cfg, err := load(lookupFrom(env))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		login := cfg.RateLimitPolicies[constants.RateLimitPolicyLogin]
+		if login.Requests != 50 || login.Period != 30*time.Second || login.KeyBy != constants.RateLimitKeyByIP {
+			t.Errorf("unexpected login policy: %+v", login)
+		}
+		if token := cfg.RateLimitPolicies[constants.RateLimitPolicyOAuthToken]; token.Burst != 60 || token.Requests != 120 {
+			t.Errorf("unexpected oauth token policy: %+v", token)
+		}
+		if mfa := cfg.RateLimitPolicies[constants.RateLimitPolicyMFA]; mfa.KeyBy != constants.RateLimitKeyByIP {
+			t.Errorf("unexpected mfa policy: %+v", mfa)
+		}
+	})
+
+	t.Run("reads secrets from files", func(t *testing.T) {
+		env := prodSecrets()
+		env["DB_PASSWORD_FILE"] = writeFile(t, "db_password", "mounted-secret\n")
+		env["CONFIG_FILE"] = writeFile(t, "config.yaml", "SMTP_PASSWORD_FILE: "+writeFile(t, "smtp_password", "smtp-from-file"))
+		delete(env, "SMTP_PASSWORD")
+
+		cfg, err := load(lookupFrom(env))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Database.Password != "mounted-secret" || cfg.Mailer.SMTPPassword != "smtp-from-file" {
+			t.Errorf("unexpected secrets: %q %q", cfg.Database.Password, cfg.Mailer.SMTPPassword)
+		}
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		tests := map[string]map[string]string{
+			"both value and file":           {"DB_PASSWORD": "a", "DB_PASSWORD_FILE": "/run/secrets/db"},
+			"vault without token":           {"SECRETS_PROVIDER": constants.SecretProviderVault, "VAULT_ADDR": "https://vault.internal"},
+			"missing secret file":           {"DB_PASSWORD_FILE": filepath.Join(t.TempDir(), "missing")},
+			"invalid duration":              {"AUTH_ACCESS_TOKEN_TTL": "one hour"},
+			"unsupported provider":          {"MESSAGING_PROVIDER": "carrier-pigeon"},
+			"unknown file key":              {"CONFIG_FILE": writeFile(t, "config.yaml", "DB_HOTS: db.internal")},
+			"unknown environment":           {"GO_ENVIRONMENT": "staging"},
+			"invalid proxy range":           {"TRUSTED_PROXIES": "10.0.0.0/8,lb.internal"},
+			"unsupported signing algorithm": {"AUTH_SIGNING_ALGORITHM": "HS256"},
+			"zero argon2 parallelism":       {"ARGON2_PARALLELISM": "0"},
+			"zero argon2 iterations":        {"ARGON2_ITERATIONS": "0"},
+			"argon2 memory below threads":   {"ARGON2_MEMORY": "8", "ARGON2_PARALLELISM": "4"},
+			"short argon2 key":              {"ARGON2_KEY_LENGTH": "4"},
+			"bcrypt cost out of range":      {"PASSWORD_HASH_ALGORITHM": constants.PasswordHashBcrypt, "BCRYPT_COST": "3"},
+			"bcrypt with long passwords":    {"PASSWORD_HASH_ALGORITHM": constants.PasswordHashBcrypt, "PASSWORD_MAX_LENGTH": "128"},
+			"zero min password length":      {"PASSWORD_MIN_LENGTH": "0"},
+			"max below min password length": {"PASSWORD_MIN_LENGTH": "12", "PASSWORD_MAX_LENGTH": "8"},
+			"too many character classes":    {"PASSWORD_MIN_CHARACTER_CLASSES": "5"},
+			"zero codes per number":         {"MESSAGING_MAX_CODES_PER_NUMBER": "0"},
+			"invalid rate limit period":     {"RATE_LIMIT_LOGIN_PERIOD": "soon"},
+			"zero rate limit requests":      {"RATE_LIMIT_SIGNUP_REQUESTS": "0"},
+			"unsupported rate limit key":    {"RATE_LIMIT_MFA_KEY_BY": "country"},
+		}
+		for name, env := range tests {
+			if _, err := load(lookupFrom(env)); err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+		}
+	})
+
+	t.Run("rejects rate limit policies that cannot refill", func(t *testing.T) {
+		for name, policy := range map[string]RateLimitPolicy{
+			"zero period":     {Requests: 10, KeyBy: constants.RateLimitKeyByIP},
+			"zero requests":   {Period: time.Minute, KeyBy: constants.RateLimitKeyByIP},
+			"unsupported key": {Requests: 10, Period: time.Minute, KeyBy: "country"},
+			"negative burst":  {Requests: 10, Period: time.Minute, Burst: -1, KeyBy: constants.RateLimitKeyByIP},
+		} {
+			cfg, err := load(lookupFrom(map[string]string{"GO_ENVIRONMENT": constants.ScopeLocal}))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			cfg.RateLimitPolicies[constants.RateLimitPolicyLogin] = policy
+			if err := cfg.Validate(); err == nil {
+				t.Errorf("%s: expected an error", name)
+			}
+		}
+	})
+}
@@ -0,0 +1,172 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// bcryptMaxPasswordLength es el largo máximo en bytes que bcrypt acepta.
+const bcryptMaxPasswordLength = 72
+
+// Validate revisa que estén los campos obligatorios y que las opciones sean válidas, para que el servicio no
+// arranque con una configuración que recién fallaría al primer uso. Los errores nombran la clave a corregir.
+func (c *Config) Validate() error {
+	var errs []error
+	required := func(key, value string) {
+		if value == "" {
+			errs = append(errs, fmt.Errorf("%s is required", key))
+		}
+	}
+	positive := func(key string, value time.Duration) {
+		if value <= 0 {
+			errs = append(errs, fmt.Errorf("%s must be positive", key))
+		}
+	}
+	between := func(key string, value, min, max int) {
+		if value < min || value > max {
+			errs = append(errs, fmt.Errorf("%s must be between %d and %d", key, min, max))
+		}
+	}
+
+	for _, cidr := range c.Server.TrustedProxies {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			errs = append(errs, fmt.Errorf("TRUSTED_PROXIES %q is not a CIDR range", cidr))
+		}
+	}
+
+	positive("SECRETS_CACHE_TTL", c.Secrets.CacheTTL)
+	positive("SECRETS_REFRESH_INTERVAL", c.Secrets.RefreshInterval)
+	switch c.Secrets.Provider {
+	case constants.SecretProviderEnv:
+	case constants.SecretProviderFile:
+		required("SECRETS_DIR", c.Secrets.Directory)
+	case constants.SecretProviderVault:
+		required("VAULT_ADDR", c.Secrets.VaultURL)
+		required("VAULT_TOKEN", c.Secrets.VaultToken)
+		required("VAULT_MOUNT", c.Secrets.VaultMount)
+	default:
+		errs = append(errs, fmt.Errorf("SECRETS_PROVIDER %q is not supported", c.Secrets.Provider))
+	}
+
+	required("DB_HOST", c.Database.Host)
+	required("DB_NAME", c.Database.Name)
+	required("DB_USERNAME", c.Database.Username)
+	if c.Database.Password == "" && c.Database.PasswordSecret == "" {
+		errs = append(errs, errors.New("DB_PASSWORD or DB_PASSWORD_SECRET is required"))
+	}
+	if c.Database.MaxRetries <= 0 {
+		errs = append(errs, errors.New("DB_MAX_RETRIES must be positive"))
+	}
+
+	positive("RUSTY_TIMEOUT", c.Rusty.DefaultTimeOut)
+
+	required("AUTH_PUBLIC_URL", c.Auth.PublicURL)
+	positive("AUTH_ACCESS_TOKEN_TTL", c.Auth.AccessTokenTTL)
+	positive("AUTH_REFRESH_TOKEN_TTL", c.Auth.RefreshTokenTTL)
+	positive("AUTH_KEY_ROTATION_INTERVAL", c.Auth.KeyRotationInterval)
+	positive("AUTH_KEY_ROTATION_CHECK_INTERVAL", c.Auth.KeyRotationCheckInterval)
+	switch c.Auth.SigningAlgorithm {
+	case utils.AlgorithmRS256, utils.AlgorithmES256:
+	default:
+		errs = append(errs, fmt.Errorf("AUTH_SIGNING_ALGORITHM %q is not supported", c.Auth.SigningAlgorithm))
+	}
+
+	required("WEBAUTHN_RP_ID", c.WebAuthn.RPID)
+	if len(c.WebAuthn.RPOrigins) == 0 {
+		errs = append(errs, errors.New("WEBAUTHN_RP_ORIGINS is required"))
+	}
+	positive("WEBAUTHN_TIMEOUT", c.WebAuthn.Timeout)
+
+	for name, provider := range c.IdentityProviders {
+		if provider.ClientID != "" {
+			required(strings.ToUpper(name)+"_CLIENT_SECRET", provider.ClientSecret)
+		}
+	}
+
+	for name, policy := range c.RateLimitPolicies {
+		if policy.Requests <= 0 || policy.Period <= 0 || policy.Burst < 0 {
+			errs = append(errs, fmt.Errorf("rate limit policy %q needs positive requests and period and a non-negative burst", name))
+		}
+		switch policy.KeyBy {
+		case constants.RateLimitKeyByIP, constants.RateLimitKeyByUser, constants.RateLimitKeyByClient:
+		default:
+			errs = append(errs, fmt.Errorf("rate limit policy %q: key %q is not supported", name, policy.KeyBy))
+		}
+	}
+
+	// Sin máximo (0) la política no limita el largo.
+	if c.PasswordPolicy.MinLength < 1 {
+		errs = append(errs, errors.New("PASSWORD_MIN_LENGTH must be positive"))
+	}
+	if c.PasswordPolicy.MaxLength != 0 && c.PasswordPolicy.MaxLength < c.PasswordPolicy.MinLength {
+		errs = append(errs, errors.New("PASSWORD_MAX_LENGTH must not be lower than PASSWORD_MIN_LENGTH"))
+	}
+	between("PASSWORD_MIN_CHARACTER_CLASSES", c.PasswordPolicy.MinCharacterClasses, 0, 4)
+	if c.PasswordPolicy.HistorySize < 0 {
+		errs = append(errs, errors.New("PASSWORD_HISTORY_SIZE must not be negative"))
+	}
+
+	switch c.PasswordHashing.Algorithm {
+	case constants.PasswordHashArgon2id:
+		// argon2.IDKey entra en pánico con cero iteraciones o hilos.
+		if c.PasswordHashing.Argon2Iterations < 1 {
+			errs = append(errs, errors.New("ARGON2_ITERATIONS must be positive"))
+		}
+		if c.PasswordHashing.Argon2Parallelism < 1 {
+			errs = append(errs, errors.New("ARGON2_PARALLELISM must be positive"))
+		}
+		if c.PasswordHashing.Argon2Memory < 8*uint32(c.PasswordHashing.Argon2Parallelism) {
+			errs = append(errs, errors.New("ARGON2_MEMORY must be at least 8 KiB per ARGON2_PARALLELISM"))
+		}
+		if c.PasswordHashing.Argon2SaltLength < 8 {
+			errs = append(errs, errors.New("ARGON2_SALT_LENGTH must be at least 8 bytes"))
+		}
+		if c.PasswordHashing.Argon2KeyLength < 16 {
+			errs = append(errs, errors.New("ARGON2_KEY_LENGTH must be at least 16 bytes"))
+		}
+	case constants.PasswordHashBcrypt:
+		between("BCRYPT_COST", c.PasswordHashing.BcryptCost, bcrypt.MinCost, bcrypt.MaxCost)
+		if c.PasswordPolicy.MaxLength == 0 || c.PasswordPolicy.MaxLength > bcryptMaxPasswordLength {
+			errs = append(errs, fmt.Errorf("PASSWORD_MAX_LENGTH must be at most %d with bcrypt", bcryptMaxPasswordLength))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("PASSWORD_HASH_ALGORITHM %q is not supported", c.PasswordHashing.Algorithm))
+	}
+
+	required("MAILER_APP_URL", c.Mailer.AppURL)
+	positive("MAILER_OUTBOX_INTERVAL", c.Mailer.OutboxInterval)
+	positive("MAILER_RETRY_BASE_DELAY", c.Mailer.RetryBaseDelay)
+	switch c.Mailer.Transport {
+	case constants.MailTransportSMTP:
+		required("SMTP_HOST", c.Mailer.SMTPHost)
+		required("SMTP_PASSWORD", c.Mailer.SMTPPassword)
+		positive("SMTP_TIMEOUT", c.Mailer.SMTPTimeout)
+	case constants.MailTransportFile:
+		required("MAILER_OUTPUT_DIR", c.Mailer.OutputDir)
+	case constants.MailTransportLog:
+	default:
+		errs = append(errs, fmt.Errorf("MAILER_TRANSPORT %q is not supported", c.Mailer.Transport))
+	}
+
+	positive("MESSAGING_CODE_WINDOW", c.Messaging.CodeWindow)
+	if c.Messaging.MaxCodesPerNumber < 1 {
+		errs = append(errs, errors.New("MESSAGING_MAX_CODES_PER_NUMBER must be positive"))
+	}
+	switch c.Messaging.Provider {
+	case constants.MessagingProviderHTTP:
+		required("MESSAGING_PROVIDER_URL", c.Messaging.BaseURL)
+		required("MESSAGING_API_KEY", c.Messaging.APIKey)
+	case constants.MessagingProviderLog:
+	default:
+		errs = append(errs, fmt.Errorf("MESSAGING_PROVIDER %q is not supported", c.Messaging.Provider))
+	}
+
+	return errors.Join(errs...)
+}
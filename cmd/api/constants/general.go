@@ -4,7 +4,7 @@ const (
 	NameApp = "tareaya"
 )
 
-// Time Exp.
+// Time Exp. ShortTermUserExpirationTime y TimeCodeExpiration en horas, LongTermUserExpirationTime en segundos.
 const (
 	ShortTermUserExpirationTime = 1
 	LongTermUserExpirationTime  = 86400
@@ -15,12 +15,94 @@ const (
 const (
 	TemplateVerifyEmail   = "verify_email"
 	TemplatePasswordReset = "password_reset"
+	TemplateMagicLink     = "magic_link"
+	TemplateLoginCode     = "login_code"
+)
+
+// Transportes de email.
+const (
+	MailTransportSMTP = "smtp"
+	MailTransportFile = "file"
+	MailTransportLog  = "log"
 )
 
 // Plantilla Email.
 const (
-	EmailFromNotifications    = "wilson.valencia.06091988@gmail.com"
-	UserNameSender            = "notificacion@tareaya.com"
-	EmailSubjectVerifyEmail   = "Verificar email"
-	EmailSubjectResetPassword = "Restablecer contraseña"
+	EmailFromNotifications = "wilson.valencia.06091988@gmail.com"
+	UserNameSender         = "notificacion@tareaya.com"
+)
+
+// Idiomas de los emails. DefaultLocale se usa cuando ni el usuario ni la petición piden uno soportado.
+const (
+	LocaleSpanish    = "es"
+	LocaleEnglish    = "en"
+	LocalePortuguese = "pt"
+	DefaultLocale    = LocaleSpanish
+)
+
+// Verification codes.
+const (
+	VerificationCodeLength      = 6
+	VerificationTokenSize       = 32
+	MaxVerificationCodeAttempts = 5
+)
+
+// MFA. MFAChallengeExpiration en minutos; TOTPAllowedSkew en intervalos de 30 segundos.
+const (
+	MFAMethodTOTP          = "totp"
+	MFAMethodRecoveryCode  = "recovery_code"
+	MFAChallengeExpiration = 5
+	TOTPAllowedSkew        = 1
+	RecoveryCodeCount      = 10
+)
+
+// Passwordless. PasswordlessExpiration en minutos.
+const (
+	PasswordlessMethodLink = "link"
+	PasswordlessMethodCode = "code"
+	PasswordlessExpiration = 10
+)
+
+// Códigos por SMS o WhatsApp. PhoneCodeExpiration en minutos.
+const (
+	MessageChannelSMS      = "sms"
+	MessageChannelWhatsApp = "whatsapp"
+	MessagingProviderHTTP  = "http"
+	MessagingProviderLog   = "log"
+	PhoneCodeExpiration    = 10
+)
+
+// Login social. FederatedStateExpiration en minutos.
+const (
+	IdentityProviderGoogle   = "google"
+	IdentityProviderFacebook = "facebook"
+	FederatedStateExpiration = 10
+)
+
+// Rate limiting: políticas por grupo de endpoints y de qué se toma la clave del bucket.
+const (
+	RateLimitPolicyLogin      = "login"
+	RateLimitPolicySignup     = "signup"
+	RateLimitPolicyPassword   = "password"
+	RateLimitPolicyCodeResend = "code_resend"
+	RateLimitPolicyMFA        = "mfa"
+	RateLimitPolicyOAuthToken = "oauth_token"
+	RateLimitPolicyPublicKeys = "public_keys"
+	RateLimitPolicyRefresh    = "refresh"
+	RateLimitKeyByIP          = "ip"
+	RateLimitKeyByUser        = "user"
+	RateLimitKeyByClient      = "client"
+)
+
+// Algoritmos de hash de contraseñas.
+const (
+	PasswordHashArgon2id = "argon2id"
+	PasswordHashBcrypt   = "bcrypt"
+)
+
+// Proveedores de secretos.
+const (
+	SecretProviderEnv   = "env"
+	SecretProviderFile  = "file"
+	SecretProviderVault = "vault"
 )
@@ -0,0 +1,24 @@
+package constants
+
+// OAuth 2.0 grant types y parámetros soportados.
+const (
+	GrantTypeAuthorizationCode = "authorization_code"
+	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeClientCredentials = "client_credentials"
+	ResponseTypeCode           = "code"
+	CodeChallengeMethodS256    = "S256"
+)
+
+// OAuth clients y códigos. OAuthCodeExpiration en minutos.
+const (
+	OAuthClientIDSize     = 16
+	OAuthClientSecretSize = 32
+	OAuthCodeExpiration   = 5
+)
+
+// Scopes de OpenID Connect.
+const (
+	OAuthScopeOpenID  = "openid"
+	OAuthScopeProfile = "profile"
+	OAuthScopeEmail   = "email"
+)
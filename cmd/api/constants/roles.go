@@ -0,0 +1,21 @@
+package constants
+
+// Roles.
+const (
+	RoleAdmin  = "admin"
+	RoleTasker = "tasker"
+	RoleClient = "client"
+
+	DefaultSignupRole = RoleClient
+)
+
+// Permissions.
+const (
+	PermissionRolesManage   = "roles:manage"
+	PermissionUsersRead     = "users:read"
+	PermissionUsersManage   = "users:manage"
+	PermissionClientsManage = "clients:manage"
+	PermissionTasksRead     = "tasks:read"
+	PermissionTasksWrite    = "tasks:write"
+	PermissionTasksApply    = "tasks:apply"
+)
@@ -0,0 +1,109 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type AuthController struct {
+	AuthService services.IAuthService
+}
+
+func NewAuthController(authService services.IAuthService) *AuthController {
+	return &AuthController{AuthService: authService}
+}
+
+// Login godoc
+// @Summary Log in with email and password
+// @Description Verifies the credentials of a verified user and returns a signed JWT access token and a refresh token.
+// @Description When the user has two-factor authentication enabled it returns an MFA challenge instead, to be completed at /v1/api/auth/login/mfa.
+// @Description Repeated failures for the same account or client IP are delayed and then temporarily locked out; the Retry-After header tells how long to wait.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.LoginRequest true "Credentials"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 429 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/auth/login [post]
+func (ctl *AuthController) Login(c echo.Context) error {
+	var request dto.LoginRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	response, err := ctl.AuthService.Login(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// LoginMFA godoc
+// @Summary Complete a login with a second factor
+// @Description Redeems the MFA challenge with a TOTP code or a recovery code. The challenge is single use: a wrong code requires logging in again.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.MFALoginRequest true "MFA challenge and code"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/auth/login/mfa [post]
+func (ctl *AuthController) LoginMFA(c echo.Context) error {
+	var request dto.MFALoginRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	response, err := ctl.AuthService.LoginMFA(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Refresh godoc
+// @Summary Rotate a refresh token
+// @Description Exchanges a refresh token for a new token pair. Reusing a rotated token revokes its whole family.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.RefreshRequest true "Refresh token"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/auth/refresh [post]
+func (ctl *AuthController) Refresh(c echo.Context) error {
+	var request dto.RefreshRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	response, err := ctl.AuthService.Refresh(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,205 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/middlewares"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type FederatedController struct {
+	FederatedLoginService services.IFederatedLoginService
+	AuthService           services.IAuthService
+}
+
+func NewFederatedController(federatedLoginService services.IFederatedLoginService, authService services.IAuthService) *FederatedController {
+	return &FederatedController{
+		FederatedLoginService: federatedLoginService,
+		AuthService:           authService,
+	}
+}
+
+// ListProviders godoc
+// @Summary List social login providers
+// @Description Returns the identity providers configured in this environment.
+// @Tags social
+// @Produce json
+// @Success 200 {object} dto.IdentityProvidersResponse
+// @Router /v1/api/auth/social/providers [get]
+func (ctl *FederatedController) ListProviders(c echo.Context) error {
+	return c.JSON(http.StatusOK, dto.IdentityProvidersResponse{Providers: ctl.FederatedLoginService.ListProviders()})
+}
+
+// BeginLogin godoc
+// @Summary Begin social login
+// @Description Returns the provider authorization URL to redirect the user to. The provider sends the user back to the app with a code and the state, which the app posts to /v1/api/auth/social/{provider}/login.
+// @Tags social
+// @Produce json
+// @Param provider path string true "Identity provider"
+// @Success 200 {object} dto.FederatedBeginResponse
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/auth/social/{provider}/begin [post]
+func (ctl *FederatedController) BeginLogin(c echo.Context) error {
+	var request dto.IdentityProviderRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	response, err := ctl.FederatedLoginService.Begin(c.Request().Context(), request.Provider, 0)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Login godoc
+// @Summary Log in with a social provider
+// @Description Exchanges the provider code and returns a token pair. A new external identity is linked to the account with the same verified email when the provider allows it, or creates a new account.
+// @Description When the user has two-factor authentication enabled it returns an MFA challenge instead, to be completed at /v1/api/auth/login/mfa.
+// @Tags social
+// @Accept json
+// @Produce json
+// @Param provider path string true "Identity provider"
+// @Param request body dto.FederatedCallbackRequest true "Code and state returned by the provider"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 409 {object} utils.APIError
+// @Failure 502 {object} utils.APIError
+// @Router /v1/api/auth/social/{provider}/login [post]
+func (ctl *FederatedController) Login(c echo.Context) error {
+	var request dto.FederatedCallbackRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	response, err := ctl.AuthService.LoginFederated(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ListIdentities godoc
+// @Summary List linked social providers
+// @Tags social
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.ExternalIdentityResponse
+// @Failure 401 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/identities [get]
+func (ctl *FederatedController) ListIdentities(c echo.Context) error {
+	principal := middlewares.GetPrincipal(c)
+
+	response, err := ctl.FederatedLoginService.ListIdentities(c.Request().Context(), principal.UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// BeginLink godoc
+// @Summary Begin linking a social provider
+// @Description Like the social login begin, but the returned state can only be used to link the provider to the current account.
+// @Tags social
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "Identity provider"
+// @Success 200 {object} dto.FederatedBeginResponse
+// @Failure 401 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/identities/{provider}/begin [post]
+func (ctl *FederatedController) BeginLink(c echo.Context) error {
+	var request dto.IdentityProviderRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	response, err := ctl.FederatedLoginService.Begin(c.Request().Context(), request.Provider, principal.UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Link godoc
+// @Summary Link a social provider
+// @Tags social
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param provider path string true "Identity provider"
+// @Param request body dto.FederatedCallbackRequest true "Code and state returned by the provider"
+// @Success 201 {object} dto.ExternalIdentityResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 409 {object} utils.APIError
+// @Failure 502 {object} utils.APIError
+// @Router /v1/api/users/me/identities/{provider} [post]
+func (ctl *FederatedController) Link(c echo.Context) error {
+	var request dto.FederatedCallbackRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	response, err := ctl.FederatedLoginService.Link(c.Request().Context(), principal.UserID, request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// Unlink godoc
+// @Summary Unlink a social provider
+// @Tags social
+// @Security BearerAuth
+// @Param provider path string true "Identity provider"
+// @Success 204
+// @Failure 401 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/identities/{provider} [delete]
+func (ctl *FederatedController) Unlink(c echo.Context) error {
+	var request dto.IdentityProviderRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	if err := ctl.FederatedLoginService.Unlink(c.Request().Context(), principal.UserID, request.Provider); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,47 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type LockoutController struct {
+	LockoutService services.ILockoutService
+}
+
+func NewLockoutController(lockoutService services.ILockoutService) *LockoutController {
+	return &LockoutController{LockoutService: lockoutService}
+}
+
+// Unlock godoc
+// @Summary Unlock a user's account
+// @Description Clears the failed login attempts of a user locked out after too many wrong passwords. Attempts counted against the client IP are kept.
+// @Tags admin
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/users/{id}/lockout [delete]
+func (ctl *LockoutController) Unlock(c echo.Context) error {
+	var request dto.UserIDRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := ctl.LockoutService.Unlock(c.Request().Context(), request.UserID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,182 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/middlewares"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type MFAController struct {
+	MFAService services.IMFAService
+}
+
+func NewMFAController(mfaService services.IMFAService) *MFAController {
+	return &MFAController{MFAService: mfaService}
+}
+
+// Status godoc
+// @Summary Two-factor status
+// @Description Returns whether the current user has two-factor authentication enabled.
+// @Tags mfa
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.MFAStatusResponse
+// @Failure 401 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/mfa [get]
+func (ctl *MFAController) Status(c echo.Context) error {
+	principal := middlewares.GetPrincipal(c)
+
+	response, err := ctl.MFAService.Status(c.Request().Context(), principal.UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// EnrollTOTP godoc
+// @Summary Start TOTP enrollment
+// @Description Generates a TOTP secret and its otpauth:// URI. Two-factor is not enforced until the enrollment is confirmed.
+// @Tags mfa
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.TOTPEnrollmentResponse
+// @Failure 401 {object} utils.APIError
+// @Failure 409 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/mfa/totp [post]
+func (ctl *MFAController) EnrollTOTP(c echo.Context) error {
+	principal := middlewares.GetPrincipal(c)
+
+	response, err := ctl.MFAService.EnrollTOTP(c.Request().Context(), principal.UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ConfirmTOTP godoc
+// @Summary Confirm TOTP enrollment
+// @Description Enables two-factor authentication with a code from the authenticator app and returns one-time recovery codes. They are shown only once.
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.TOTPConfirmRequest true "TOTP code"
+// @Success 200 {object} dto.RecoveryCodesResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 409 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/mfa/totp/confirm [post]
+func (ctl *MFAController) ConfirmTOTP(c echo.Context) error {
+	var request dto.TOTPConfirmRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	response, err := ctl.MFAService.ConfirmTOTP(c.Request().Context(), principal.UserID, request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// DisableTOTP godoc
+// @Summary Disable two-factor authentication
+// @Description Requires a current TOTP code or a recovery code. Removes the TOTP secret and every recovery code.
+// @Tags mfa
+// @Accept json
+// @Security BearerAuth
+// @Param request body dto.MFACodeRequest true "TOTP or recovery code"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/mfa/totp/disable [post]
+func (ctl *MFAController) DisableTOTP(c echo.Context) error {
+	var request dto.MFACodeRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	if err := ctl.MFAService.Disable(c.Request().Context(), principal.UserID, request); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes godoc
+// @Summary Regenerate recovery codes
+// @Description Requires a current TOTP code or a recovery code. Invalidates the previous recovery codes.
+// @Tags mfa
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.MFACodeRequest true "TOTP or recovery code"
+// @Success 200 {object} dto.RecoveryCodesResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/mfa/recovery-codes [post]
+func (ctl *MFAController) RegenerateRecoveryCodes(c echo.Context) error {
+	var request dto.MFACodeRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	response, err := ctl.MFAService.RegenerateRecoveryCodes(c.Request().Context(), principal.UserID, request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Reset godoc
+// @Summary Reset a user's two-factor authentication
+// @Description Removes the TOTP secret and recovery codes of a user who lost access to both.
+// @Tags admin
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/users/{id}/mfa [delete]
+func (ctl *MFAController) Reset(c echo.Context) error {
+	var request dto.UserIDRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := ctl.MFAService.Reset(c.Request().Context(), request.UserID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
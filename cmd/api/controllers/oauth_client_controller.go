@@ -0,0 +1,104 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type OAuthClientController struct {
+	OAuthClientService services.IOAuthClientService
+}
+
+func NewOAuthClientController(oauthClientService services.IOAuthClientService) *OAuthClientController {
+	return &OAuthClientController{
+		OAuthClientService: oauthClientService,
+	}
+}
+
+// ListClients godoc
+// @Summary List OAuth clients
+// @Description Returns every registered OAuth client, without secrets.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.OAuthClientResponse
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/oauth/clients [get]
+func (ctl *OAuthClientController) ListClients(c echo.Context) error {
+	clients, err := ctl.OAuthClientService.ListClients(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	response := make([]dto.OAuthClientResponse, 0, len(clients))
+	for i := range clients {
+		response = append(response, dto.NewOAuthClientResponse(&clients[i]))
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// CreateClient godoc
+// @Summary Register an OAuth client
+// @Description Registers a client. Confidential clients receive a client_secret that is only shown in this response.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateOAuthClientRequest true "Client data"
+// @Success 201 {object} dto.OAuthClientResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/oauth/clients [post]
+func (ctl *OAuthClientController) CreateClient(c echo.Context) error {
+	var request dto.CreateOAuthClientRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	response, err := ctl.OAuthClientService.CreateClient(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, response)
+}
+
+// DeleteClient godoc
+// @Summary Delete an OAuth client
+// @Description Deletes the client and its consents. Its refresh tokens can no longer be used.
+// @Tags admin
+// @Security BearerAuth
+// @Param client_id path string true "Client ID"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/oauth/clients/{client_id} [delete]
+func (ctl *OAuthClientController) DeleteClient(c echo.Context) error {
+	var request dto.OAuthClientIDRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid client id")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := ctl.OAuthClientService.DeleteClient(c.Request().Context(), request.ClientID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,298 @@
+package controllers
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/middlewares"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type OAuthController struct {
+	IntrospectionService services.IIntrospectionService
+	RevocationService    services.IRevocationService
+	OAuthService         services.IOAuthService
+	OIDCService          services.IOIDCService
+}
+
+func NewOAuthController(
+	introspectionService services.IIntrospectionService,
+	revocationService services.IRevocationService,
+	oauthService services.IOAuthService,
+	oidcService services.IOIDCService,
+) *OAuthController {
+	return &OAuthController{
+		IntrospectionService: introspectionService,
+		RevocationService:    revocationService,
+		OAuthService:         oauthService,
+		OIDCService:          oidcService,
+	}
+}
+
+// Authorize godoc
+// @Summary Authorization endpoint (RFC 6749 4.1 with PKCE)
+// @Description Validates an authorization request for the logged-in user. Returns the redirect with the code, or the client and scopes to show on the consent screen.
+// @Description PKCE with S256 is required. Errors after the client and redirect_uri are validated are returned in redirect_to.
+// @Tags oauth
+// @Produce json
+// @Security BearerAuth
+// @Param response_type query string true "code"
+// @Param client_id query string true "Client ID"
+// @Param redirect_uri query string false "Registered redirect URI"
+// @Param scope query string false "Space-separated scopes"
+// @Param state query string false "Opaque value returned in the redirect"
+// @Param code_challenge query string true "PKCE code challenge"
+// @Param code_challenge_method query string true "S256"
+// @Success 200 {object} dto.AuthorizeResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /oauth/authorize [get]
+func (ctl *OAuthController) Authorize(c echo.Context) error {
+	var request dto.AuthorizeRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	if principal.ClientID != "" {
+		return utils.NewForbiddenError("a first-party session is required")
+	}
+
+	response, err := ctl.OAuthService.Authorize(c.Request().Context(), principal.UserID, request)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, response)
+}
+
+// Consent godoc
+// @Summary Answer a consent request
+// @Description Records whether the logged-in user approves the client and scopes, and returns the redirect with the code or with access_denied.
+// @Tags oauth
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ConsentRequest true "Authorization request and decision"
+// @Success 200 {object} dto.AuthorizeResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /oauth/authorize [post]
+func (ctl *OAuthController) Consent(c echo.Context) error {
+	var request dto.ConsentRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	if principal.ClientID != "" {
+		return utils.NewForbiddenError("a first-party session is required")
+	}
+
+	response, err := ctl.OAuthService.Consent(c.Request().Context(), principal.UserID, request)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, response)
+}
+
+// Token godoc
+// @Summary Token endpoint (RFC 6749 3.2)
+// @Description Supports the authorization_code (with PKCE), refresh_token and client_credentials grants.
+// @Description Confidential clients authenticate with HTTP Basic or client_id and client_secret in the body; public clients send only client_id.
+// @Tags oauth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param grant_type formData string true "authorization_code, refresh_token or client_credentials"
+// @Param code formData string false "Authorization code"
+// @Param redirect_uri formData string false "Redirect URI sent to the authorization endpoint"
+// @Param code_verifier formData string false "PKCE code verifier"
+// @Param refresh_token formData string false "Refresh token"
+// @Param scope formData string false "Space-separated scopes"
+// @Param client_id formData string false "Client ID"
+// @Param client_secret formData string false "Client secret"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} dto.OAuthErrorResponse
+// @Failure 401 {object} dto.OAuthErrorResponse
+// @Failure 500 {object} utils.APIError
+// @Router /oauth/token [post]
+func (ctl *OAuthController) Token(c echo.Context) error {
+	var request dto.TokenRequest
+	if err := c.Bind(&request); err != nil {
+		return tokenError(c, utils.NewOAuthError("invalid_request", "invalid request body"))
+	}
+	if err := c.Validate(&request); err != nil {
+		return tokenError(c, utils.NewOAuthError("invalid_request", "grant_type is required"))
+	}
+
+	if err := basicClientCredentials(c, &request.ClientID, &request.ClientSecret); err != nil {
+		return tokenError(c, err)
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	response, err := ctl.OAuthService.Token(c.Request().Context(), request)
+	if err != nil {
+		return tokenError(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// Introspect godoc
+// @Summary Token introspection (RFC 7662)
+// @Description Reports whether an access or refresh token is still active, taking revocations and password changes into account.
+// @Description Only confidential clients may call it, authenticating with HTTP Basic or client_id and client_secret in the body.
+// @Tags oauth
+// @Accept x-www-form-urlencoded
+// @Produce json
+// @Param token formData string true "Token to introspect"
+// @Param token_type_hint formData string false "access_token or refresh_token"
+// @Param client_id formData string false "Client ID"
+// @Param client_secret formData string false "Client secret"
+// @Success 200 {object} dto.IntrospectionResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} dto.OAuthErrorResponse
+// @Router /oauth/introspect [post]
+func (ctl *OAuthController) Introspect(c echo.Context) error {
+	var request dto.IntrospectionRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+	if err := basicClientCredentials(c, &request.ClientID, &request.ClientSecret); err != nil {
+		return tokenError(c, err)
+	}
+
+	client, err := ctl.OAuthService.AuthenticateClient(c.Request().Context(), request.ClientID, request.ClientSecret)
+	if err != nil {
+		return tokenError(c, err)
+	}
+	// RFC 7662 2.1: el client_id de un cliente público no autentica a quien lo presenta.
+	if client.Public {
+		return tokenError(c, utils.NewOAuthError("invalid_client", "client authentication failed"))
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, ctl.IntrospectionService.Introspect(c.Request().Context(), request))
+}
+
+// Revoke godoc
+// @Summary Token revocation (RFC 7009)
+// @Description Revokes an access token by jti or a refresh token with its whole family. Unknown tokens are ignored.
+// @Description The client authenticates like in the token endpoint and can only revoke the tokens issued to it.
+// @Tags oauth
+// @Accept x-www-form-urlencoded
+// @Param token formData string true "Token to revoke"
+// @Param token_type_hint formData string false "access_token or refresh_token"
+// @Param client_id formData string false "Client ID"
+// @Param client_secret formData string false "Client secret"
+// @Success 200
+// @Failure 400 {object} dto.OAuthErrorResponse
+// @Failure 401 {object} dto.OAuthErrorResponse
+// @Failure 500 {object} utils.APIError
+// @Router /oauth/revoke [post]
+func (ctl *OAuthController) Revoke(c echo.Context) error {
+	var request dto.RevocationRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+	if err := basicClientCredentials(c, &request.ClientID, &request.ClientSecret); err != nil {
+		return tokenError(c, err)
+	}
+
+	client, err := ctl.OAuthService.AuthenticateClient(c.Request().Context(), request.ClientID, request.ClientSecret)
+	if err != nil {
+		return tokenError(c, err)
+	}
+
+	if err := ctl.RevocationService.Revoke(c.Request().Context(), client.ClientID, request); err != nil {
+		var apiErr *utils.APIError
+		if errors.As(err, &apiErr) {
+			return tokenError(c, err)
+		}
+		return utils.NewInternalServerError("could not revoke token")
+	}
+
+	return c.NoContent(http.StatusOK)
+}
+
+// UserInfo godoc
+// @Summary OpenID Connect userinfo endpoint
+// @Description Returns the claims of the token's user allowed by its scopes: name with profile, email and email_verified with email.
+// @Tags oauth
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.UserInfoResponse
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /oauth/userinfo [get]
+// @Router /oauth/userinfo [post]
+func (ctl *OAuthController) UserInfo(c echo.Context) error {
+	principal := middlewares.GetPrincipal(c)
+	if principal.UserID == 0 {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+		return utils.NewUnauthorizedError("the access token does not belong to a user")
+	}
+
+	response, err := ctl.OIDCService.UserInfo(c.Request().Context(), principal.UserID, principal.Scopes)
+	if err != nil {
+		return err
+	}
+
+	c.Response().Header().Set("Cache-Control", "no-store")
+	return c.JSON(http.StatusOK, response)
+}
+
+// basicClientCredentials toma las credenciales del cliente de HTTP Basic si vienen; no se permite usar a la vez
+// Basic y client_secret en el cuerpo.
+func basicClientCredentials(c echo.Context, clientID, clientSecret *string) error {
+	basicID, basicSecret, ok := c.Request().BasicAuth()
+	if !ok {
+		return nil
+	}
+	if *clientSecret != "" {
+		return utils.NewOAuthError("invalid_request", "only one client authentication method is allowed")
+	}
+	// RFC 6749 2.3.1: las credenciales van codificadas como application/x-www-form-urlencoded.
+	*clientID, _ = url.QueryUnescape(basicID)
+	*clientSecret, _ = url.QueryUnescape(basicSecret)
+	return nil
+}
+
+// tokenError responde los errores del token endpoint con el formato de RFC 6749 5.2; los errores internos siguen
+// el formato habitual.
+func tokenError(c echo.Context, err error) error {
+	var apiErr *utils.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status >= http.StatusInternalServerError {
+		return err
+	}
+
+	if apiErr.Status == http.StatusUnauthorized {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Basic realm="`+constants.NameApp+`"`)
+	}
+	return c.JSON(apiErr.Status, dto.OAuthErrorResponse{Error: apiErr.Code, ErrorDescription: apiErr.Message})
+}
@@ -0,0 +1,103 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/middlewares"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type PasswordController struct {
+	PasswordService services.IPasswordService
+}
+
+func NewPasswordController(passwordService services.IPasswordService) *PasswordController {
+	return &PasswordController{PasswordService: passwordService}
+}
+
+// ForgotPassword godoc
+// @Summary Request a password reset email
+// @Description Always answers 204 so the response does not reveal whether the email is registered.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ForgotPasswordRequest true "User email"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Router /v1/api/auth/password/forgot [post]
+func (ctl *PasswordController) ForgotPassword(c echo.Context) error {
+	var request dto.ForgotPasswordRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := ctl.PasswordService.ForgotPassword(c.Request().Context(), request); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ResetPassword godoc
+// @Summary Reset a password with an emailed token
+// @Description Redeems the single-use reset token, sets the new password, revokes existing sessions and lifts any login lockout of the account.
+// @Description The new password must satisfy the password policy; violations are answered with a 400 weak_password error.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.ResetPasswordRequest true "Reset token and new password"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/auth/password/reset [post]
+func (ctl *PasswordController) ResetPassword(c echo.Context) error {
+	var request dto.ResetPasswordRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := ctl.PasswordService.ResetPassword(c.Request().Context(), request); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ChangePassword godoc
+// @Summary Change the password
+// @Description Checks the current password and sets a new one that satisfies the password policy. All sessions are revoked, including the current one, so the client must log in again.
+// @Tags users
+// @Accept json
+// @Security BearerAuth
+// @Param request body dto.ChangePasswordRequest true "Current and new password"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 429 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/password [post]
+func (ctl *PasswordController) ChangePassword(c echo.Context) error {
+	var request dto.ChangePasswordRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	if err := ctl.PasswordService.ChangePassword(c.Request().Context(), principal.UserID, request); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
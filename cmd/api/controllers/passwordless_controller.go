@@ -0,0 +1,79 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type PasswordlessController struct {
+	PasswordlessService services.IPasswordlessService
+	AuthService         services.IAuthService
+}
+
+func NewPasswordlessController(passwordlessService services.IPasswordlessService, authService services.IAuthService) *PasswordlessController {
+	return &PasswordlessController{
+		PasswordlessService: passwordlessService,
+		AuthService:         authService,
+	}
+}
+
+// Start godoc
+// @Summary Request a passwordless login email
+// @Description Emails a single-use magic link (method "link", the default) or a 6-digit code (method "code").
+// @Description Always answers 204 so the response does not reveal whether the email is registered.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.PasswordlessStartRequest true "User email and delivery method"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Router /v1/api/auth/passwordless/start [post]
+func (ctl *PasswordlessController) Start(c echo.Context) error {
+	var request dto.PasswordlessStartRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := ctl.PasswordlessService.SendLoginEmail(c.Request().Context(), request); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// Login godoc
+// @Summary Log in with a magic link or a one-time code
+// @Description Redeems the magic link token, or the email or phone number and code, and returns a token pair.
+// @Description When the user has two-factor authentication enabled it returns an MFA challenge instead, to be completed at /v1/api/auth/login/mfa.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.PasswordlessLoginRequest true "Magic link token, or email or phone and code"
+// @Success 200 {object} dto.LoginResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/auth/passwordless/login [post]
+func (ctl *PasswordlessController) Login(c echo.Context) error {
+	var request dto.PasswordlessLoginRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	response, err := ctl.AuthService.LoginPasswordless(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,111 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/middlewares"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type PhoneController struct {
+	PhoneService services.IPhoneService
+}
+
+func NewPhoneController(phoneService services.IPhoneService) *PhoneController {
+	return &PhoneController{PhoneService: phoneService}
+}
+
+// StartVerification godoc
+// @Summary Add a phone number
+// @Description Sends a 6-digit code by SMS (channel "sms", the default) or WhatsApp to verify the phone number. National numbers are accepted when a default country code is configured; numbers are stored in E.164.
+// @Description The current verified number stays in use until the new one is confirmed. Each number receives a limited number of codes per window.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.PhoneCodeRequest true "Phone number and delivery channel"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 409 {object} utils.APIError
+// @Failure 429 {object} utils.APIError
+// @Failure 502 {object} utils.APIError
+// @Router /v1/api/users/me/phone [post]
+func (ctl *PhoneController) StartVerification(c echo.Context) error {
+	var request dto.PhoneCodeRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	if err := ctl.PhoneService.StartVerification(c.Request().Context(), principal.UserID, request); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ConfirmVerification godoc
+// @Summary Confirm a phone number
+// @Description Redeems the code sent to the pending phone number and makes it the user's verified number.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.ConfirmPhoneRequest true "Code received by SMS or WhatsApp"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 409 {object} utils.APIError
+// @Router /v1/api/users/me/phone/verify [post]
+func (ctl *PhoneController) ConfirmVerification(c echo.Context) error {
+	var request dto.ConfirmPhoneRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	user, err := ctl.PhoneService.ConfirmVerification(c.Request().Context(), principal.UserID, request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.NewUserResponse(user))
+}
+
+// StartLogin godoc
+// @Summary Request a login code by phone
+// @Description Sends a 6-digit login code by SMS or WhatsApp to a verified phone number; redeem it at /v1/api/auth/passwordless/login with the phone and code.
+// @Description Answers 204 whether or not the number is registered.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param request body dto.PhoneCodeRequest true "Phone number and delivery channel"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 429 {object} utils.APIError
+// @Router /v1/api/auth/passwordless/phone/start [post]
+func (ctl *PhoneController) StartLogin(c echo.Context) error {
+	var request dto.PhoneCodeRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := ctl.PhoneService.SendLoginCode(c.Request().Context(), request); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
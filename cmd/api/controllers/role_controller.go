@@ -0,0 +1,283 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type RoleController struct {
+	RoleService services.IRoleService
+}
+
+func NewRoleController(roleService services.IRoleService) *RoleController {
+	return &RoleController{
+		RoleService: roleService,
+	}
+}
+
+// ListRoles godoc
+// @Summary List roles
+// @Description Returns every role with its permissions.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.RoleResponse
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/roles [get]
+func (ctl *RoleController) ListRoles(c echo.Context) error {
+	roles, err := ctl.RoleService.ListRoles(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	response := make([]dto.RoleResponse, 0, len(roles))
+	for i := range roles {
+		response = append(response, dto.NewRoleResponse(&roles[i]))
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// CreateRole godoc
+// @Summary Create a role
+// @Description Creates a role granting existing permissions.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreateRoleRequest true "Role data"
+// @Success 201 {object} dto.RoleResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 409 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/roles [post]
+func (ctl *RoleController) CreateRole(c echo.Context) error {
+	var request dto.CreateRoleRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	role, err := ctl.RoleService.CreateRole(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.NewRoleResponse(role))
+}
+
+// UpdateRole godoc
+// @Summary Update a role
+// @Description Replaces the description and permissions of a role.
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "Role ID"
+// @Param request body dto.UpdateRoleRequest true "Role data"
+// @Success 200 {object} dto.RoleResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/roles/{id} [put]
+func (ctl *RoleController) UpdateRole(c echo.Context) error {
+	var request dto.UpdateRoleRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	role, err := ctl.RoleService.UpdateRole(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.NewRoleResponse(role))
+}
+
+// DeleteRole godoc
+// @Summary Delete a role
+// @Description Deletes a role and its assignments. Built-in roles cannot be deleted.
+// @Tags admin
+// @Security BearerAuth
+// @Param id path int true "Role ID"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 409 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/roles/{id} [delete]
+func (ctl *RoleController) DeleteRole(c echo.Context) error {
+	var request dto.RoleIDRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := ctl.RoleService.DeleteRole(c.Request().Context(), request.ID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ListPermissions godoc
+// @Summary List permissions
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.PermissionResponse
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/permissions [get]
+func (ctl *RoleController) ListPermissions(c echo.Context) error {
+	permissions, err := ctl.RoleService.ListPermissions(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	response := make([]dto.PermissionResponse, 0, len(permissions))
+	for i := range permissions {
+		response = append(response, dto.NewPermissionResponse(&permissions[i]))
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// CreatePermission godoc
+// @Summary Create a permission
+// @Tags admin
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.CreatePermissionRequest true "Permission data"
+// @Success 201 {object} dto.PermissionResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 409 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/permissions [post]
+func (ctl *RoleController) CreatePermission(c echo.Context) error {
+	var request dto.CreatePermissionRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	permission, err := ctl.RoleService.CreatePermission(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.NewPermissionResponse(permission))
+}
+
+// UserRoles godoc
+// @Summary User roles
+// @Description Returns the roles assigned to a user and the permissions they grant.
+// @Tags admin
+// @Produce json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Success 200 {object} dto.UserRolesResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/users/{id}/roles [get]
+func (ctl *RoleController) UserRoles(c echo.Context) error {
+	var request dto.UserIDRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	response, err := ctl.RoleService.UserAuthorization(c.Request().Context(), request.UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// AssignRole godoc
+// @Summary Assign a role to a user
+// @Description The change is reflected in the next access token issued to the user.
+// @Tags admin
+// @Accept json
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param request body dto.AssignRoleRequest true "Role name"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/users/{id}/roles [post]
+func (ctl *RoleController) AssignRole(c echo.Context) error {
+	var request dto.AssignRoleRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := ctl.RoleService.AssignRole(c.Request().Context(), request.UserID, request.Role); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RemoveRole godoc
+// @Summary Remove a role from a user
+// @Tags admin
+// @Security BearerAuth
+// @Param id path int true "User ID"
+// @Param role path string true "Role name"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/admin/users/{id}/roles/{role} [delete]
+func (ctl *RoleController) RemoveRole(c echo.Context) error {
+	var request dto.RemoveRoleRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := ctl.RoleService.RemoveRole(c.Request().Context(), request.UserID, request.Role); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
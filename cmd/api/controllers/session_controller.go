@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/middlewares"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type SessionController struct {
+	SessionService services.ISessionService
+}
+
+func NewSessionController(sessionService services.ISessionService) *SessionController {
+	return &SessionController{SessionService: sessionService}
+}
+
+// ListSessions godoc
+// @Summary List active sessions
+// @Description Returns the devices where the user is logged in, most recently used first.
+// @Tags sessions
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.SessionResponse
+// @Failure 401 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/sessions [get]
+func (ctl *SessionController) ListSessions(c echo.Context) error {
+	principal := middlewares.GetPrincipal(c)
+
+	response, err := ctl.SessionService.ListSessions(c.Request().Context(), principal.UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// RevokeSession godoc
+// @Summary Log out a session
+// @Description Revokes the session so its refresh token can no longer be used. Access tokens already issued stay valid until they expire.
+// @Tags sessions
+// @Security BearerAuth
+// @Param id path int true "Session ID"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/sessions/{id} [delete]
+func (ctl *SessionController) RevokeSession(c echo.Context) error {
+	var request dto.SessionRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	if err := ctl.SessionService.RevokeSession(c.Request().Context(), principal.UserID, request.ID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// RevokeAllSessions godoc
+// @Summary Log out every session
+// @Description Revokes all the sessions of the user, including the current one.
+// @Tags sessions
+// @Security BearerAuth
+// @Success 204
+// @Failure 401 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/sessions [delete]
+func (ctl *SessionController) RevokeAllSessions(c echo.Context) error {
+	principal := middlewares.GetPrincipal(c)
+
+	if err := ctl.SessionService.RevokeAllSessions(c.Request().Context(), principal.UserID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
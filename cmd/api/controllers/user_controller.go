@@ -0,0 +1,158 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/middlewares"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type UserController struct {
+	UserService         services.IUserService
+	VerificationService services.IVerificationService
+}
+
+func NewUserController(userService services.IUserService, verificationService services.IVerificationService) *UserController {
+	return &UserController{
+		UserService:         userService,
+		VerificationService: verificationService,
+	}
+}
+
+// Signup godoc
+// @Summary Register a new user
+// @Description Creates a user account with a unique email and a hashed password. The password must satisfy the password policy; violations are answered with a 400 weak_password error.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.SignupRequest true "Signup data"
+// @Success 201 {object} dto.UserResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 409 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users [post]
+func (ctl *UserController) Signup(c echo.Context) error {
+	var request dto.SignupRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	user, err := ctl.UserService.Signup(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.NewUserResponse(user))
+}
+
+// Me godoc
+// @Summary Current user
+// @Description Returns the user identified by the bearer access token.
+// @Tags users
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.UserResponse
+// @Failure 401 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Router /v1/api/users/me [get]
+func (ctl *UserController) Me(c echo.Context) error {
+	principal := middlewares.GetPrincipal(c)
+
+	user, err := ctl.UserService.GetByID(c.Request().Context(), principal.UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.NewUserResponse(user))
+}
+
+// UpdateMe godoc
+// @Summary Update the current user
+// @Description Sets the preferred language (es, en or pt) used for the user's emails. Without a preference, emails follow the request's Accept-Language header.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.UpdateUserRequest true "User preferences"
+// @Success 200 {object} dto.UserResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Router /v1/api/users/me [patch]
+func (ctl *UserController) UpdateMe(c echo.Context) error {
+	var request dto.UpdateUserRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	user, err := ctl.UserService.Update(c.Request().Context(), principal.UserID, request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, dto.NewUserResponse(user))
+}
+
+// VerifyEmail godoc
+// @Summary Confirm a user's email
+// @Description Redeems the verification code sent by email and marks the user as verified.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.VerifyEmailRequest true "Verification code"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/verify-email [post]
+func (ctl *UserController) VerifyEmail(c echo.Context) error {
+	var request dto.VerifyEmailRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := ctl.VerificationService.ConfirmEmail(c.Request().Context(), request); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// ResendVerification godoc
+// @Summary Resend the email verification code
+// @Description Always answers 204 so the response does not reveal whether the email is registered.
+// @Tags users
+// @Accept json
+// @Produce json
+// @Param request body dto.ResendVerificationRequest true "User email"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/verify-email/resend [post]
+func (ctl *UserController) ResendVerification(c echo.Context) error {
+	var request dto.ResendVerificationRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	if err := ctl.VerificationService.ResendEmailVerification(c.Request().Context(), request); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
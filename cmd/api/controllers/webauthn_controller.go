@@ -0,0 +1,175 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/middlewares"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type WebAuthnController struct {
+	WebAuthnService services.IWebAuthnService
+	AuthService     services.IAuthService
+}
+
+func NewWebAuthnController(webAuthnService services.IWebAuthnService, authService services.IAuthService) *WebAuthnController {
+	return &WebAuthnController{
+		WebAuthnService: webAuthnService,
+		AuthService:     authService,
+	}
+}
+
+// BeginRegistration godoc
+// @Summary Begin passkey registration
+// @Description Returns the options for navigator.credentials.create() and the session to send back when finishing.
+// @Tags webauthn
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} dto.WebAuthnBeginResponse
+// @Failure 401 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/webauthn/registration/begin [post]
+func (ctl *WebAuthnController) BeginRegistration(c echo.Context) error {
+	principal := middlewares.GetPrincipal(c)
+
+	response, err := ctl.WebAuthnService.BeginRegistration(c.Request().Context(), principal.UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// FinishRegistration godoc
+// @Summary Finish passkey registration
+// @Description Verifies the attestation returned by the authenticator and stores the passkey.
+// @Tags webauthn
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body dto.WebAuthnRegistrationRequest true "Session and authenticator response"
+// @Success 201 {object} dto.WebAuthnCredentialResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 409 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/webauthn/registration/finish [post]
+func (ctl *WebAuthnController) FinishRegistration(c echo.Context) error {
+	var request dto.WebAuthnRegistrationRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	credential, err := ctl.WebAuthnService.FinishRegistration(c.Request().Context(), principal.UserID, request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusCreated, dto.NewWebAuthnCredentialResponse(credential))
+}
+
+// ListCredentials godoc
+// @Summary List passkeys
+// @Tags webauthn
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {array} dto.WebAuthnCredentialResponse
+// @Failure 401 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/webauthn/credentials [get]
+func (ctl *WebAuthnController) ListCredentials(c echo.Context) error {
+	principal := middlewares.GetPrincipal(c)
+
+	credentials, err := ctl.WebAuthnService.ListCredentials(c.Request().Context(), principal.UserID)
+	if err != nil {
+		return err
+	}
+
+	response := make([]dto.WebAuthnCredentialResponse, 0, len(credentials))
+	for i := range credentials {
+		response = append(response, dto.NewWebAuthnCredentialResponse(&credentials[i]))
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// DeleteCredential godoc
+// @Summary Delete a passkey
+// @Tags webauthn
+// @Security BearerAuth
+// @Param id path int true "Passkey ID"
+// @Success 204
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 404 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/users/me/webauthn/credentials/{id} [delete]
+func (ctl *WebAuthnController) DeleteCredential(c echo.Context) error {
+	var request dto.WebAuthnCredentialRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	principal := middlewares.GetPrincipal(c)
+	if err := ctl.WebAuthnService.DeleteCredential(c.Request().Context(), principal.UserID, request.ID); err != nil {
+		return err
+	}
+
+	return c.NoContent(http.StatusNoContent)
+}
+
+// BeginLogin godoc
+// @Summary Begin passkey login
+// @Description Returns the options for navigator.credentials.get(). The authenticator chooses the passkey, so no email is needed.
+// @Tags webauthn
+// @Produce json
+// @Success 200 {object} dto.WebAuthnBeginResponse
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/auth/webauthn/login/begin [post]
+func (ctl *WebAuthnController) BeginLogin(c echo.Context) error {
+	response, err := ctl.WebAuthnService.BeginLogin(c.Request().Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// FinishLogin godoc
+// @Summary Finish passkey login
+// @Description Verifies the assertion signed by the passkey and returns a token pair.
+// @Tags webauthn
+// @Accept json
+// @Produce json
+// @Param request body dto.WebAuthnLoginRequest true "Session and authenticator response"
+// @Success 200 {object} dto.TokenResponse
+// @Failure 400 {object} utils.APIError
+// @Failure 401 {object} utils.APIError
+// @Failure 403 {object} utils.APIError
+// @Failure 500 {object} utils.APIError
+// @Router /v1/api/auth/webauthn/login/finish [post]
+func (ctl *WebAuthnController) FinishLogin(c echo.Context) error {
+	var request dto.WebAuthnLoginRequest
+	if err := c.Bind(&request); err != nil {
+		return utils.NewBadRequestError("invalid request body")
+	}
+	if err := c.Validate(&request); err != nil {
+		return err
+	}
+
+	response, err := ctl.AuthService.LoginWebAuthn(c.Request().Context(), request)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
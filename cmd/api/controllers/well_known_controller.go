@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type WellKnownController struct {
+	KeyService  services.IKeyService
+	OIDCService services.IOIDCService
+}
+
+func NewWellKnownController(keyService services.IKeyService, oidcService services.IOIDCService) *WellKnownController {
+	return &WellKnownController{
+		KeyService:  keyService,
+		OIDCService: oidcService,
+	}
+}
+
+// JWKS godoc
+// @Summary Public signing keys
+// @Description Publishes the keys used to sign tokens, including upcoming and recently rotated ones.
+// @Tags well-known
+// @Produce json
+// @Success 200 {object} dto.JWKSResponse
+// @Failure 500 {object} utils.APIError
+// @Router /.well-known/jwks.json [get]
+func (ctl *WellKnownController) JWKS(c echo.Context) error {
+	keys, err := ctl.KeyService.JWKS(c.Request().Context())
+	if err != nil {
+		log.Error(c.Request().Context(), "error loading signing keys: ", err)
+		return utils.NewInternalServerError("could not load signing keys")
+	}
+
+	c.Response().Header().Set("Cache-Control", "public, max-age=300")
+	return c.JSON(http.StatusOK, dto.JWKSResponse{Keys: keys})
+}
+
+// OpenIDConfiguration godoc
+// @Summary OpenID Connect discovery document
+// @Description Describes the issuer, endpoints, scopes and algorithms supported by the OpenID provider.
+// @Tags well-known
+// @Produce json
+// @Success 200 {object} dto.OpenIDConfiguration
+// @Router /.well-known/openid-configuration [get]
+func (ctl *WellKnownController) OpenIDConfiguration(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "public, max-age=3600")
+	return c.JSON(http.StatusOK, ctl.OIDCService.Configuration())
+}
@@ -0,0 +1,44 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+)
+
+type IdentityProviderRequest struct {
+	Provider string `param:"provider" validate:"required"`
+}
+
+type IdentityProvidersResponse struct {
+	Providers []string `json:"providers"`
+}
+
+// FederatedBeginResponse lleva la URL del proveedor a la que se redirige al usuario; el state vuelve en el
+// callback y el cliente lo reenvía junto con el code.
+type FederatedBeginResponse struct {
+	AuthorizationURL string `json:"authorization_url"`
+	State            string `json:"state"`
+}
+
+type FederatedCallbackRequest struct {
+	Provider string `param:"provider" validate:"required"`
+	Code     string `json:"code" validate:"required"`
+	State    string `json:"state" validate:"required"`
+}
+
+type ExternalIdentityResponse struct {
+	Provider    string     `json:"provider"`
+	Email       string     `json:"email,omitempty"`
+	CreatedAt   time.Time  `json:"created_at"`
+	LastLoginAt *time.Time `json:"last_login_at,omitempty"`
+}
+
+func NewExternalIdentityResponse(identity *models.ExternalIdentity) ExternalIdentityResponse {
+	return ExternalIdentityResponse{
+		Provider:    identity.Provider,
+		Email:       identity.Email,
+		CreatedAt:   identity.CreatedAt,
+		LastLoginAt: identity.LastLoginAt,
+	}
+}
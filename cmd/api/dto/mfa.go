@@ -0,0 +1,46 @@
+package dto
+
+import "time"
+
+type MFAStatusResponse struct {
+	Enabled     bool       `json:"enabled"`
+	Methods     []string   `json:"methods"`
+	ConfirmedAt *time.Time `json:"confirmed_at,omitempty"`
+}
+
+type TOTPEnrollmentResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type TOTPConfirmRequest struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+}
+
+// MFACodeRequest acepta un código TOTP o, si el usuario perdió el dispositivo, un código de recuperación.
+type MFACodeRequest struct {
+	Code         string `json:"code" validate:"required_without=RecoveryCode,omitempty,numeric,len=6"`
+	RecoveryCode string `json:"recovery_code" validate:"required_without=Code"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+type MFALoginRequest struct {
+	MFAToken string `json:"mfa_token" validate:"required"`
+	MFACodeRequest
+}
+
+type MFAChallengeResponse struct {
+	MFARequired bool     `json:"mfa_required"`
+	MFAToken    string   `json:"mfa_token"`
+	Methods     []string `json:"methods"`
+}
+
+// LoginResponse lleva el par de tokens o, si el usuario tiene 2FA activo, el desafío MFA; nunca ambos. Los
+// campos JSON de los dos no deben repetirse porque encoding/json descarta los duplicados.
+type LoginResponse struct {
+	*TokenResponse
+	*MFAChallengeResponse
+}
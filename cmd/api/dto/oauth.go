@@ -0,0 +1,128 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+)
+
+// Valores de token_type_hint según RFC 7009.
+const (
+	TokenTypeHintAccessToken  = "access_token"
+	TokenTypeHintRefreshToken = "refresh_token"
+)
+
+// IntrospectionRequest y RevocationRequest aceptan las credenciales del cliente en el cuerpo o por HTTP Basic.
+type IntrospectionRequest struct {
+	Token         string `form:"token" validate:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+// IntrospectionResponse sigue RFC 7662; un token inválido solo devuelve active en false.
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	TokenType string `json:"token_type,omitempty"`
+	Sub       string `json:"sub,omitempty"`
+	Username  string `json:"username,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	Iss       string `json:"iss,omitempty"`
+	Jti       string `json:"jti,omitempty"`
+	Exp       int64  `json:"exp,omitempty"`
+	Iat       int64  `json:"iat,omitempty"`
+}
+
+type RevocationRequest struct {
+	Token         string `form:"token" validate:"required"`
+	TokenTypeHint string `form:"token_type_hint"`
+	ClientID      string `form:"client_id"`
+	ClientSecret  string `form:"client_secret"`
+}
+
+// AuthorizeRequest son los parámetros de /oauth/authorize (RFC 6749 4.1.1 y RFC 7636 4.3); llegan por query en
+// el GET y en el cuerpo JSON al confirmar el consentimiento.
+type AuthorizeRequest struct {
+	ResponseType        string `query:"response_type" json:"response_type"`
+	ClientID            string `query:"client_id" json:"client_id" validate:"required"`
+	RedirectURI         string `query:"redirect_uri" json:"redirect_uri"`
+	Scope               string `query:"scope" json:"scope"`
+	State               string `query:"state" json:"state"`
+	CodeChallenge       string `query:"code_challenge" json:"code_challenge"`
+	CodeChallengeMethod string `query:"code_challenge_method" json:"code_challenge_method"`
+	Nonce               string `query:"nonce" json:"nonce" validate:"max=255"`
+}
+
+type ConsentRequest struct {
+	AuthorizeRequest
+	Approve bool `json:"approve"`
+}
+
+// AuthorizeResponse indica a dónde redirigir el navegador o, si falta el consentimiento del usuario, qué
+// cliente y scopes mostrarle.
+type AuthorizeResponse struct {
+	ConsentRequired bool     `json:"consent_required"`
+	ClientID        string   `json:"client_id"`
+	ClientName      string   `json:"client_name"`
+	Scopes          []string `json:"scopes"`
+	RedirectTo      string   `json:"redirect_to,omitempty"`
+}
+
+// TokenRequest cubre los grants authorization_code, refresh_token y client_credentials. Las credenciales del
+// cliente pueden llegar en el cuerpo o por HTTP Basic.
+type TokenRequest struct {
+	GrantType    string `form:"grant_type" validate:"required"`
+	Code         string `form:"code"`
+	RedirectURI  string `form:"redirect_uri"`
+	CodeVerifier string `form:"code_verifier"`
+	RefreshToken string `form:"refresh_token"`
+	Scope        string `form:"scope"`
+	ClientID     string `form:"client_id"`
+	ClientSecret string `form:"client_secret"`
+}
+
+// OAuthErrorResponse es el cuerpo de error de RFC 6749 5.2.
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+type CreateOAuthClientRequest struct {
+	Name         string   `json:"name" validate:"required,max=120"`
+	RedirectURIs []string `json:"redirect_uris" validate:"dive,required"`
+	GrantTypes   []string `json:"grant_types" validate:"required,min=1,dive,oneof=authorization_code refresh_token client_credentials"`
+	Scopes       []string `json:"scopes"`
+	Public       bool     `json:"public"`
+	FirstParty   bool     `json:"first_party"`
+}
+
+type OAuthClientIDRequest struct {
+	ClientID string `param:"client_id" validate:"required"`
+}
+
+// OAuthClientResponse solo incluye el secreto al crear el cliente; después no se puede recuperar.
+type OAuthClientResponse struct {
+	ClientID     string    `json:"client_id"`
+	ClientSecret string    `json:"client_secret,omitempty"`
+	Name         string    `json:"name"`
+	RedirectURIs []string  `json:"redirect_uris"`
+	GrantTypes   []string  `json:"grant_types"`
+	Scopes       []string  `json:"scopes"`
+	Public       bool      `json:"public"`
+	FirstParty   bool      `json:"first_party"`
+	CreatedAt    time.Time `json:"created_at"`
+}
+
+func NewOAuthClientResponse(client *models.OAuthClient) OAuthClientResponse {
+	return OAuthClientResponse{
+		ClientID:     client.ClientID,
+		Name:         client.Name,
+		RedirectURIs: client.RedirectURIList(),
+		GrantTypes:   client.GrantTypeList(),
+		Scopes:       client.ScopeList(),
+		Public:       client.Public,
+		FirstParty:   client.FirstParty,
+		CreatedAt:    client.CreatedAt,
+	}
+}
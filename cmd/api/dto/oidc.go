@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"slices"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+)
+
+// OpenIDConfiguration es el documento de OpenID Connect Discovery 1.0, sección 3.
+type OpenIDConfiguration struct {
+	Issuer                                    string   `json:"issuer"`
+	AuthorizationEndpoint                     string   `json:"authorization_endpoint"`
+	TokenEndpoint                             string   `json:"token_endpoint"`
+	UserInfoEndpoint                          string   `json:"userinfo_endpoint"`
+	JWKSURI                                   string   `json:"jwks_uri"`
+	IntrospectionEndpoint                     string   `json:"introspection_endpoint"`
+	RevocationEndpoint                        string   `json:"revocation_endpoint"`
+	ScopesSupported                           []string `json:"scopes_supported"`
+	ResponseTypesSupported                    []string `json:"response_types_supported"`
+	GrantTypesSupported                       []string `json:"grant_types_supported"`
+	SubjectTypesSupported                     []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported          []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported         []string `json:"token_endpoint_auth_methods_supported"`
+	IntrospectionEndpointAuthMethodsSupported []string `json:"introspection_endpoint_auth_methods_supported"`
+	RevocationEndpointAuthMethodsSupported    []string `json:"revocation_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported             []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                           []string `json:"claims_supported"`
+}
+
+// StandardClaims son los claims estándar de OpenID Connect (sección 5.1) que liberan los scopes profile y email.
+// EmailVerified es un puntero para que false se publique y la ausencia del scope lo omita.
+type StandardClaims struct {
+	Name          string `json:"name,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+}
+
+type UserInfoResponse struct {
+	Sub string `json:"sub"`
+	StandardClaims
+}
+
+func NewStandardClaims(user *models.User, scopes []string) StandardClaims {
+	var claims StandardClaims
+	if slices.Contains(scopes, constants.OAuthScopeProfile) {
+		claims.Name = user.Name
+	}
+	if slices.Contains(scopes, constants.OAuthScopeEmail) {
+		emailVerified := user.EmailVerified
+		claims.Email = user.Email
+		claims.EmailVerified = &emailVerified
+	}
+	return claims
+}
@@ -0,0 +1,15 @@
+package dto
+
+// PasswordlessStartRequest pide un enlace mágico (por defecto) o un código de 6 dígitos por email.
+type PasswordlessStartRequest struct {
+	Email  string `json:"email" validate:"required,email"`
+	Method string `json:"method" validate:"omitempty,oneof=link code"`
+}
+
+// PasswordlessLoginRequest canjea el token del enlace o, con el email o el teléfono, el código enviado.
+type PasswordlessLoginRequest struct {
+	Token string `json:"token" validate:"required_without=Code"`
+	Email string `json:"email" validate:"required_without_all=Token Phone,omitempty,email"`
+	Phone string `json:"phone" validate:"omitempty,max=32"`
+	Code  string `json:"code" validate:"required_without=Token,omitempty,numeric,len=6"`
+}
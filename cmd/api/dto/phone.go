@@ -0,0 +1,12 @@
+package dto
+
+// PhoneCodeRequest pide un código por SMS (por defecto) o WhatsApp. Phone puede venir en formato nacional si está
+// configurado un código de país por defecto.
+type PhoneCodeRequest struct {
+	Phone   string `json:"phone" validate:"required,max=32"`
+	Channel string `json:"channel" validate:"omitempty,oneof=sms whatsapp"`
+}
+
+type ConfirmPhoneRequest struct {
+	Code string `json:"code" validate:"required,numeric,len=6"`
+}
@@ -0,0 +1,74 @@
+package dto
+
+import "github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+
+type CreateRoleRequest struct {
+	Name        string   `json:"name" validate:"required,max=50"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions"`
+}
+
+type UpdateRoleRequest struct {
+	ID          uint     `param:"id" validate:"required"`
+	Description string   `json:"description" validate:"max=255"`
+	Permissions []string `json:"permissions"`
+}
+
+type RoleIDRequest struct {
+	ID uint `param:"id" validate:"required"`
+}
+
+type CreatePermissionRequest struct {
+	Name        string `json:"name" validate:"required,max=80"`
+	Description string `json:"description" validate:"max=255"`
+}
+
+type AssignRoleRequest struct {
+	UserID uint   `param:"id" validate:"required"`
+	Role   string `json:"role" validate:"required"`
+}
+
+type UserIDRequest struct {
+	UserID uint `param:"id" validate:"required"`
+}
+
+type RemoveRoleRequest struct {
+	UserID uint   `param:"id" validate:"required"`
+	Role   string `param:"role" validate:"required"`
+}
+
+type RoleResponse struct {
+	ID          uint     `json:"id"`
+	Name        string   `json:"name"`
+	Description string   `json:"description"`
+	Permissions []string `json:"permissions"`
+}
+
+type PermissionResponse struct {
+	ID          uint   `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type UserRolesResponse struct {
+	UserID      uint     `json:"user_id"`
+	Roles       []string `json:"roles"`
+	Permissions []string `json:"permissions"`
+}
+
+func NewRoleResponse(role *models.Role) RoleResponse {
+	return RoleResponse{
+		ID:          role.ID,
+		Name:        role.Name,
+		Description: role.Description,
+		Permissions: role.PermissionNames(),
+	}
+}
+
+func NewPermissionResponse(permission *models.Permission) PermissionResponse {
+	return PermissionResponse{
+		ID:          permission.ID,
+		Name:        permission.Name,
+		Description: permission.Description,
+	}
+}
@@ -0,0 +1,33 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+)
+
+type SessionRequest struct {
+	ID uint `param:"id" validate:"required"`
+}
+
+type SessionResponse struct {
+	ID         uint      `json:"id"`
+	ClientID   string    `json:"client_id,omitempty"`
+	DeviceName string    `json:"device_name"`
+	UserAgent  string    `json:"user_agent"`
+	IPAddress  string    `json:"ip_address"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+}
+
+func NewSessionResponse(session *models.Session) SessionResponse {
+	return SessionResponse{
+		ID:         session.ID,
+		ClientID:   session.ClientID,
+		DeviceName: session.DeviceName,
+		UserAgent:  session.UserAgent,
+		IPAddress:  session.IPAddress,
+		CreatedAt:  session.CreatedAt,
+		LastSeenAt: session.LastSeenAt,
+	}
+}
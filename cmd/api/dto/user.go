@@ -0,0 +1,49 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+)
+
+type SignupRequest struct {
+	Name     string `json:"name" validate:"required,max=120"`
+	Email    string `json:"email" validate:"required,email,max=255"`
+	Password string `json:"password" validate:"required"`
+	Locale   string `json:"locale" validate:"omitempty,oneof=es en pt"`
+}
+
+type UpdateUserRequest struct {
+	Locale string `json:"locale" validate:"required,oneof=es en pt"`
+}
+
+type UserResponse struct {
+	ID            uint      `json:"id"`
+	Name          string    `json:"name"`
+	Email         string    `json:"email"`
+	EmailVerified bool      `json:"email_verified"`
+	PhoneNumber   string    `json:"phone_number,omitempty"`
+	Locale        string    `json:"locale,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+func NewUserResponse(user *models.User) UserResponse {
+	return UserResponse{
+		ID:            user.ID,
+		Name:          user.Name,
+		Email:         user.Email,
+		EmailVerified: user.EmailVerified,
+		PhoneNumber:   user.PhoneNumber,
+		Locale:        user.Locale,
+		CreatedAt:     user.CreatedAt,
+	}
+}
+
+type VerifyEmailRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Code  string `json:"code" validate:"required,numeric,len=6"`
+}
+
+type ResendVerificationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+}
@@ -0,0 +1,53 @@
+package dto
+
+import (
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+)
+
+// WebAuthnBeginResponse lleva las opciones para navigator.credentials.create/get y el identificador de sesión
+// que el cliente debe devolver al terminar la ceremonia.
+type WebAuthnBeginResponse struct {
+	SessionID string      `json:"session_id"`
+	Options   interface{} `json:"options" swaggertype:"object"`
+}
+
+type WebAuthnRegistrationRequest struct {
+	SessionID  string          `json:"session_id" validate:"required"`
+	Name       string          `json:"name" validate:"max=100"`
+	Credential json.RawMessage `json:"credential" validate:"required" swaggertype:"object"`
+}
+
+type WebAuthnLoginRequest struct {
+	SessionID  string          `json:"session_id" validate:"required"`
+	Credential json.RawMessage `json:"credential" validate:"required" swaggertype:"object"`
+}
+
+type WebAuthnCredentialRequest struct {
+	ID uint `param:"id" validate:"required"`
+}
+
+type WebAuthnCredentialResponse struct {
+	ID         uint       `json:"id"`
+	Name       string     `json:"name"`
+	Transports []string   `json:"transports"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+func NewWebAuthnCredentialResponse(credential *models.WebAuthnCredential) WebAuthnCredentialResponse {
+	transports := []string{}
+	if credential.Transports != "" {
+		transports = strings.Split(credential.Transports, ",")
+	}
+	return WebAuthnCredentialResponse{
+		ID:         credential.ID,
+		Name:       credential.Name,
+		Transports: transports,
+		CreatedAt:  credential.CreatedAt,
+		LastUsedAt: credential.LastUsedAt,
+	}
+}
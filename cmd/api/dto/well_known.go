@@ -0,0 +1,7 @@
+package dto
+
+import "github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+
+type JWKSResponse struct {
+	Keys []utils.JWK `json:"keys"`
+}
@@ -0,0 +1,33 @@
+package mailer
+
+import (
+	"context"
+)
+
+// Message describe un email transaccional; Template es el nombre de una plantilla de constants y el asunto sale de
+// ella. Locale es el idioma preferido del destinatario; si está vacío se usa el Accept-Language de la petición.
+type Message struct {
+	To       string
+	Locale   string
+	Template string
+	Data     map[string]string
+}
+
+type IMailer interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// Email es un mensaje ya renderizado, listo para que un transporte lo entregue.
+type Email struct {
+	From     string
+	FromName string
+	To       string
+	Subject  string
+	Text     string
+	HTML     string
+}
+
+// ITransport entrega un email ya renderizado: por SMTP, a un archivo o al log.
+type ITransport interface {
+	Deliver(ctx context.Context, email Email) error
+}
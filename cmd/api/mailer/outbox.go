@@ -0,0 +1,159 @@
+package mailer
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+// maxLastErrorLength es el tamaño de la columna last_error.
+const maxLastErrorLength = 1000
+
+// OutboxMailer no envía nada: encola el email en la tabla outbox. El repositorio escribe en la transacción que el
+// llamador abrió con repositories.ITransactor, junto con el usuario o el token que el email anuncia; como
+// OutboxDispatcher solo ve filas confirmadas, un email nunca sale por una operación que terminó deshaciéndose.
+type OutboxMailer struct {
+	OutboxRepository repositories.IOutboxEmailRepository
+	Renderer         *Renderer
+	now              func() time.Time
+}
+
+func NewOutboxMailer(outboxRepository repositories.IOutboxEmailRepository, renderer *Renderer) *OutboxMailer {
+	return &OutboxMailer{
+		OutboxRepository: outboxRepository,
+		Renderer:         renderer,
+		now:              time.Now,
+	}
+}
+
+// Send elige el idioma al encolar, mientras el Accept-Language de la petición sigue en el contexto, y renderiza
+// el asunto para que una plantilla o un dato faltante falle acá y no en el envío.
+func (m *OutboxMailer) Send(ctx context.Context, message Message) error {
+	locale := utils.RequestLocale(ctx, message.Locale)
+	content, err := m.Renderer.Render(message.Template, locale, message.Data)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(message.Data)
+	if err != nil {
+		return err
+	}
+
+	now := m.now()
+	return m.OutboxRepository.Create(ctx, &models.OutboxEmail{
+		Recipient:     message.To,
+		Subject:       content.Subject,
+		Template:      message.Template,
+		Locale:        locale,
+		Data:          string(data),
+		Status:        models.OutboxStatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	})
+}
+
+// OutboxDispatcher renderiza y entrega los emails pendientes del outbox. Un envío fallido se reintenta con espera
+// exponencial; tras MaxAttempts el email queda en estado failed.
+type OutboxDispatcher struct {
+	OutboxRepository repositories.IOutboxEmailRepository
+	Transport        ITransport
+	Renderer         *Renderer
+	config           config.MailDeliveryConfig
+	now              func() time.Time
+}
+
+func NewOutboxDispatcher(
+	outboxRepository repositories.IOutboxEmailRepository,
+	transport ITransport,
+	renderer *Renderer,
+	mailConfig config.MailDeliveryConfig,
+) *OutboxDispatcher {
+	return &OutboxDispatcher{
+		OutboxRepository: outboxRepository,
+		Transport:        transport,
+		Renderer:         renderer,
+		config:           mailConfig,
+		now:              time.Now,
+	}
+}
+
+func (d *OutboxDispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := d.DispatchPending(ctx); err != nil {
+				log.Error(ctx, "error dispatching outbox emails: ", err)
+			}
+		}
+	}
+}
+
+// DispatchPending procesa un lote. Los emails se reservan por el doble del intervalo de reintento base, tiempo de
+// sobra para entregarlos; si el proceso muere antes de marcarlos, otra pasada los retoma al vencer la reserva.
+func (d *OutboxDispatcher) DispatchPending(ctx context.Context) error {
+	now := d.now()
+	emails, err := d.OutboxRepository.ClaimDue(ctx, now, d.config.OutboxBatchSize, now.Add(2*d.config.RetryBaseDelay))
+	if err != nil {
+		return err
+	}
+
+	for _, email := range emails {
+		if err := d.deliver(ctx, email); err != nil {
+			d.retry(ctx, email, err)
+			continue
+		}
+		if err := d.OutboxRepository.MarkSent(ctx, email.ID, d.now()); err != nil {
+			log.Error(ctx, "error marking outbox email as sent: ", err)
+		}
+	}
+	return nil
+}
+
+func (d *OutboxDispatcher) deliver(ctx context.Context, email models.OutboxEmail) error {
+	var data map[string]string
+	if err := json.Unmarshal([]byte(email.Data), &data); err != nil {
+		return err
+	}
+	content, err := d.Renderer.Render(email.Template, email.Locale, data)
+	if err != nil {
+		return err
+	}
+
+	return d.Transport.Deliver(ctx, Email{
+		From:     d.config.From,
+		FromName: d.config.FromName,
+		To:       email.Recipient,
+		Subject:  email.Subject,
+		Text:     content.Text,
+		HTML:     content.HTML,
+	})
+}
+
+// retry espera RetryBaseDelay, luego el doble, y así hasta MaxAttempts intentos.
+func (d *OutboxDispatcher) retry(ctx context.Context, email models.OutboxEmail, cause error) {
+	attempts := email.Attempts + 1
+	dead := attempts >= d.config.MaxAttempts
+	nextAttemptAt := d.now().Add(d.config.RetryBaseDelay << (attempts - 1))
+
+	lastError := cause.Error()
+	if len(lastError) > maxLastErrorLength {
+		lastError = lastError[:maxLastErrorLength]
+	}
+
+	log.Error(ctx, "error delivering outbox email: ", cause)
+	if err := d.OutboxRepository.MarkFailed(ctx, email.ID, attempts, nextAttemptAt, lastError, dead); err != nil {
+		log.Error(ctx, "error marking outbox email as failed: ", err)
+	}
+}
@@ -0,0 +1,193 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type fakeOutboxEmailRepository struct {
+	emails []*models.OutboxEmail
+}
+
+func (r *fakeOutboxEmailRepository) Create(_ context.Context, email *models.OutboxEmail) error {
+	email.ID = uint(len(r.emails) + 1)
+	r.emails = append(r.emails, email)
+	return nil
+}
+
+func (r *fakeOutboxEmailRepository) ClaimDue(_ context.Context, now time.Time, limit int, leaseUntil time.Time) ([]models.OutboxEmail, error) {
+	var due []models.OutboxEmail
+	for _, email := range r.emails {
+		if len(due) < limit && email.Status == models.OutboxStatusPending && !email.NextAttemptAt.After(now) {
+			email.NextAttemptAt = leaseUntil
+			due = append(due, *email)
+		}
+	}
+	return due, nil
+}
+
+func (r *fakeOutboxEmailRepository) MarkSent(_ context.Context, id uint, sentAt time.Time) error {
+	email := r.emails[id-1]
+	email.Status, email.SentAt, email.Data = models.OutboxStatusSent, &sentAt, "{}"
+	return nil
+}
+
+func (r *fakeOutboxEmailRepository) MarkFailed(_ context.Context, id uint, attempts int, nextAttemptAt time.Time, lastError string, dead bool) error {
+	email := r.emails[id-1]
+	email.Attempts, email.NextAttemptAt, email.LastError = attempts, nextAttemptAt, lastError
+	if dead {
+		email.Status, email.Data = models.OutboxStatusFailed, "{}"
+	}
+	return nil
+}
+
+type fakeTransport struct {
+	delivered []Email
+	err       error
+}
+
+func (t *fakeTransport) Deliver(_ context.Context, email Email) error {
+	if t.err != nil {
+		return t.err
+	}
+	t.delivered = append(t.delivered, email)
+	return nil
+}
+
+func newTestOutbox(t *testing.T, now *time.Time) (*OutboxMailer, *OutboxDispatcher, *fakeOutboxEmailRepository, *fakeTransport) {
+	t.Helper()
+	renderer, err := NewRenderer("https://tareaya.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	repository := &fakeOutboxEmailRepository{}
+	transport := &fakeTransport{}
+	outboxMailer := NewOutboxMailer(repository, renderer)
+	outboxMailer.now = func() time.Time { return *now }
+	dispatcher := NewOutboxDispatcher(repository, transport, renderer, config.MailDeliveryConfig{
+		From:            "notificacion@tareaya.com",
+		OutboxBatchSize: 10,
+		MaxAttempts:     3,
+		RetryBaseDelay:  time.Minute,
+	})
+	dispatcher.now = func() time.Time { return *now }
+	return outboxMailer, dispatcher, repository, transport
+}
+
+func TestOutbox(t *testing.T) {
+	ctx := context.Background()
+	message := Message{
+		To:       "ana@example.com",
+		Template: constants.TemplateVerifyEmail,
+		Data:     map[string]string{"name": "Ana", "code": "123456"},
+	}
+
+	t.Run("queues the email and delivers it on the next dispatch", func(t *testing.T) {
+		now := time.Now()
+		outboxMailer, dispatcher, repository, transport := newTestOutbox(t, &now)
+
+		if err := outboxMailer.Send(ctx, message); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(transport.delivered) != 0 || repository.emails[0].Status != models.OutboxStatusPending {
+			t.Fatalf("expected Send to only queue the email")
+		}
+
+		if err := dispatcher.DispatchPending(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(transport.delivered) != 1 || transport.delivered[0].To != "ana@example.com" || transport.delivered[0].Subject != "Verificar email" {
+			t.Fatalf("unexpected deliveries: %+v", transport.delivered)
+		}
+		if email := repository.emails[0]; email.Status != models.OutboxStatusSent || email.Data != "{}" {
+			t.Errorf("expected the email to be sent and its data cleared, got %+v", email)
+		}
+
+		if err := dispatcher.DispatchPending(ctx); err != nil || len(transport.delivered) != 1 {
+			t.Errorf("expected sent emails not to be delivered again")
+		}
+	})
+
+	t.Run("retries with exponential backoff until max attempts", func(t *testing.T) {
+		now := time.Now()
+		outboxMailer, dispatcher, repository, transport := newTestOutbox(t, &now)
+		transport.err = errors.New("connection refused")
+		_ = outboxMailer.Send(ctx, message)
+		email := repository.emails[0]
+
+		_ = dispatcher.DispatchPending(ctx)
+		if email.Attempts != 1 || !email.NextAttemptAt.Equal(now.Add(time.Minute)) || email.LastError != "connection refused" {
+			t.Fatalf("unexpected first retry: %+v", email)
+		}
+
+		_ = dispatcher.DispatchPending(ctx)
+		if email.Attempts != 1 {
+			t.Fatalf("expected no attempt before the retry is due")
+		}
+
+		now = now.Add(time.Minute)
+		_ = dispatcher.DispatchPending(ctx)
+		if email.Attempts != 2 || !email.NextAttemptAt.Equal(now.Add(2*time.Minute)) {
+			t.Fatalf("unexpected second retry: %+v", email)
+		}
+
+		now = now.Add(2 * time.Minute)
+		_ = dispatcher.DispatchPending(ctx)
+		if email.Attempts != 3 || email.Status != models.OutboxStatusFailed || email.Data != "{}" {
+			t.Errorf("expected the email to be discarded after max attempts, got %+v", email)
+		}
+	})
+
+	t.Run("rejects unknown templates when queueing", func(t *testing.T) {
+		now := time.Now()
+		outboxMailer, _, repository, _ := newTestOutbox(t, &now)
+
+		if err := outboxMailer.Send(ctx, Message{To: "ana@example.com", Template: "missing"}); err == nil || len(repository.emails) != 0 {
+			t.Errorf("expected the email not to be queued")
+		}
+	})
+
+	t.Run("picks the user locale, then the request language", func(t *testing.T) {
+		testCases := []struct {
+			name           string
+			locale         string
+			acceptLanguage string
+			expected       string
+			subject        string
+		}{
+			{name: "user preference", locale: "en", acceptLanguage: "pt-BR", expected: "en", subject: "Verify your email"},
+			{name: "accept language", acceptLanguage: "fr, pt-BR;q=0.8", expected: "pt", subject: "Verificar email"},
+			{name: "default locale", acceptLanguage: "fr", expected: "es", subject: "Verificar email"},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				now := time.Now()
+				outboxMailer, dispatcher, repository, transport := newTestOutbox(t, &now)
+				requestCtx := utils.WithClientInfo(ctx, utils.ClientInfo{AcceptLanguage: tc.acceptLanguage})
+
+				localized := message
+				localized.Locale = tc.locale
+				if err := outboxMailer.Send(requestCtx, localized); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				_ = dispatcher.DispatchPending(ctx)
+
+				if email := repository.emails[0]; email.Locale != tc.expected || email.Subject != tc.subject {
+					t.Errorf("unexpected locale %q and subject %q", email.Locale, email.Subject)
+				}
+				if len(transport.delivered) != 1 || transport.delivered[0].Subject != tc.subject {
+					t.Errorf("unexpected deliveries: %+v", transport.delivered)
+				}
+			})
+		}
+	})
+}
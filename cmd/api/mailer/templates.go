@@ -0,0 +1,108 @@
+package mailer
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	htmltemplate "html/template"
+	texttemplate "text/template"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+//go:embed templates
+var templateFiles embed.FS
+
+var templateNames = []string{
+	constants.TemplateVerifyEmail,
+	constants.TemplatePasswordReset,
+	constants.TemplateMagicLink,
+	constants.TemplateLoginCode,
+}
+
+var templateLocales = []string{
+	constants.LocaleSpanish,
+	constants.LocaleEnglish,
+	constants.LocalePortuguese,
+}
+
+// Content es un email renderizado en un idioma.
+type Content struct {
+	Subject string
+	Text    string
+	HTML    string
+}
+
+// Renderer arma el asunto y el cuerpo en texto y en HTML de cada plantilla de templates/<locale>/. El .txt define
+// además el bloque "subject"; el .html define "content", que se inserta en el layout.html del mismo idioma. Todas
+// las plantillas reciben, además de Message.Data, app_url con la base de los enlaces.
+type Renderer struct {
+	appURL string
+	text   map[string]*texttemplate.Template
+	html   map[string]*htmltemplate.Template
+}
+
+// NewRenderer parsea las plantillas una sola vez; un error indica una plantilla mal escrita o que falta en algún
+// idioma.
+func NewRenderer(appURL string) (*Renderer, error) {
+	r := &Renderer{
+		appURL: appURL,
+		text:   map[string]*texttemplate.Template{},
+		html:   map[string]*htmltemplate.Template{},
+	}
+
+	for _, locale := range templateLocales {
+		dir := "templates/" + locale + "/"
+		for _, name := range templateNames {
+			text, err := texttemplate.New(name+".txt").Option("missingkey=error").ParseFS(templateFiles, dir+name+".txt")
+			if err != nil {
+				return nil, err
+			}
+			if text.Lookup("subject") == nil {
+				return nil, fmt.Errorf("email template %s%s.txt does not define a subject", dir, name)
+			}
+			html, err := htmltemplate.New("layout.html").Option("missingkey=error").ParseFS(templateFiles, dir+"layout.html", dir+name+".html")
+			if err != nil {
+				return nil, err
+			}
+			r.text[templateKey(name, locale)] = text
+			r.html[templateKey(name, locale)] = html
+		}
+	}
+	return r, nil
+}
+
+// Render usa constants.DefaultLocale si locale no está soportado.
+func (r *Renderer) Render(name, locale string, data map[string]string) (Content, error) {
+	if locale = utils.SupportedLocale(locale); locale == "" {
+		locale = constants.DefaultLocale
+	}
+	key := templateKey(name, locale)
+	text, ok := r.text[key]
+	if !ok {
+		return Content{}, fmt.Errorf("unknown email template %q", name)
+	}
+
+	values := make(map[string]string, len(data)+1)
+	for key, value := range data {
+		values[key] = value
+	}
+	values["app_url"] = r.appURL
+
+	var subject, textBody, htmlBody bytes.Buffer
+	if err := text.ExecuteTemplate(&subject, "subject", values); err != nil {
+		return Content{}, err
+	}
+	if err := text.Execute(&textBody, values); err != nil {
+		return Content{}, err
+	}
+	if err := r.html[key].Execute(&htmlBody, values); err != nil {
+		return Content{}, err
+	}
+	return Content{Subject: subject.String(), Text: textBody.String(), HTML: htmlBody.String()}, nil
+}
+
+func templateKey(name, locale string) string {
+	return locale + "/" + name
+}
@@ -0,0 +1,90 @@
+package mailer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+)
+
+func TestRenderer(t *testing.T) {
+	renderer, err := NewRenderer("https://tareaya.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	t.Run("renders text and html with links to the app", func(t *testing.T) {
+		content, err := renderer.Render(constants.TemplatePasswordReset, constants.LocaleSpanish, map[string]string{"name": "Ana", "token": "abc123"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		link := "https://tareaya.com/reset-password?token=abc123"
+		if !strings.Contains(content.Text, link) || !strings.Contains(content.HTML, `href="`+link+`"`) {
+			t.Errorf("expected the reset link in both bodies:\n%s\n%s", content.Text, content.HTML)
+		}
+		if !strings.Contains(content.HTML, "<!DOCTYPE html>") {
+			t.Errorf("expected the html body to use the layout")
+		}
+		if content.Subject != "Restablecer contraseña" || strings.HasPrefix(content.Text, "\n") {
+			t.Errorf("unexpected subject %q or leading newline in text", content.Subject)
+		}
+	})
+
+	t.Run("renders every template in every locale", func(t *testing.T) {
+		data := map[string]string{"name": "Ana", "code": "123456", "token": "abc123"}
+		subjects := map[string]bool{}
+		for _, locale := range templateLocales {
+			for _, name := range templateNames {
+				content, err := renderer.Render(name, locale, data)
+				if err != nil {
+					t.Fatalf("%s/%s: unexpected error: %v", locale, name, err)
+				}
+				if content.Subject == "" || !strings.Contains(content.HTML, `<html lang="`+locale+`">`) {
+					t.Errorf("%s/%s: unexpected content: %+v", locale, name, content)
+				}
+				subjects[content.Subject] = true
+			}
+		}
+		if len(subjects) < 2*len(templateLocales) {
+			t.Errorf("expected localized subjects, got %v", subjects)
+		}
+	})
+
+	t.Run("falls back to the default locale", func(t *testing.T) {
+		content, err := renderer.Render(constants.TemplateVerifyEmail, "fr", map[string]string{"name": "Ana", "code": "123456"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if content.Subject != "Verificar email" {
+			t.Errorf("expected the spanish template, got %q", content.Subject)
+		}
+
+		content, _ = renderer.Render(constants.TemplateVerifyEmail, "pt-BR", map[string]string{"name": "Ana", "code": "123456"})
+		if !strings.HasPrefix(content.Text, "Olá Ana") {
+			t.Errorf("expected regional variants to use their language, got %q", content.Text)
+		}
+	})
+
+	t.Run("escapes data in the html body only", func(t *testing.T) {
+		content, err := renderer.Render(constants.TemplateVerifyEmail, constants.LocaleEnglish, map[string]string{"name": "<b>Ana</b>", "code": "123456"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Contains(content.HTML, "<b>Ana</b>") || !strings.Contains(content.HTML, "&lt;b&gt;Ana&lt;/b&gt;") {
+			t.Errorf("expected the name to be escaped in html")
+		}
+		if !strings.Contains(content.Text, "<b>Ana</b>") {
+			t.Errorf("expected the text body to keep the name as is")
+		}
+	})
+
+	t.Run("fails on unknown templates and missing data", func(t *testing.T) {
+		if _, err := renderer.Render("missing", constants.LocaleSpanish, nil); err == nil {
+			t.Errorf("expected an error for an unknown template")
+		}
+		if _, err := renderer.Render(constants.TemplateLoginCode, constants.LocaleSpanish, map[string]string{"name": "Ana"}); err == nil {
+			t.Errorf("expected an error for a missing code")
+		}
+	})
+}
@@ -0,0 +1,199 @@
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+// SMTPTransport envía por SMTP y exige STARTTLS: un servidor que no lo ofrece es un error, no un envío en claro.
+// Cada envío tiene timeout como plazo total para que un servidor colgado no bloquee al dispatcher.
+type SMTPTransport struct {
+	host     string
+	port     string
+	username string
+	password string
+	timeout  time.Duration
+}
+
+func NewSMTPTransport(host, port, username, password string, timeout time.Duration) *SMTPTransport {
+	return &SMTPTransport{host: host, port: port, username: username, password: password, timeout: timeout}
+}
+
+func (t *SMTPTransport) Deliver(ctx context.Context, email Email) error {
+	message, err := buildMIMEMessage(email, time.Now())
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(t.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	dialer := net.Dialer{Deadline: deadline}
+	conn, err := dialer.DialContext(ctx, "tcp", net.JoinHostPort(t.host, t.port))
+	if err != nil {
+		return err
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		conn.Close()
+		return err
+	}
+
+	client, err := smtp.NewClient(conn, t.host)
+	if err != nil {
+		conn.Close()
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); !ok {
+		return errors.New("smtp server does not offer STARTTLS")
+	}
+	if err := client.StartTLS(&tls.Config{ServerName: t.host, MinVersion: tls.VersionTLS12}); err != nil {
+		return err
+	}
+	if t.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", t.username, t.password, t.host)); err != nil {
+			return err
+		}
+	}
+	if err := client.Mail(email.From); err != nil {
+		return err
+	}
+	if err := client.Rcpt(email.To); err != nil {
+		return err
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := writer.Write(message); err != nil {
+		return err
+	}
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+// FileTransport escribe cada email como un .eml en dir, para abrirlo con cualquier cliente de correo en local.
+type FileTransport struct {
+	dir string
+}
+
+func NewFileTransport(dir string) *FileTransport {
+	return &FileTransport{dir: dir}
+}
+
+func (t *FileTransport) Deliver(ctx context.Context, email Email) error {
+	now := time.Now()
+	message, err := buildMIMEMessage(email, now)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		return err
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", now.UTC().Format("20060102T150405.000000000"), randomHex(4))
+	path := filepath.Join(t.dir, name)
+	if err := os.WriteFile(path, message, 0o600); err != nil {
+		return err
+	}
+	log.Info(ctx, fmt.Sprintf("EMAIL - to: %v, subject: %v, file: %v", email.To, email.Subject, path))
+	return nil
+}
+
+// LogTransport escribe el texto del email en el log en lugar de enviarlo.
+type LogTransport struct {
+}
+
+func NewLogTransport() *LogTransport {
+	return &LogTransport{}
+}
+
+func (t *LogTransport) Deliver(ctx context.Context, email Email) error {
+	log.Info(ctx, fmt.Sprintf("EMAIL - to: %v, subject: %v\n%v", email.To, email.Subject, email.Text))
+	return nil
+}
+
+// buildMIMEMessage arma un multipart/alternative con la parte de texto primero, como pide el RFC 2046: los
+// clientes muestran la última parte que saben renderizar.
+func buildMIMEMessage(email Email, date time.Time) ([]byte, error) {
+	var body bytes.Buffer
+	parts := multipart.NewWriter(&body)
+
+	for _, part := range []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", email.Text},
+		{"text/html; charset=utf-8", email.HTML},
+	} {
+		writer, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+		encoder := quotedprintable.NewWriter(writer)
+		if _, err := encoder.Write([]byte(part.content)); err != nil {
+			return nil, err
+		}
+		if err := encoder.Close(); err != nil {
+			return nil, err
+		}
+	}
+	if err := parts.Close(); err != nil {
+		return nil, err
+	}
+
+	from := (&mail.Address{Name: email.FromName, Address: email.From}).String()
+	var message bytes.Buffer
+	for _, header := range [][2]string{
+		{"From", from},
+		{"To", email.To},
+		{"Subject", mime.QEncoding.Encode("utf-8", email.Subject)},
+		{"Date", date.Format(time.RFC1123Z)},
+		{"Message-ID", fmt.Sprintf("<%s@%s>", randomHex(16), domainOf(email.From))},
+		{"MIME-Version", "1.0"},
+		{"Content-Type", "multipart/alternative; boundary=" + parts.Boundary()},
+	} {
+		fmt.Fprintf(&message, "%s: %s\r\n", header[0], header[1])
+	}
+	message.WriteString("\r\n")
+	message.Write(body.Bytes())
+	return message.Bytes(), nil
+}
+
+func domainOf(address string) string {
+	if at := strings.LastIndex(address, "@"); at >= 0 {
+		return address[at+1:]
+	}
+	return "localhost"
+}
+
+func randomHex(size int) string {
+	buf := make([]byte, size)
+	_, _ = rand.Read(buf)
+	return hex.EncodeToString(buf)
+}
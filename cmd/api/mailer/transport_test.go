@@ -0,0 +1,131 @@
+package mailer
+
+import (
+	"context"
+	"io"
+	"mime"
+	"mime/multipart"
+	"net"
+	"net/mail"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBuildMIMEMessage(t *testing.T) {
+	raw, err := buildMIMEMessage(Email{
+		From:     "notificacion@tareaya.com",
+		FromName: "Tareaya",
+		To:       "ana@example.com",
+		Subject:  "Restablecer contraseña",
+		Text:     "Hola Ana",
+		HTML:     "<p>Hola Ana</p>",
+	}, time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	message, err := mail.ReadMessage(strings.NewReader(string(raw)))
+	if err != nil {
+		t.Fatalf("expected a parseable message: %v", err)
+	}
+	if from := message.Header.Get("From"); from != `"Tareaya" <notificacion@tareaya.com>` {
+		t.Errorf("unexpected from: %q", from)
+	}
+	if subject, _ := new(mime.WordDecoder).DecodeHeader(message.Header.Get("Subject")); subject != "Restablecer contraseña" {
+		t.Errorf("unexpected subject: %q", subject)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(message.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("unexpected content type: %q", message.Header.Get("Content-Type"))
+	}
+	reader := multipart.NewReader(message.Body, params["boundary"])
+	for _, expected := range []struct{ contentType, body string }{
+		{"text/plain; charset=utf-8", "Hola Ana"},
+		{"text/html; charset=utf-8", "<p>Hola Ana</p>"},
+	} {
+		part, err := reader.NextPart()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		body, _ := io.ReadAll(part)
+		if part.Header.Get("Content-Type") != expected.contentType || string(body) != expected.body {
+			t.Errorf("unexpected part %q: %q", part.Header.Get("Content-Type"), body)
+		}
+	}
+}
+
+func TestSMTPTransport(t *testing.T) {
+	// serve acepta una conexión y le pasa el socket a handle, que hace de servidor SMTP.
+	serve := func(t *testing.T, handle func(conn net.Conn)) (string, string) {
+		t.Helper()
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		t.Cleanup(func() { listener.Close() })
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			defer conn.Close()
+			handle(conn)
+		}()
+		host, port, _ := net.SplitHostPort(listener.Addr().String())
+		return host, port
+	}
+	email := Email{From: "no-reply@tareaya.com", To: "ana@example.com", Subject: "Hola", Text: "Hola", HTML: "<p>Hola</p>"}
+
+	t.Run("a server that never answers times out", func(t *testing.T) {
+		host, port := serve(t, func(conn net.Conn) {
+			_, _ = io.Copy(io.Discard, conn)
+		})
+		transport := NewSMTPTransport(host, port, "", "", 200*time.Millisecond)
+
+		start := time.Now()
+		if err := transport.Deliver(context.Background(), email); err == nil {
+			t.Fatalf("expected a timeout error")
+		}
+		if elapsed := time.Since(start); elapsed > 2*time.Second {
+			t.Errorf("delivery took %v, expected it to give up after the timeout", elapsed)
+		}
+	})
+
+	t.Run("a server without STARTTLS is rejected", func(t *testing.T) {
+		commands := make(chan string, 10)
+		host, port := serve(t, func(conn net.Conn) {
+			text := textproto.NewConn(conn)
+			_ = text.PrintfLine("220 localhost ESMTP")
+			for {
+				line, err := text.ReadLine()
+				if err != nil {
+					return
+				}
+				commands <- strings.Fields(line)[0]
+				switch strings.Fields(line)[0] {
+				case "EHLO":
+					_ = text.PrintfLine("250-localhost")
+					_ = text.PrintfLine("250 AUTH PLAIN")
+				case "QUIT":
+					_ = text.PrintfLine("221 bye")
+					return
+				default:
+					_ = text.PrintfLine("250 ok")
+				}
+			}
+		})
+		transport := NewSMTPTransport(host, port, "", "", time.Second)
+
+		if err := transport.Deliver(context.Background(), email); err == nil || !strings.Contains(err.Error(), "STARTTLS") {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for len(commands) > 0 {
+			if command := <-commands; command == "MAIL" {
+				t.Errorf("expected no message to be sent without TLS")
+			}
+		}
+	})
+}
@@ -11,6 +11,9 @@ import (
 // @version 1.0
 // @description Taska API for use with his admin project.
 // @contact.email wilsonev.saldarriaga88@gmail.com
+// @securityDefinitions.apikey BearerAuth
+// @in header
+// @name Authorization
 func main() {
 	appInstance, err := app.Start()
 	if err != nil {
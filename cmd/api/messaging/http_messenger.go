@@ -0,0 +1,57 @@
+package messaging
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/rusty"
+)
+
+// HTTPMessenger envía por la API del proveedor de notificaciones: POST {baseURL}/v1/messages con el cuerpo
+// {"to", "channel", "text", "sender_id"} y la API key como bearer token. Un 400 o 422 es un número rechazado.
+type HTTPMessenger struct {
+	RustyClient rusty.IRustyClient
+	baseURL     string
+	apiKey      string
+	senderID    string
+}
+
+func NewHTTPMessenger(rustyClient rusty.IRustyClient, baseURL, apiKey, senderID string) *HTTPMessenger {
+	return &HTTPMessenger{
+		RustyClient: rustyClient,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		apiKey:      apiKey,
+		senderID:    senderID,
+	}
+}
+
+type sendMessageRequest struct {
+	To       string `json:"to"`
+	Channel  string `json:"channel"`
+	Text     string `json:"text"`
+	SenderID string `json:"sender_id,omitempty"`
+}
+
+func (m *HTTPMessenger) Send(ctx context.Context, message Message) error {
+	response := m.RustyClient.Post(ctx, m.baseURL+"/v1/messages", map[string]string{
+		"Accept":        "application/json",
+		"Authorization": "Bearer " + m.apiKey,
+	}, sendMessageRequest{
+		To:       message.To,
+		Channel:  message.Channel,
+		Text:     message.Text,
+		SenderID: m.senderID,
+	}, []string{fmt.Sprintf("channel:%v", message.Channel)})
+
+	switch {
+	case response.StatusCode == http.StatusBadRequest || response.StatusCode == http.StatusUnprocessableEntity:
+		return ErrInvalidRecipient
+	case response.Error != nil:
+		return response.Error
+	case response.StatusCode < 200 || response.StatusCode > 299:
+		return fmt.Errorf("messaging provider answered %d", response.StatusCode)
+	}
+	return nil
+}
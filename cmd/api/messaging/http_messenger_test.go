@@ -0,0 +1,109 @@
+package messaging
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/rusty/rustytest"
+)
+
+// fakeProvider es el proveedor de notificaciones: guarda los mensajes aceptados y rechaza los números de
+// rejected con 422, como la API real.
+type fakeProvider struct {
+	server   *httptest.Server
+	mutex    sync.Mutex
+	received []sendMessageRequest
+	rejected map[string]bool
+	status   int
+}
+
+func newFakeProvider(t *testing.T) *fakeProvider {
+	provider := &fakeProvider{rejected: map[string]bool{}, status: http.StatusAccepted}
+	provider.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/v1/messages" || r.Header.Get("Authorization") != "Bearer test-key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		var message sendMessageRequest
+		if err := json.NewDecoder(r.Body).Decode(&message); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		if provider.rejected[message.To] {
+			w.WriteHeader(http.StatusUnprocessableEntity)
+			return
+		}
+
+		provider.mutex.Lock()
+		defer provider.mutex.Unlock()
+		if provider.status == http.StatusAccepted {
+			provider.received = append(provider.received, message)
+		}
+		w.WriteHeader(provider.status)
+		_, _ = w.Write([]byte(`{"id":"msg_1"}`))
+	}))
+	t.Cleanup(provider.server.Close)
+	return provider
+}
+
+func TestHTTPMessenger(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("posts the message to the provider", func(t *testing.T) {
+		provider := newFakeProvider(t)
+		messenger := NewHTTPMessenger(rustytest.Client{}, provider.server.URL+"/", "test-key", "Tareaya")
+
+		err := messenger.Send(ctx, Message{To: "+573001234567", Channel: constants.MessageChannelWhatsApp, Text: "hola"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := sendMessageRequest{To: "+573001234567", Channel: constants.MessageChannelWhatsApp, Text: "hola", SenderID: "Tareaya"}
+		if len(provider.received) != 1 || provider.received[0] != expected {
+			t.Errorf("unexpected messages: %+v", provider.received)
+		}
+	})
+
+	t.Run("maps provider errors", func(t *testing.T) {
+		provider := newFakeProvider(t)
+		provider.rejected["+573000000000"] = true
+		messenger := NewHTTPMessenger(rustytest.Client{}, provider.server.URL, "test-key", "Tareaya")
+
+		if err := messenger.Send(ctx, Message{To: "+573000000000", Channel: constants.MessageChannelSMS}); !errors.Is(err, ErrInvalidRecipient) {
+			t.Errorf("expected an invalid recipient, got %v", err)
+		}
+
+		provider.status = http.StatusServiceUnavailable
+		if err := messenger.Send(ctx, Message{To: "+573001234567", Channel: constants.MessageChannelSMS}); err == nil || errors.Is(err, ErrInvalidRecipient) {
+			t.Errorf("expected a provider error, got %v", err)
+		}
+
+		unauthorized := NewHTTPMessenger(rustytest.Client{}, provider.server.URL, "wrong-key", "Tareaya")
+		if err := unauthorized.Send(ctx, Message{To: "+573001234567", Channel: constants.MessageChannelSMS}); err == nil {
+			t.Errorf("expected an error for a rejected api key")
+		}
+
+		provider.server.Close()
+		if err := messenger.Send(ctx, Message{To: "+573001234567", Channel: constants.MessageChannelSMS}); err == nil {
+			t.Errorf("expected an error when the provider is unreachable")
+		}
+	})
+}
+
+func TestCodeText(t *testing.T) {
+	for locale := range codeTexts {
+		// Un SMS con caracteres fuera de GSM-7 admite 70 caracteres por segmento.
+		if text := CodeText(locale, "123456", constants.PhoneCodeExpiration); utf8.RuneCountInString(text) > 70 {
+			t.Errorf("%s: text does not fit in one sms: %q", locale, text)
+		}
+	}
+	if CodeText("fr", "123456", 10) != CodeText(constants.DefaultLocale, "123456", 10) {
+		t.Errorf("expected unsupported locales to use the default text")
+	}
+}
@@ -0,0 +1,37 @@
+package messaging
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/labstack/gommon/log"
+)
+
+// ErrInvalidRecipient indica que el proveedor rechazó el número: reintentar no sirve.
+var ErrInvalidRecipient = errors.New("invalid recipient")
+
+// Message es un mensaje de texto a un número E.164 por el canal indicado (constants.MessageChannelSMS o
+// constants.MessageChannelWhatsApp).
+type Message struct {
+	To      string
+	Channel string
+	Text    string
+}
+
+type IMessenger interface {
+	Send(ctx context.Context, message Message) error
+}
+
+// LogMessenger escribe los mensajes en el log en lugar de enviarlos.
+type LogMessenger struct {
+}
+
+func NewLogMessenger() *LogMessenger {
+	return &LogMessenger{}
+}
+
+func (m *LogMessenger) Send(ctx context.Context, message Message) error {
+	log.Info(ctx, fmt.Sprintf("MESSAGE - to: %v, channel: %v, text: %v", message.To, message.Channel, message.Text))
+	return nil
+}
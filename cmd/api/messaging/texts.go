@@ -0,0 +1,23 @@
+package messaging
+
+import (
+	"fmt"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+)
+
+// codeTexts son cortos a propósito: un SMS de más de 70 caracteres con acentos se cobra como dos.
+var codeTexts = map[string]string{
+	constants.LocaleSpanish:    "Tu código de Tareaya es %s. Vence en %d min. No lo compartas.",
+	constants.LocaleEnglish:    "Your Tareaya code is %s. It expires in %d min. Don't share it.",
+	constants.LocalePortuguese: "Seu código da Tareaya é %s. Expira em %d min. Não compartilhe.",
+}
+
+// CodeText arma el mensaje con un código de un solo uso; un locale no soportado usa constants.DefaultLocale.
+func CodeText(locale, code string, minutes int) string {
+	text, ok := codeTexts[locale]
+	if !ok {
+		text = codeTexts[constants.DefaultLocale]
+	}
+	return fmt.Sprintf(text, code, minutes)
+}
@@ -0,0 +1,117 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type TokenValidator interface {
+	ValidateAccessToken(ctx context.Context, token string) (*services.AccessTokenClaims, error)
+}
+
+type Authenticator struct {
+	TokenValidator TokenValidator
+}
+
+func NewAuthenticator(tokenValidator TokenValidator) *Authenticator {
+	return &Authenticator{TokenValidator: tokenValidator}
+}
+
+// Authenticate exige un bearer token válido con todos los requiredScopes y deja el principal en echo.Context y
+// en el context.Context de la petición.
+func (a *Authenticator) Authenticate(requiredScopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			token, ok := bearerToken(c.Request())
+			if !ok {
+				return unauthorized(c, "")
+			}
+
+			claims, err := a.TokenValidator.ValidateAccessToken(c.Request().Context(), token)
+			if err != nil {
+				return unauthorized(c, "invalid_token")
+			}
+
+			principal, err := newPrincipal(claims)
+			if err != nil {
+				return unauthorized(c, "invalid_token")
+			}
+
+			c.Set(PrincipalContextKey, principal)
+			c.SetRequest(c.Request().WithContext(WithPrincipal(c.Request().Context(), principal)))
+
+			return RequireScopes(requiredScopes...)(next)(c)
+		}
+	}
+}
+
+// RequireScopes responde 403 si el principal no tiene todos los scopes; debe ir después de Authenticate.
+func RequireScopes(scopes ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal := GetPrincipal(c)
+			if principal == nil {
+				return unauthorized(c, "")
+			}
+
+			for _, scope := range scopes {
+				if !principal.HasScope(scope) {
+					c.Response().Header().Set(echo.HeaderWWWAuthenticate, fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, strings.Join(scopes, " ")))
+					return utils.NewForbiddenError("insufficient scope")
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+func newPrincipal(claims *services.AccessTokenClaims) (*Principal, error) {
+	if claims.IsClientToken() {
+		return &Principal{
+			ClientID: claims.ClientID,
+			Scopes:   claims.Scopes(),
+			TokenID:  claims.ID,
+		}, nil
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Principal{
+		UserID:      userID,
+		ClientID:    claims.ClientID,
+		FirstParty:  claims.IsFirstParty(),
+		Email:       claims.Email,
+		Roles:       claims.Roles,
+		Permissions: claims.Permissions,
+		Scopes:      claims.Scopes(),
+		TokenID:     claims.ID,
+	}, nil
+}
+
+func bearerToken(request *http.Request) (string, bool) {
+	header := request.Header.Get(echo.HeaderAuthorization)
+	scheme, token, found := strings.Cut(header, " ")
+	if !found || !strings.EqualFold(scheme, "Bearer") || strings.TrimSpace(token) == "" {
+		return "", false
+	}
+	return strings.TrimSpace(token), true
+}
+
+// unauthorized sigue RFC 6750: sin token solo se anuncia el esquema, con token inválido se indica el error.
+func unauthorized(c echo.Context, bearerError string) error {
+	challenge := "Bearer"
+	if bearerError != "" {
+		challenge = fmt.Sprintf(`Bearer error="%s"`, bearerError)
+	}
+	c.Response().Header().Set(echo.HeaderWWWAuthenticate, challenge)
+	return utils.NewUnauthorizedError("missing or invalid access token")
+}
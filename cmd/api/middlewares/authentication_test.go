@@ -0,0 +1,130 @@
+package middlewares
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/services"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type fakeTokenValidator struct {
+	tokens map[string]*services.AccessTokenClaims
+}
+
+func (v *fakeTokenValidator) ValidateAccessToken(_ context.Context, token string) (*services.AccessTokenClaims, error) {
+	claims, ok := v.tokens[token]
+	if !ok {
+		return nil, services.ErrInvalidToken
+	}
+	return claims, nil
+}
+
+func newTestRouter(authenticator *Authenticator, scopes ...string) *echo.Echo {
+	router := echo.New()
+	router.HTTPErrorHandler = utils.HTTPErrorHandler
+	router.GET("/protected", func(c echo.Context) error {
+		fromEcho := GetPrincipal(c)
+		fromContext := PrincipalFromContext(c.Request().Context())
+		if fromEcho == nil || fromEcho != fromContext {
+			return c.NoContent(http.StatusInternalServerError)
+		}
+		return c.String(http.StatusOK, fromEcho.Email)
+	}, authenticator.Authenticate(scopes...))
+	return router
+}
+
+func TestAuthenticate(t *testing.T) {
+	authenticator := NewAuthenticator(&fakeTokenValidator{tokens: map[string]*services.AccessTokenClaims{
+		"valid": {
+			Email:            "ana@example.com",
+			Scope:            "profile tasks:read",
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "7", ID: "jti-1"},
+		},
+		"bad-subject": {
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "not-a-number"},
+		},
+		"client": {
+			Scope:            "tasks:read",
+			ClientID:         "partner",
+			RegisteredClaims: jwt.RegisteredClaims{Subject: "partner", ID: "jti-2"},
+		},
+	}})
+
+	testCases := []struct {
+		name              string
+		authorization     string
+		scopes            []string
+		expectedStatus    int
+		expectedChallenge string
+	}{
+		{
+			name:           "valid token",
+			authorization:  "Bearer valid",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "valid token with required scopes",
+			authorization:  "bearer valid",
+			scopes:         []string{"tasks:read", "profile"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "client credentials token",
+			authorization:  "Bearer client",
+			scopes:         []string{"tasks:read"},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:              "missing token",
+			expectedStatus:    http.StatusUnauthorized,
+			expectedChallenge: "Bearer",
+		},
+		{
+			name:              "wrong scheme",
+			authorization:     "Basic dXNlcjpwYXNz",
+			expectedStatus:    http.StatusUnauthorized,
+			expectedChallenge: "Bearer",
+		},
+		{
+			name:              "invalid token",
+			authorization:     "Bearer invalid",
+			expectedStatus:    http.StatusUnauthorized,
+			expectedChallenge: `Bearer error="invalid_token"`,
+		},
+		{
+			name:              "invalid subject",
+			authorization:     "Bearer bad-subject",
+			expectedStatus:    http.StatusUnauthorized,
+			expectedChallenge: `Bearer error="invalid_token"`,
+		},
+		{
+			name:              "missing scope",
+			authorization:     "Bearer valid",
+			scopes:            []string{"tasks:write"},
+			expectedStatus:    http.StatusForbidden,
+			expectedChallenge: `Bearer error="insufficient_scope", scope="tasks:write"`,
+		},
+	}
+
+	for _, tc := range testCases {
+		request := httptest.NewRequest(http.MethodGet, "/protected", nil)
+		if tc.authorization != "" {
+			request.Header.Set(echo.HeaderAuthorization, tc.authorization)
+		}
+		recorder := httptest.NewRecorder()
+
+		newTestRouter(authenticator, tc.scopes...).ServeHTTP(recorder, request)
+
+		if recorder.Code != tc.expectedStatus {
+			t.Errorf("%s: unexpected status: got %d, want %d", tc.name, recorder.Code, tc.expectedStatus)
+		}
+		if challenge := recorder.Header().Get(echo.HeaderWWWAuthenticate); challenge != tc.expectedChallenge {
+			t.Errorf("%s: unexpected challenge: got %q, want %q", tc.name, challenge, tc.expectedChallenge)
+		}
+	}
+}
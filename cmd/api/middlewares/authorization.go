@@ -0,0 +1,61 @@
+package middlewares
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+// RequirePermissions responde 403 si el principal no tiene todos los permisos; debe ir después de Authenticate.
+func RequirePermissions(permissions ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal := GetPrincipal(c)
+			if principal == nil {
+				return unauthorized(c, "")
+			}
+
+			for _, permission := range permissions {
+				if !principal.HasPermission(permission) {
+					return utils.NewForbiddenError("insufficient permissions")
+				}
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireFirstParty responde 403 a los tokens de clientes de terceros y de client_credentials; protege los
+// endpoints que gestionan la cuenta del usuario. Debe ir después de Authenticate.
+func RequireFirstParty() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal := GetPrincipal(c)
+			if principal == nil {
+				return unauthorized(c, "")
+			}
+			if principal.UserID == 0 || !principal.FirstParty {
+				return utils.NewForbiddenError("this endpoint is only available to first-party applications")
+			}
+			return next(c)
+		}
+	}
+}
+
+// RequireAnyRole responde 403 si el principal no tiene ninguno de los roles; debe ir después de Authenticate.
+func RequireAnyRole(roles ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			principal := GetPrincipal(c)
+			if principal == nil {
+				return unauthorized(c, "")
+			}
+
+			for _, role := range roles {
+				if principal.HasRole(role) {
+					return next(c)
+				}
+			}
+			return utils.NewForbiddenError("insufficient role")
+		}
+	}
+}
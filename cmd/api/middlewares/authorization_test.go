@@ -0,0 +1,99 @@
+package middlewares
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+func TestAuthorization(t *testing.T) {
+	principal := &Principal{
+		UserID:      7,
+		FirstParty:  true,
+		Roles:       []string{"tasker"},
+		Permissions: []string{"tasks:read", "tasks:apply"},
+	}
+
+	testCases := []struct {
+		name           string
+		principal      *Principal
+		middleware     echo.MiddlewareFunc
+		expectedStatus int
+	}{
+		{
+			name:           "all permissions granted",
+			principal:      principal,
+			middleware:     RequirePermissions("tasks:read", "tasks:apply"),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "missing permission",
+			principal:      principal,
+			middleware:     RequirePermissions("tasks:read", "roles:manage"),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "any role matches",
+			principal:      principal,
+			middleware:     RequireAnyRole("admin", "tasker"),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "no role matches",
+			principal:      principal,
+			middleware:     RequireAnyRole("admin"),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "first-party token",
+			principal:      principal,
+			middleware:     RequireFirstParty(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "third-party client token",
+			principal:      &Principal{UserID: 7, ClientID: "partner-app"},
+			middleware:     RequireFirstParty(),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "client credentials token",
+			principal:      &Principal{ClientID: "partner-app"},
+			middleware:     RequireFirstParty(),
+			expectedStatus: http.StatusForbidden,
+		},
+		{
+			name:           "not authenticated",
+			middleware:     RequirePermissions("tasks:read"),
+			expectedStatus: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			router := echo.New()
+			router.HTTPErrorHandler = utils.HTTPErrorHandler
+			router.GET("/protected", func(c echo.Context) error {
+				return c.NoContent(http.StatusOK)
+			}, func(next echo.HandlerFunc) echo.HandlerFunc {
+				return func(c echo.Context) error {
+					if tc.principal != nil {
+						c.Set(PrincipalContextKey, tc.principal)
+					}
+					return next(c)
+				}
+			}, tc.middleware)
+
+			request := httptest.NewRequest(http.MethodGet, "/protected", nil)
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			if recorder.Code != tc.expectedStatus {
+				t.Errorf("unexpected status: got %d, want %d", recorder.Code, tc.expectedStatus)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+package middlewares
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+// ClientInfo deja el user agent, la IP y el Accept-Language de la petición en el context.Context para que los
+// servicios los registren en las sesiones y elijan el idioma de los emails. La IP sale de echo.Context.RealIP, que
+// usa el IPExtractor del router: X-Forwarded-For solo cuenta si la conexión viene de un proxy de confianza.
+func ClientInfo() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			info := utils.ClientInfo{
+				UserAgent:      c.Request().UserAgent(),
+				IPAddress:      c.RealIP(),
+				AcceptLanguage: c.Request().Header.Get("Accept-Language"),
+			}
+			c.SetRequest(c.Request().WithContext(utils.WithClientInfo(c.Request().Context(), info)))
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package middlewares
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+)
+
+type principalKey struct{}
+
+// PrincipalContextKey es la clave con la que el principal se guarda en echo.Context.
+const PrincipalContextKey = "principal"
+
+// Principal identifica al usuario autenticado por el bearer token de la petición. ClientID es el cliente OAuth
+// que obtuvo el token; en los tokens de client_credentials no hay usuario y UserID queda en 0. FirstParty indica
+// que el token es del login propio o de una app propia.
+type Principal struct {
+	UserID      uint
+	ClientID    string
+	FirstParty  bool
+	Email       string
+	Roles       []string
+	Permissions []string
+	Scopes      []string
+	TokenID     string
+}
+
+func (p *Principal) HasScope(scope string) bool {
+	return contains(p.Scopes, scope)
+}
+
+func (p *Principal) HasRole(role string) bool {
+	return contains(p.Roles, role)
+}
+
+func (p *Principal) HasPermission(permission string) bool {
+	return contains(p.Permissions, permission)
+}
+
+func WithPrincipal(ctx context.Context, principal *Principal) context.Context {
+	return context.WithValue(ctx, principalKey{}, principal)
+}
+
+// PrincipalFromContext devuelve nil si la petición no pasó por Authenticate.
+func PrincipalFromContext(ctx context.Context) *Principal {
+	principal, _ := ctx.Value(principalKey{}).(*Principal)
+	return principal
+}
+
+// GetPrincipal devuelve nil si la petición no pasó por Authenticate.
+func GetPrincipal(c echo.Context) *Principal {
+	principal, _ := c.Get(PrincipalContextKey).(*Principal)
+	return principal
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,126 @@
+package middlewares
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+// RateLimiter.Retention es cuánto tarda en recargarse por completo el bucket más lento: uno sin uso desde
+// entonces está lleno, y borrarlo es igual que conservarlo.
+type RateLimiter struct {
+	Store     repositories.IRateLimitRepository
+	Policies  map[string]config.RateLimitPolicy
+	Retention time.Duration
+	now       func() time.Time
+}
+
+func NewRateLimiter(store repositories.IRateLimitRepository, policies map[string]config.RateLimitPolicy) *RateLimiter {
+	var retention time.Duration
+	for _, policy := range policies {
+		refill := time.Duration(float64(policy.Period) * float64(policyBurst(policy)) / float64(policy.Requests))
+		retention = max(retention, refill)
+	}
+
+	return &RateLimiter{
+		Store:     store,
+		Policies:  policies,
+		Retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Limit aplica la política indicada y responde 429 con Retry-After cuando el bucket está vacío. Las políticas por
+// usuario deben ir después de Authenticate. Si el store falla la petición pasa: el rate limiting no debe tumbar
+// el login. Una política inexistente es un error de programación y entra en pánico al armar las rutas.
+func (l *RateLimiter) Limit(policyName string) echo.MiddlewareFunc {
+	policy, ok := l.Policies[policyName]
+	if !ok {
+		panic(fmt.Sprintf("rate limit policy %q is not configured", policyName))
+	}
+	burst := policyBurst(policy)
+	rate := float64(policy.Requests) / policy.Period.Seconds()
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := policyName + ":" + rateLimitKey(c, policy.KeyBy)
+			wait, err := l.Store.Take(c.Request().Context(), key, rate, burst, l.now())
+			if err != nil {
+				log.Error(c.Request().Context(), "error taking rate limit token: ", err)
+				return next(c)
+			}
+
+			if wait > 0 {
+				return utils.NewTooManyRequestsError("rate limit exceeded", wait)
+			}
+			return next(c)
+		}
+	}
+}
+
+// PurgeStale borra los buckets que no se usan desde hace más de Retention.
+func (l *RateLimiter) PurgeStale(ctx context.Context) error {
+	return l.Store.DeleteStale(ctx, l.now().Add(-l.Retention))
+}
+
+// Run ejecuta PurgeStale cada interval hasta que se cancele ctx.
+func (l *RateLimiter) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := l.PurgeStale(ctx); err != nil {
+				log.Error(ctx, "error purging rate limit buckets: ", err)
+			}
+		}
+	}
+}
+
+// policyBurst es el tamaño del bucket: Burst, o Requests si no se configuró.
+func policyBurst(policy config.RateLimitPolicy) int {
+	if policy.Burst == 0 {
+		return policy.Requests
+	}
+	return policy.Burst
+}
+
+// rateLimitKey usa el usuario o el cliente OAuth según la política y cae a la IP cuando la petición no los trae.
+// El client_id de un cliente sin autenticar todavía puede ser falso; basta para repartir el cupo entre clientes.
+func rateLimitKey(c echo.Context, keyBy string) string {
+	switch keyBy {
+	case constants.RateLimitKeyByUser:
+		if principal := GetPrincipal(c); principal != nil && principal.UserID != 0 {
+			return "user:" + strconv.FormatUint(uint64(principal.UserID), 10)
+		}
+	case constants.RateLimitKeyByClient:
+		if clientID := rateLimitClientID(c); clientID != "" {
+			return "client:" + clientID
+		}
+	}
+	return "ip:" + c.RealIP()
+}
+
+func rateLimitClientID(c echo.Context) string {
+	if principal := GetPrincipal(c); principal != nil && principal.ClientID != "" {
+		return principal.ClientID
+	}
+	if clientID, _, ok := c.Request().BasicAuth(); ok {
+		if unescaped, err := url.QueryUnescape(clientID); err == nil {
+			return unescaped
+		}
+	}
+	return c.FormValue("client_id")
+}
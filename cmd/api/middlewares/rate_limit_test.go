@@ -0,0 +1,194 @@
+package middlewares
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type failingRateLimitStore struct{}
+
+func (failingRateLimitStore) Take(context.Context, string, float64, int, time.Time) (time.Duration, error) {
+	return 0, errors.New("database is down")
+}
+
+func (failingRateLimitStore) DeleteStale(context.Context, time.Time) error {
+	return errors.New("database is down")
+}
+
+// purgeRecordingStore guarda el corte con el que se purgaron los buckets.
+type purgeRecordingStore struct {
+	repositories.IRateLimitRepository
+	before time.Time
+}
+
+func (s *purgeRecordingStore) DeleteStale(_ context.Context, before time.Time) error {
+	s.before = before
+	return nil
+}
+
+func newTestRateLimiter(store repositories.IRateLimitRepository, now *time.Time) *RateLimiter {
+	limiter := NewRateLimiter(store, map[string]config.RateLimitPolicy{
+		"by_ip":     {Requests: 2, Period: time.Minute, KeyBy: constants.RateLimitKeyByIP},
+		"by_user":   {Requests: 2, Period: time.Minute, KeyBy: constants.RateLimitKeyByUser},
+		"by_client": {Requests: 60, Period: time.Minute, Burst: 2, KeyBy: constants.RateLimitKeyByClient},
+	})
+	limiter.now = func() time.Time { return *now }
+	return limiter
+}
+
+func newRateLimitedRouter(limiter *RateLimiter, policy string, principal *Principal) *echo.Echo {
+	router := echo.New()
+	router.HTTPErrorHandler = utils.HTTPErrorHandler
+	setPrincipal := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if principal != nil {
+				c.Set(PrincipalContextKey, principal)
+			}
+			return next(c)
+		}
+	}
+	router.POST("/limited", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}, setPrincipal, limiter.Limit(policy))
+	return router
+}
+
+func serveLimited(router *echo.Echo, ip string, configure func(*http.Request)) *httptest.ResponseRecorder {
+	request := httptest.NewRequest(http.MethodPost, "/limited", nil)
+	request.Header.Set(echo.HeaderXRealIP, ip)
+	if configure != nil {
+		configure(request)
+	}
+	recorder := httptest.NewRecorder()
+	router.ServeHTTP(recorder, request)
+	return recorder
+}
+
+func TestRateLimiter(t *testing.T) {
+
+	t.Run("rejects once the bucket is empty and refills over time", func(t *testing.T) {
+		now := time.Now()
+		router := newRateLimitedRouter(newTestRateLimiter(repositories.NewMemoryRateLimitRepository(), &now), "by_ip", nil)
+
+		for range 2 {
+			if recorder := serveLimited(router, "190.0.2.10", nil); recorder.Code != http.StatusNoContent {
+				t.Fatalf("unexpected status: %d", recorder.Code)
+			}
+		}
+		recorder := serveLimited(router, "190.0.2.10", nil)
+		if recorder.Code != http.StatusTooManyRequests || recorder.Header().Get("Retry-After") != "30" {
+			t.Fatalf("expected 429 with Retry-After 30, got %d %q", recorder.Code, recorder.Header().Get("Retry-After"))
+		}
+		if recorder := serveLimited(router, "190.0.2.11", nil); recorder.Code != http.StatusNoContent {
+			t.Errorf("expected other ips to keep their own bucket, got %d", recorder.Code)
+		}
+
+		now = now.Add(30 * time.Second)
+		if recorder := serveLimited(router, "190.0.2.10", nil); recorder.Code != http.StatusNoContent {
+			t.Errorf("expected a token after the refill, got %d", recorder.Code)
+		}
+		if recorder := serveLimited(router, "190.0.2.10", nil); recorder.Code != http.StatusTooManyRequests {
+			t.Errorf("expected only one token to be refilled, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("keys by user across ips", func(t *testing.T) {
+		now := time.Now()
+		router := newRateLimitedRouter(newTestRateLimiter(repositories.NewMemoryRateLimitRepository(), &now), "by_user", &Principal{UserID: 7})
+
+		serveLimited(router, "190.0.2.10", nil)
+		serveLimited(router, "190.0.2.11", nil)
+		if recorder := serveLimited(router, "190.0.2.12", nil); recorder.Code != http.StatusTooManyRequests {
+			t.Errorf("expected the user bucket to be shared, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("keys by client id", func(t *testing.T) {
+		now := time.Now()
+		router := newRateLimitedRouter(newTestRateLimiter(repositories.NewMemoryRateLimitRepository(), &now), "by_client", nil)
+		basic := func(r *http.Request) { r.SetBasicAuth("partner", "secret") }
+		form := func(r *http.Request) {
+			r.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+			r.Body = io.NopCloser(strings.NewReader("client_id=partner"))
+		}
+
+		serveLimited(router, "190.0.2.10", basic)
+		serveLimited(router, "190.0.2.11", form)
+		recorder := serveLimited(router, "190.0.2.12", basic)
+		if recorder.Code != http.StatusTooManyRequests || recorder.Header().Get("Retry-After") != "1" {
+			t.Errorf("expected the client bucket to be shared, got %d %q", recorder.Code, recorder.Header().Get("Retry-After"))
+		}
+		if recorder := serveLimited(router, "190.0.2.12", func(r *http.Request) { r.SetBasicAuth("other", "secret") }); recorder.Code != http.StatusNoContent {
+			t.Errorf("expected other clients to keep their own bucket, got %d", recorder.Code)
+		}
+	})
+
+	t.Run("lets requests through when the store fails", func(t *testing.T) {
+		now := time.Now()
+		router := newRateLimitedRouter(newTestRateLimiter(failingRateLimitStore{}, &now), "by_ip", nil)
+
+		if recorder := serveLimited(router, "190.0.2.10", nil); recorder.Code != http.StatusNoContent {
+			t.Errorf("unexpected status: %d", recorder.Code)
+		}
+	})
+
+	t.Run("purges buckets idle for longer than the slowest refill", func(t *testing.T) {
+		testCases := []struct {
+			name     string
+			policies map[string]config.RateLimitPolicy
+			expected time.Duration
+		}{
+			{
+				name: "burst defaults to requests",
+				policies: map[string]config.RateLimitPolicy{
+					"login":  {Requests: 20, Period: time.Minute},
+					"signup": {Requests: 10, Period: time.Hour},
+				},
+				expected: time.Hour,
+			},
+			{
+				name: "larger burst takes longer to refill",
+				policies: map[string]config.RateLimitPolicy{
+					"token": {Requests: 120, Period: time.Minute, Burst: 240},
+				},
+				expected: 2 * time.Minute,
+			},
+		}
+
+		for _, tc := range testCases {
+			now := time.Now()
+			store := &purgeRecordingStore{}
+			limiter := NewRateLimiter(store, tc.policies)
+			limiter.now = func() time.Time { return now }
+
+			if err := limiter.PurgeStale(context.Background()); err != nil {
+				t.Fatalf("%s: unexpected error: %v", tc.name, err)
+			}
+			if !store.before.Equal(now.Add(-tc.expected)) {
+				t.Errorf("%s: unexpected cutoff: got %v, want %v", tc.name, now.Sub(store.before), tc.expected)
+			}
+		}
+	})
+
+	t.Run("unknown policies fail at startup", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Errorf("expected a panic")
+			}
+		}()
+		now := time.Now()
+		newTestRateLimiter(repositories.NewMemoryRateLimitRepository(), &now).Limit("missing")
+	})
+}
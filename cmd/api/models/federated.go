@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// ExternalIdentity vincula una cuenta de un proveedor social con un usuario local. Subject es el identificador
+// estable del usuario en el proveedor; Email queda solo como referencia de con qué cuenta se vinculó.
+type ExternalIdentity struct {
+	ID          uint   `gorm:"primaryKey"`
+	UserID      uint   `gorm:"not null;index"`
+	Provider    string `gorm:"size:32;not null;uniqueIndex:idx_external_identities_provider_subject"`
+	Subject     string `gorm:"size:255;not null;uniqueIndex:idx_external_identities_provider_subject"`
+	Email       string `gorm:"size:255"`
+	LastLoginAt *time.Time
+	CreatedAt   time.Time `gorm:"not null"`
+}
+
+// FederatedLoginState guarda el state y el code verifier PKCE de un login social entre la redirección al
+// proveedor y el callback. UserID solo se informa cuando un usuario autenticado vincula un proveedor a su cuenta.
+type FederatedLoginState struct {
+	ID           uint   `gorm:"primaryKey"`
+	StateHash    string `gorm:"size:64;not null;uniqueIndex"`
+	Provider     string `gorm:"size:32;not null"`
+	CodeVerifier string `gorm:"size:128;not null"`
+	UserID       *uint
+	ExpiresAt    time.Time `gorm:"not null;index"`
+	CreatedAt    time.Time `gorm:"not null"`
+}
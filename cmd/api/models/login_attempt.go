@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// LoginAttempt cuenta los logins fallidos de una cuenta o de una IP. Key es "account:<email>" o "ip:<dirección>".
+type LoginAttempt struct {
+	Key           string    `gorm:"primaryKey;size:320"`
+	Failures      int       `gorm:"not null;default:0"`
+	LastFailureAt time.Time `gorm:"not null"`
+	LockedUntil   *time.Time
+	UpdatedAt     time.Time `gorm:"not null"`
+}
+
+func (a *LoginAttempt) IsLocked(now time.Time) bool {
+	return a.LockedUntil != nil && now.Before(*a.LockedUntil)
+}
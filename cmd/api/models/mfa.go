@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// CodePurposeMFAChallenge identifica el token que el login entrega cuando el usuario tiene 2FA activo.
+const CodePurposeMFAChallenge = "mfa_challenge"
+
+// TOTPCredential es el secreto TOTP de un usuario; no protege el login hasta que ConfirmedAt tiene valor.
+// LastUsedStep guarda el último intervalo aceptado para que un mismo código no sirva dos veces.
+type TOTPCredential struct {
+	ID           uint   `gorm:"primaryKey"`
+	UserID       uint   `gorm:"not null;uniqueIndex"`
+	Secret       string `gorm:"size:64;not null"`
+	ConfirmedAt  *time.Time
+	LastUsedStep int64     `gorm:"not null;default:0"`
+	CreatedAt    time.Time `gorm:"not null"`
+	UpdatedAt    time.Time `gorm:"not null"`
+}
+
+func (c *TOTPCredential) IsConfirmed() bool {
+	return c.ConfirmedAt != nil
+}
+
+// RecoveryCode es un código de recuperación de un solo uso, guardado como hash.
+type RecoveryCode struct {
+	ID        uint   `gorm:"primaryKey"`
+	UserID    uint   `gorm:"not null;index"`
+	CodeHash  string `gorm:"size:64;not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"not null"`
+}
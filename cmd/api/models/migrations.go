@@ -0,0 +1,86 @@
+package models
+
+import (
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"gorm.io/gorm"
+)
+
+// defaultRoles son los roles que el panel de administración espera encontrar, con sus permisos iniciales.
+var defaultRoles = map[string][]string{
+	constants.RoleAdmin: {
+		constants.PermissionRolesManage,
+		constants.PermissionUsersRead,
+		constants.PermissionUsersManage,
+		constants.PermissionClientsManage,
+		constants.PermissionTasksRead,
+		constants.PermissionTasksWrite,
+	},
+	constants.RoleTasker: {
+		constants.PermissionTasksRead,
+		constants.PermissionTasksApply,
+	},
+	constants.RoleClient: {
+		constants.PermissionTasksRead,
+		constants.PermissionTasksWrite,
+	},
+}
+
+// AutoMigrate crea o actualiza las tablas de todos los modelos del servicio.
+func AutoMigrate(db *gorm.DB) error {
+	err := db.AutoMigrate(
+		&User{},
+		&VerificationCode{},
+		&RefreshToken{},
+		&SigningKey{},
+		&RevokedToken{},
+		&Permission{},
+		&Role{},
+		&UserRole{},
+		&TOTPCredential{},
+		&RecoveryCode{},
+		&WebAuthnCredential{},
+		&WebAuthnSession{},
+		&OAuthClient{},
+		&OAuthAuthorizationCode{},
+		&OAuthConsent{},
+		&ExternalIdentity{},
+		&FederatedLoginState{},
+		&Session{},
+		&LoginAttempt{},
+		&RateLimitBucket{},
+		&PasswordHistory{},
+		&OutboxEmail{},
+	)
+	if err != nil {
+		return err
+	}
+
+	return seedRoles(db)
+}
+
+// seedRoles crea los roles y permisos por defecto que falten, sin tocar los que ya existen.
+func seedRoles(db *gorm.DB) error {
+	for roleName, permissionNames := range defaultRoles {
+		var role Role
+		result := db.Where(Role{Name: roleName}).FirstOrCreate(&role)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			continue
+		}
+
+		permissions := make([]Permission, 0, len(permissionNames))
+		for _, permissionName := range permissionNames {
+			var permission Permission
+			if err := db.Where(Permission{Name: permissionName}).FirstOrCreate(&permission).Error; err != nil {
+				return err
+			}
+			permissions = append(permissions, permission)
+		}
+		if err := db.Model(&role).Association("Permissions").Replace(permissions); err != nil {
+			return err
+		}
+	}
+	return nil
+}
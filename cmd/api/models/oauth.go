@@ -0,0 +1,79 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// OAuthClient es una aplicación registrada en el servidor de autorización. Los clientes públicos (apps móviles y
+// SPAs) no tienen secreto; FirstParty marca las apps propias de Tareaya, que no piden consentimiento. RedirectURIs,
+// GrantTypes y Scopes se guardan separados por espacios.
+type OAuthClient struct {
+	ID           uint      `gorm:"primaryKey"`
+	ClientID     string    `gorm:"size:64;not null;uniqueIndex"`
+	SecretHash   string    `gorm:"size:64"`
+	Name         string    `gorm:"size:120;not null"`
+	RedirectURIs string    `gorm:"type:text;not null"`
+	GrantTypes   string    `gorm:"size:255;not null"`
+	Scopes       string    `gorm:"type:text;not null"`
+	Public       bool      `gorm:"not null;default:false"`
+	FirstParty   bool      `gorm:"not null;default:false"`
+	CreatedAt    time.Time `gorm:"not null"`
+	UpdatedAt    time.Time `gorm:"not null"`
+}
+
+func (c *OAuthClient) RedirectURIList() []string {
+	return strings.Fields(c.RedirectURIs)
+}
+
+func (c *OAuthClient) GrantTypeList() []string {
+	return strings.Fields(c.GrantTypes)
+}
+
+func (c *OAuthClient) ScopeList() []string {
+	return strings.Fields(c.Scopes)
+}
+
+func (c *OAuthClient) AllowsGrantType(grantType string) bool {
+	for _, allowed := range c.GrantTypeList() {
+		if allowed == grantType {
+			return true
+		}
+	}
+	return false
+}
+
+// OAuthAuthorizationCode guarda el hash del código junto con el reto PKCE y el nonce de OpenID Connect. FamilyID es la familia de refresh
+// tokens emitida al canjearlo, para revocarla si el código se presenta de nuevo.
+type OAuthAuthorizationCode struct {
+	ID            uint      `gorm:"primaryKey"`
+	CodeHash      string    `gorm:"size:64;not null;uniqueIndex"`
+	ClientID      string    `gorm:"size:64;not null;index"`
+	UserID        uint      `gorm:"not null;index"`
+	RedirectURI   string    `gorm:"type:text;not null"`
+	Scope         string    `gorm:"type:text;not null"`
+	CodeChallenge string    `gorm:"size:128;not null"`
+	Nonce         string    `gorm:"size:255"`
+	FamilyID      string    `gorm:"size:64"`
+	ExpiresAt     time.Time `gorm:"not null"`
+	UsedAt        *time.Time
+	CreatedAt     time.Time `gorm:"not null"`
+}
+
+func (c *OAuthAuthorizationCode) IsActive(now time.Time) bool {
+	return c.UsedAt == nil && now.Before(c.ExpiresAt)
+}
+
+// OAuthConsent registra los scopes que el usuario ya autorizó a un cliente.
+type OAuthConsent struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;uniqueIndex:idx_oauth_consents_user_client"`
+	ClientID  string    `gorm:"size:64;not null;uniqueIndex:idx_oauth_consents_user_client"`
+	Scope     string    `gorm:"type:text;not null"`
+	CreatedAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+}
+
+func (c *OAuthConsent) ScopeList() []string {
+	return strings.Fields(c.Scope)
+}
@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Estados de un email en el outbox.
+const (
+	OutboxStatusPending = "pending"
+	OutboxStatusSent    = "sent"
+	OutboxStatusFailed  = "failed"
+)
+
+// OutboxEmail es un email encolado para enviar. El asunto se renderiza al encolar, en el idioma de Locale. Data
+// guarda en JSON los datos de la plantilla, que pueden incluir tokens de un solo uso: se borra al enviar. NextAttemptAt es cuándo puede tomarlo el próximo envío o reintento.
+type OutboxEmail struct {
+	ID            uint      `gorm:"primaryKey"`
+	Recipient     string    `gorm:"size:255;not null"`
+	Subject       string    `gorm:"size:255;not null"`
+	Template      string    `gorm:"size:64;not null"`
+	Locale        string    `gorm:"size:8;not null;default:es"`
+	Data          string    `gorm:"type:text;not null"`
+	Status        string    `gorm:"size:16;not null;index:idx_outbox_emails_due,priority:1"`
+	Attempts      int       `gorm:"not null;default:0"`
+	NextAttemptAt time.Time `gorm:"not null;index:idx_outbox_emails_due,priority:2"`
+	LastError     string    `gorm:"size:1000"`
+	SentAt        *time.Time
+	CreatedAt     time.Time `gorm:"not null"`
+	UpdatedAt     time.Time `gorm:"not null"`
+}
@@ -0,0 +1,11 @@
+package models
+
+import "time"
+
+// PasswordHistory guarda los hashes de las contraseñas anteriores de un usuario para impedir que las repita.
+type PasswordHistory struct {
+	ID           uint      `gorm:"primaryKey"`
+	UserID       uint      `gorm:"not null;index"`
+	PasswordHash string    `gorm:"size:255;not null"`
+	CreatedAt    time.Time `gorm:"not null"`
+}
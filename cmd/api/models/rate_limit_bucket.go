@@ -0,0 +1,35 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// RateLimitBucket es un token bucket: Tokens se recarga a razón constante hasta el burst de la política y cada
+// petición consume uno.
+type RateLimitBucket struct {
+	Key       string    `gorm:"primaryKey;size:320"`
+	Tokens    float64   `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null;index"`
+}
+
+// Take recarga el bucket hasta now y consume un token. Si no alcanza devuelve cuánto falta para tenerlo, sin
+// consumir nada. rate es en tokens por segundo.
+func (b *RateLimitBucket) Take(rate float64, burst int, now time.Time) time.Duration {
+	if elapsed := now.Sub(b.UpdatedAt).Seconds(); elapsed > 0 {
+		b.Tokens = math.Min(float64(burst), b.Tokens+elapsed*rate)
+		b.UpdatedAt = now
+	}
+
+	if b.Tokens >= 1 {
+		b.Tokens--
+		return 0
+	}
+	return time.Duration(math.Ceil((1 - b.Tokens) / rate * float64(time.Second)))
+}
+
+// FullAt es cuando el bucket vuelve a estar lleno; desde entonces guardarlo es igual que no tenerlo.
+func (b *RateLimitBucket) FullAt(rate float64, burst int) time.Time {
+	missing := float64(burst) - b.Tokens
+	return b.UpdatedAt.Add(time.Duration(missing / rate * float64(time.Second)))
+}
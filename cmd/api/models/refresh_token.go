@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// RefreshToken es un token opaco guardado como hash. Todos los tokens obtenidos por rotación a partir del mismo
+// login comparten FamilyID, de modo que reutilizar uno ya rotado permite revocar la familia entera. Los emitidos
+// por el servidor OAuth guardan el cliente y los scopes concedidos; los del login propio los dejan vacíos.
+type RefreshToken struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index"`
+	FamilyID  string    `gorm:"size:64;not null;index"`
+	ClientID  string    `gorm:"size:64;not null;default:''"`
+	Scope     string    `gorm:"type:text;not null;default:''"`
+	TokenHash string    `gorm:"size:64;not null;uniqueIndex"`
+	ExpiresAt time.Time `gorm:"not null"`
+	RotatedAt *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (t *RefreshToken) IsActive(now time.Time) bool {
+	return t.RotatedAt == nil && t.RevokedAt == nil && now.Before(t.ExpiresAt)
+}
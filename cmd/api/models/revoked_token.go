@@ -0,0 +1,12 @@
+package models
+
+import "time"
+
+// RevokedToken registra el jti de un access token revocado antes de expirar. La fila puede borrarse cuando el
+// token expira.
+type RevokedToken struct {
+	JTI       string    `gorm:"column:jti;primaryKey;size:64"`
+	UserID    uint      `gorm:"not null;index"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	RevokedAt time.Time `gorm:"not null"`
+}
@@ -0,0 +1,34 @@
+package models
+
+import "time"
+
+type Permission struct {
+	ID          uint      `gorm:"primaryKey"`
+	Name        string    `gorm:"size:80;not null;uniqueIndex"`
+	Description string    `gorm:"size:255"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
+
+type Role struct {
+	ID          uint         `gorm:"primaryKey"`
+	Name        string       `gorm:"size:50;not null;uniqueIndex"`
+	Description string       `gorm:"size:255"`
+	Permissions []Permission `gorm:"many2many:role_permissions;constraint:OnDelete:CASCADE"`
+	CreatedAt   time.Time    `gorm:"not null"`
+	UpdatedAt   time.Time    `gorm:"not null"`
+}
+
+func (r *Role) PermissionNames() []string {
+	names := make([]string, 0, len(r.Permissions))
+	for _, permission := range r.Permissions {
+		names = append(names, permission.Name)
+	}
+	return names
+}
+
+type UserRole struct {
+	UserID    uint      `gorm:"primaryKey"`
+	RoleID    uint      `gorm:"primaryKey;index"`
+	Role      Role      `gorm:"constraint:OnDelete:CASCADE"`
+	CreatedAt time.Time `gorm:"not null"`
+}
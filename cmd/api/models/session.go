@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// Session es un login activo en un dispositivo. Cada sesión corresponde a una familia de refresh tokens: rotar
+// el token actualiza LastSeenAt y ExpiresAt, y revocar la sesión revoca la familia entera.
+type Session struct {
+	ID         uint      `gorm:"primaryKey"`
+	UserID     uint      `gorm:"not null;index"`
+	FamilyID   string    `gorm:"size:64;not null;uniqueIndex"`
+	ClientID   string    `gorm:"size:64;not null;default:''"`
+	DeviceName string    `gorm:"size:120;not null;default:''"`
+	UserAgent  string    `gorm:"size:512;not null;default:''"`
+	IPAddress  string    `gorm:"size:64;not null;default:''"`
+	LastSeenAt time.Time `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	RevokedAt  *time.Time
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+func (s *Session) IsActive(now time.Time) bool {
+	return s.RevokedAt == nil && now.Before(s.ExpiresAt)
+}
@@ -0,0 +1,15 @@
+package models
+
+import "time"
+
+// SigningKey es una clave de firma de JWT. Se publica en el JWKS desde su creación, firma a partir de ActivatedAt
+// y se elimina al llegar a RetiresAt, cuando ya expiraron todos los tokens firmados con ella.
+type SigningKey struct {
+	ID          uint      `gorm:"primaryKey"`
+	KID         string    `gorm:"column:kid;size:64;not null;uniqueIndex"`
+	Algorithm   string    `gorm:"size:10;not null"`
+	PrivateKey  string    `gorm:"type:text;not null"`
+	ActivatedAt time.Time `gorm:"not null;index"`
+	RetiresAt   *time.Time
+	CreatedAt   time.Time `gorm:"not null"`
+}
@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+type User struct {
+	ID              uint   `gorm:"primaryKey"`
+	Name            string `gorm:"size:120;not null"`
+	Email           string `gorm:"size:255;not null;uniqueIndex"`
+	PasswordHash    string `gorm:"size:255;not null"`
+	EmailVerified   bool   `gorm:"not null;default:false"`
+	EmailVerifiedAt *time.Time
+	// PhoneNumber es el último número verificado, en E.164; PendingPhoneNumber espera el código de verificación y
+	// no reemplaza al verificado hasta confirmarlo.
+	PhoneNumber        string `gorm:"size:16;not null;default:'';uniqueIndex:idx_users_phone_number,where:phone_number <> ''"`
+	PhoneVerifiedAt    *time.Time
+	PendingPhoneNumber string `gorm:"size:16;not null;default:''"`
+	// Locale es el idioma preferido para los emails; vacío usa el Accept-Language de cada petición.
+	Locale string `gorm:"size:8;not null;default:''"`
+	// PasswordChangedAt invalida cualquier credencial emitida antes del último cambio de contraseña.
+	PasswordChangedAt *time.Time
+	CreatedAt         time.Time `gorm:"not null"`
+	UpdatedAt         time.Time `gorm:"not null"`
+}
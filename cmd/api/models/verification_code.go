@@ -0,0 +1,28 @@
+package models
+
+import "time"
+
+// Propósitos de los códigos de verificación.
+const (
+	CodePurposeVerifyEmail   = "verify_email"
+	CodePurposePasswordReset = "password_reset"
+	CodePurposeMagicLink     = "magic_link"
+	CodePurposeLoginCode     = "login_code"
+	CodePurposeVerifyPhone   = "verify_phone"
+)
+
+// VerificationCode guarda solo el hash del código o token enviado al usuario; cada uno es de un solo uso.
+type VerificationCode struct {
+	ID        uint      `gorm:"primaryKey"`
+	UserID    uint      `gorm:"not null;index:idx_verification_codes_user_purpose"`
+	Purpose   string    `gorm:"size:40;not null;index:idx_verification_codes_user_purpose"`
+	CodeHash  string    `gorm:"size:64;not null;index"`
+	Attempts  int       `gorm:"not null;default:0"`
+	ExpiresAt time.Time `gorm:"not null"`
+	UsedAt    *time.Time
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (c *VerificationCode) IsActive(now time.Time) bool {
+	return c.UsedAt == nil && now.Before(c.ExpiresAt)
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// Ceremonias WebAuthn.
+const (
+	WebAuthnCeremonyRegistration = "registration"
+	WebAuthnCeremonyLogin        = "login"
+)
+
+// WebAuthnCredential es una passkey registrada. Flags guarda el byte de flags del autenticador tal como llegó en el
+// registro, porque la librería lo necesita para validar los logins siguientes.
+type WebAuthnCredential struct {
+	ID              uint   `gorm:"primaryKey"`
+	UserID          uint   `gorm:"not null;index"`
+	CredentialID    []byte `gorm:"not null;uniqueIndex"`
+	PublicKey       []byte `gorm:"not null"`
+	AttestationType string `gorm:"size:32"`
+	AAGUID          []byte
+	SignCount       uint32 `gorm:"not null;default:0"`
+	CloneWarning    bool   `gorm:"not null;default:false"`
+	Transports      string `gorm:"size:255"`
+	Flags           uint8  `gorm:"not null;default:0"`
+	Name            string `gorm:"size:100"`
+	LastUsedAt      *time.Time
+	CreatedAt       time.Time `gorm:"not null"`
+}
+
+// WebAuthnSession guarda el estado de una ceremonia entre el begin y el finish. El cliente solo conoce el token
+// cuyo hash es TokenHash; UserID es nil en los logins con passkeys detectables.
+type WebAuthnSession struct {
+	ID        uint   `gorm:"primaryKey"`
+	TokenHash string `gorm:"size:64;not null;uniqueIndex"`
+	Ceremony  string `gorm:"size:20;not null"`
+	UserID    *uint
+	Data      string    `gorm:"type:text;not null"`
+	ExpiresAt time.Time `gorm:"not null;index"`
+	CreatedAt time.Time `gorm:"not null"`
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// IBreachedPasswordRepository consulta contraseñas filtradas por k-anonimato: recibe los primeros 5 caracteres
+// del SHA-1 en hexadecimal y devuelve los 35 restantes de cada hash conocido con ese prefijo, como la API de
+// rangos de Have I Been Pwned. Así la contraseña nunca sale completa del servicio.
+type IBreachedPasswordRepository interface {
+	FindSuffixes(ctx context.Context, prefix string) ([]string, error)
+}
+
+// BreachedPasswordFileRepository carga la lista desde un archivo local con un hash SHA-1 por línea, opcionalmente
+// seguido de ":<apariciones>" como en los volcados de Have I Been Pwned. Las líneas vacías o que empiezan con #
+// se ignoran.
+type BreachedPasswordFileRepository struct {
+	suffixes map[string][]string
+}
+
+// NewBreachedPasswordFileRepository con path vacío devuelve una lista vacía.
+func NewBreachedPasswordFileRepository(path string) (*BreachedPasswordFileRepository, error) {
+	r := &BreachedPasswordFileRepository{suffixes: map[string][]string{}}
+	if path == "" {
+		return r, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		hash, _, _ := strings.Cut(entry, ":")
+		if len(hash) != 40 {
+			return nil, fmt.Errorf("%s:%d: invalid sha-1 hash", path, line)
+		}
+		hash = strings.ToUpper(hash)
+		r.suffixes[hash[:5]] = append(r.suffixes[hash[:5]], hash[5:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	for prefix := range r.suffixes {
+		sort.Strings(r.suffixes[prefix])
+	}
+	return r, nil
+}
+
+func (r *BreachedPasswordFileRepository) FindSuffixes(_ context.Context, prefix string) ([]string, error) {
+	return r.suffixes[strings.ToUpper(prefix)], nil
+}
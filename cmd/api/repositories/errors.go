@@ -0,0 +1,14 @@
+package repositories
+
+import "errors"
+
+var (
+	ErrDuplicatedEmail       = errors.New("email already registered")
+	ErrCodeAlreadyUsed       = errors.New("code already used")
+	ErrTokenNotActive        = errors.New("token is no longer active")
+	ErrDuplicatedName        = errors.New("name already exists")
+	ErrNotFound              = errors.New("record not found")
+	ErrDuplicatedCredential  = errors.New("credential already registered")
+	ErrDuplicatedIdentity    = errors.New("identity already linked")
+	ErrDuplicatedPhoneNumber = errors.New("phone number already registered")
+)
@@ -0,0 +1,71 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+)
+
+type IExternalIdentityRepository interface {
+	Create(ctx context.Context, identity *models.ExternalIdentity) error
+	FindByProviderSubject(ctx context.Context, provider, subject string) (*models.ExternalIdentity, error)
+	FindByUserID(ctx context.Context, userID uint) ([]models.ExternalIdentity, error)
+	UpdateLastLogin(ctx context.Context, id uint, lastLoginAt time.Time) error
+	Delete(ctx context.Context, userID uint, provider string) error
+}
+
+type ExternalIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewExternalIdentityRepository(db *gorm.DB) *ExternalIdentityRepository {
+	return &ExternalIdentityRepository{db: db}
+}
+
+func (r *ExternalIdentityRepository) Create(ctx context.Context, identity *models.ExternalIdentity) error {
+	err := conn(ctx, r.db).Create(identity).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicatedIdentity
+	}
+	return err
+}
+
+func (r *ExternalIdentityRepository) FindByProviderSubject(ctx context.Context, provider, subject string) (*models.ExternalIdentity, error) {
+	var identity models.ExternalIdentity
+	err := conn(ctx, r.db).Where("provider = ? AND subject = ?", provider, subject).First(&identity).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &identity, nil
+}
+
+func (r *ExternalIdentityRepository) FindByUserID(ctx context.Context, userID uint) ([]models.ExternalIdentity, error) {
+	var identities []models.ExternalIdentity
+	err := conn(ctx, r.db).Where("user_id = ?", userID).Order("id").Find(&identities).Error
+	return identities, err
+}
+
+func (r *ExternalIdentityRepository) UpdateLastLogin(ctx context.Context, id uint, lastLoginAt time.Time) error {
+	return conn(ctx, r.db).
+		Model(&models.ExternalIdentity{}).
+		Where("id = ?", id).
+		Update("last_login_at", lastLoginAt).Error
+}
+
+// Delete devuelve ErrNotFound si el usuario no tiene vinculado ese proveedor.
+func (r *ExternalIdentityRepository) Delete(ctx context.Context, userID uint, provider string) error {
+	result := conn(ctx, r.db).Where("user_id = ? AND provider = ?", userID, provider).Delete(&models.ExternalIdentity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
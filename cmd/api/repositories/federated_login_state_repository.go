@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IFederatedLoginStateRepository interface {
+	Create(ctx context.Context, state *models.FederatedLoginState) error
+	Consume(ctx context.Context, provider, stateHash string, now time.Time) (*models.FederatedLoginState, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type FederatedLoginStateRepository struct {
+	db *gorm.DB
+}
+
+func NewFederatedLoginStateRepository(db *gorm.DB) *FederatedLoginStateRepository {
+	return &FederatedLoginStateRepository{db: db}
+}
+
+func (r *FederatedLoginStateRepository) Create(ctx context.Context, state *models.FederatedLoginState) error {
+	return conn(ctx, r.db).Create(state).Error
+}
+
+// Consume borra el state vigente y lo devuelve, de modo que cada callback se pueda procesar una sola vez.
+// Devuelve nil, nil cuando no hay un state vigente con ese hash para el proveedor.
+func (r *FederatedLoginStateRepository) Consume(ctx context.Context, provider, stateHash string, now time.Time) (*models.FederatedLoginState, error) {
+	var states []models.FederatedLoginState
+	err := conn(ctx, r.db).
+		Clauses(clause.Returning{}).
+		Where("provider = ? AND state_hash = ? AND expires_at > ?", provider, stateHash, now).
+		Delete(&states).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(states) == 0 {
+		return nil, nil
+	}
+	return &states[0], nil
+}
+
+func (r *FederatedLoginStateRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	return conn(ctx, r.db).Where("expires_at <= ?", now).Delete(&models.FederatedLoginState{}).Error
+}
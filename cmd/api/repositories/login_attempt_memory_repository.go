@@ -0,0 +1,78 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+)
+
+// MemoryLoginAttemptRepository implementa ILoginAttemptRepository en memoria, para pruebas y para correr el
+// servicio sin base de datos. Los contadores no se comparten entre instancias.
+type MemoryLoginAttemptRepository struct {
+	mutex    sync.Mutex
+	attempts map[string]models.LoginAttempt
+}
+
+func NewMemoryLoginAttemptRepository() *MemoryLoginAttemptRepository {
+	return &MemoryLoginAttemptRepository{attempts: map[string]models.LoginAttempt{}}
+}
+
+func (r *MemoryLoginAttemptRepository) Find(_ context.Context, key string) (*models.LoginAttempt, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok {
+		return nil, nil
+	}
+	return &attempt, nil
+}
+
+func (r *MemoryLoginAttemptRepository) RegisterFailure(_ context.Context, key string, now, windowStart time.Time) (*models.LoginAttempt, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	attempt, ok := r.attempts[key]
+	if !ok || attempt.LastFailureAt.Before(windowStart) {
+		attempt.Key = key
+		attempt.Failures = 0
+	}
+	attempt.Failures++
+	attempt.LastFailureAt = now
+	attempt.UpdatedAt = now
+	r.attempts[key] = attempt
+	return &attempt, nil
+}
+
+func (r *MemoryLoginAttemptRepository) Lock(_ context.Context, key string, until time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if attempt, ok := r.attempts[key]; ok {
+		attempt.LockedUntil = &until
+		r.attempts[key] = attempt
+	}
+	return nil
+}
+
+func (r *MemoryLoginAttemptRepository) Reset(_ context.Context, key string) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	delete(r.attempts, key)
+	return nil
+}
+
+func (r *MemoryLoginAttemptRepository) DeleteStale(_ context.Context, now, windowStart time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for key, attempt := range r.attempts {
+		if attempt.LastFailureAt.Before(windowStart) && !attempt.IsLocked(now) {
+			delete(r.attempts, key)
+		}
+	}
+	return nil
+}
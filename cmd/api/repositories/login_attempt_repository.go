@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ILoginAttemptRepository guarda los contadores de logins fallidos. RegisterFailure debe ser atómico para que los
+// intentos concurrentes no se pierdan. DeleteStale borra los contadores sin fallos desde windowStart y sin un
+// bloqueo vigente en now.
+type ILoginAttemptRepository interface {
+	Find(ctx context.Context, key string) (*models.LoginAttempt, error)
+	RegisterFailure(ctx context.Context, key string, now, windowStart time.Time) (*models.LoginAttempt, error)
+	Lock(ctx context.Context, key string, until time.Time) error
+	Reset(ctx context.Context, key string) error
+	DeleteStale(ctx context.Context, now, windowStart time.Time) error
+}
+
+type LoginAttemptRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginAttemptRepository(db *gorm.DB) *LoginAttemptRepository {
+	return &LoginAttemptRepository{db: db}
+}
+
+// Find devuelve nil, nil cuando la clave no tiene fallos registrados.
+func (r *LoginAttemptRepository) Find(ctx context.Context, key string) (*models.LoginAttempt, error) {
+	var attempt models.LoginAttempt
+	err := conn(ctx, r.db).Where("key = ?", key).First(&attempt).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+// RegisterFailure suma un fallo en un solo upsert; si el anterior es previo a windowStart el contador vuelve a 1.
+func (r *LoginAttemptRepository) RegisterFailure(ctx context.Context, key string, now, windowStart time.Time) (*models.LoginAttempt, error) {
+	attempt := models.LoginAttempt{Key: key, Failures: 1, LastFailureAt: now, UpdatedAt: now}
+	err := conn(ctx, r.db).
+		Clauses(
+			clause.OnConflict{
+				Columns: []clause.Column{{Name: "key"}},
+				DoUpdates: clause.Assignments(map[string]interface{}{
+					"failures":        gorm.Expr("CASE WHEN login_attempts.last_failure_at < ? THEN 1 ELSE login_attempts.failures + 1 END", windowStart),
+					"last_failure_at": now,
+					"updated_at":      now,
+				}),
+			},
+			clause.Returning{},
+		).
+		Create(&attempt).Error
+	if err != nil {
+		return nil, err
+	}
+	return &attempt, nil
+}
+
+func (r *LoginAttemptRepository) Lock(ctx context.Context, key string, until time.Time) error {
+	return conn(ctx, r.db).
+		Model(&models.LoginAttempt{}).
+		Where("key = ?", key).
+		Updates(map[string]interface{}{"locked_until": until, "updated_at": time.Now()}).Error
+}
+
+func (r *LoginAttemptRepository) Reset(ctx context.Context, key string) error {
+	return conn(ctx, r.db).Where("key = ?", key).Delete(&models.LoginAttempt{}).Error
+}
+
+func (r *LoginAttemptRepository) DeleteStale(ctx context.Context, now, windowStart time.Time) error {
+	return conn(ctx, r.db).
+		Where("last_failure_at < ? AND (locked_until IS NULL OR locked_until <= ?)", windowStart, now).
+		Delete(&models.LoginAttempt{}).Error
+}
@@ -0,0 +1,64 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+)
+
+type IOAuthAuthorizationCodeRepository interface {
+	Create(ctx context.Context, code *models.OAuthAuthorizationCode) error
+	FindByHash(ctx context.Context, codeHash string) (*models.OAuthAuthorizationCode, error)
+	MarkUsed(ctx context.Context, id uint, usedAt time.Time, familyID string) error
+	DeleteExpired(ctx context.Context, before time.Time) error
+}
+
+type OAuthAuthorizationCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthAuthorizationCodeRepository(db *gorm.DB) *OAuthAuthorizationCodeRepository {
+	return &OAuthAuthorizationCodeRepository{db: db}
+}
+
+func (r *OAuthAuthorizationCodeRepository) Create(ctx context.Context, code *models.OAuthAuthorizationCode) error {
+	return conn(ctx, r.db).Create(code).Error
+}
+
+// FindByHash devuelve nil, nil cuando el código no existe; también devuelve los ya usados para detectar reúsos.
+func (r *OAuthAuthorizationCodeRepository) FindByHash(ctx context.Context, codeHash string) (*models.OAuthAuthorizationCode, error) {
+	var code models.OAuthAuthorizationCode
+	err := conn(ctx, r.db).Where("code_hash = ?", codeHash).First(&code).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// MarkUsed solo marca el código si sigue sin usar, para que dos canjes concurrentes no lo acepten ambos.
+func (r *OAuthAuthorizationCodeRepository) MarkUsed(ctx context.Context, id uint, usedAt time.Time, familyID string) error {
+	result := conn(ctx, r.db).
+		Model(&models.OAuthAuthorizationCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"used_at":   usedAt,
+			"family_id": familyID,
+		})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCodeAlreadyUsed
+	}
+	return nil
+}
+
+func (r *OAuthAuthorizationCodeRepository) DeleteExpired(ctx context.Context, before time.Time) error {
+	return conn(ctx, r.db).Where("expires_at < ?", before).Delete(&models.OAuthAuthorizationCode{}).Error
+}
@@ -0,0 +1,61 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+)
+
+type IOAuthClientRepository interface {
+	Create(ctx context.Context, client *models.OAuthClient) error
+	FindByClientID(ctx context.Context, clientID string) (*models.OAuthClient, error)
+	FindAll(ctx context.Context) ([]models.OAuthClient, error)
+	Delete(ctx context.Context, clientID string) error
+}
+
+type OAuthClientRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthClientRepository(db *gorm.DB) *OAuthClientRepository {
+	return &OAuthClientRepository{db: db}
+}
+
+func (r *OAuthClientRepository) Create(ctx context.Context, client *models.OAuthClient) error {
+	return conn(ctx, r.db).Create(client).Error
+}
+
+// FindByClientID devuelve nil, nil cuando el cliente no existe.
+func (r *OAuthClientRepository) FindByClientID(ctx context.Context, clientID string) (*models.OAuthClient, error) {
+	var client models.OAuthClient
+	err := conn(ctx, r.db).Where("client_id = ?", clientID).First(&client).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &client, nil
+}
+
+func (r *OAuthClientRepository) FindAll(ctx context.Context) ([]models.OAuthClient, error) {
+	var clients []models.OAuthClient
+	err := conn(ctx, r.db).Order("id").Find(&clients).Error
+	return clients, err
+}
+
+// Delete borra el cliente junto con sus consentimientos; devuelve ErrNotFound si no existe.
+func (r *OAuthClientRepository) Delete(ctx context.Context, clientID string) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		result := tx.Where("client_id = ?", clientID).Delete(&models.OAuthClient{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return ErrNotFound
+		}
+		return tx.Where("client_id = ?", clientID).Delete(&models.OAuthConsent{}).Error
+	})
+}
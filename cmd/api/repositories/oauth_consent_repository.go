@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IOAuthConsentRepository interface {
+	Find(ctx context.Context, userID uint, clientID string) (*models.OAuthConsent, error)
+	Save(ctx context.Context, consent *models.OAuthConsent) error
+}
+
+type OAuthConsentRepository struct {
+	db *gorm.DB
+}
+
+func NewOAuthConsentRepository(db *gorm.DB) *OAuthConsentRepository {
+	return &OAuthConsentRepository{db: db}
+}
+
+// Find devuelve nil, nil cuando el usuario nunca autorizó al cliente.
+func (r *OAuthConsentRepository) Find(ctx context.Context, userID uint, clientID string) (*models.OAuthConsent, error) {
+	var consent models.OAuthConsent
+	err := conn(ctx, r.db).Where("user_id = ? AND client_id = ?", userID, clientID).First(&consent).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &consent, nil
+}
+
+// Save crea el consentimiento o reemplaza los scopes del que ya existía.
+func (r *OAuthConsentRepository) Save(ctx context.Context, consent *models.OAuthConsent) error {
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}, {Name: "client_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"scope", "updated_at"}),
+		}).
+		Create(consent).Error
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IOutboxEmailRepository interface {
+	Create(ctx context.Context, email *models.OutboxEmail) error
+	ClaimDue(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]models.OutboxEmail, error)
+	MarkSent(ctx context.Context, id uint, sentAt time.Time) error
+	MarkFailed(ctx context.Context, id uint, attempts int, nextAttemptAt time.Time, lastError string, dead bool) error
+}
+
+type OutboxEmailRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxEmailRepository(db *gorm.DB) *OutboxEmailRepository {
+	return &OutboxEmailRepository{db: db}
+}
+
+func (r *OutboxEmailRepository) Create(ctx context.Context, email *models.OutboxEmail) error {
+	return conn(ctx, r.db).Create(email).Error
+}
+
+// ClaimDue toma hasta limit emails pendientes y los reserva hasta leaseUntil para que otra instancia no los envíe
+// a la vez; si el proceso muere a mitad de camino, vuelven a estar disponibles al vencer la reserva.
+func (r *OutboxEmailRepository) ClaimDue(ctx context.Context, now time.Time, limit int, leaseUntil time.Time) ([]models.OutboxEmail, error) {
+	var emails []models.OutboxEmail
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? AND next_attempt_at <= ?", models.OutboxStatusPending, now).
+			Order("next_attempt_at, id").
+			Limit(limit).
+			Find(&emails).Error
+		if err != nil || len(emails) == 0 {
+			return err
+		}
+
+		ids := make([]uint, 0, len(emails))
+		for _, email := range emails {
+			ids = append(ids, email.ID)
+		}
+		return tx.Model(&models.OutboxEmail{}).Where("id IN ?", ids).Update("next_attempt_at", leaseUntil).Error
+	})
+	return emails, err
+}
+
+// MarkSent borra los datos de la plantilla: ya no hacen falta y pueden contener tokens.
+func (r *OutboxEmailRepository) MarkSent(ctx context.Context, id uint, sentAt time.Time) error {
+	return conn(ctx, r.db).
+		Model(&models.OutboxEmail{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"status":     models.OutboxStatusSent,
+			"sent_at":    sentAt,
+			"data":       "{}",
+			"last_error": "",
+		}).Error
+}
+
+// MarkFailed programa el reintento en nextAttemptAt; con dead el email queda descartado y también se borran sus datos.
+func (r *OutboxEmailRepository) MarkFailed(ctx context.Context, id uint, attempts int, nextAttemptAt time.Time, lastError string, dead bool) error {
+	updates := map[string]interface{}{
+		"attempts":        attempts,
+		"next_attempt_at": nextAttemptAt,
+		"last_error":      lastError,
+	}
+	if dead {
+		updates["status"] = models.OutboxStatusFailed
+		updates["data"] = "{}"
+	}
+	return conn(ctx, r.db).Model(&models.OutboxEmail{}).Where("id = ?", id).Updates(updates).Error
+}
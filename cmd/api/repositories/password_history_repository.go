@@ -0,0 +1,50 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+)
+
+type IPasswordHistoryRepository interface {
+	Create(ctx context.Context, entry *models.PasswordHistory) error
+	FindRecentByUserID(ctx context.Context, userID uint, limit int) ([]models.PasswordHistory, error)
+	Prune(ctx context.Context, userID uint, keep int) error
+}
+
+type PasswordHistoryRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordHistoryRepository(db *gorm.DB) *PasswordHistoryRepository {
+	return &PasswordHistoryRepository{db: db}
+}
+
+func (r *PasswordHistoryRepository) Create(ctx context.Context, entry *models.PasswordHistory) error {
+	return conn(ctx, r.db).Create(entry).Error
+}
+
+// FindRecentByUserID devuelve las limit contraseñas más recientes primero.
+func (r *PasswordHistoryRepository) FindRecentByUserID(ctx context.Context, userID uint, limit int) ([]models.PasswordHistory, error) {
+	var entries []models.PasswordHistory
+	err := conn(ctx, r.db).
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(limit).
+		Find(&entries).Error
+	return entries, err
+}
+
+// Prune borra todo salvo las keep contraseñas más recientes del usuario.
+func (r *PasswordHistoryRepository) Prune(ctx context.Context, userID uint, keep int) error {
+	recent := r.db.
+		Model(&models.PasswordHistory{}).
+		Select("id").
+		Where("user_id = ?", userID).
+		Order("created_at DESC, id DESC").
+		Limit(keep)
+	return conn(ctx, r.db).
+		Where("user_id = ? AND id NOT IN (?)", userID, recent).
+		Delete(&models.PasswordHistory{}).Error
+}
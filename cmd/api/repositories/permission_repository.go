@@ -0,0 +1,46 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+)
+
+type IPermissionRepository interface {
+	FindAll(ctx context.Context) ([]models.Permission, error)
+	FindByNames(ctx context.Context, names []string) ([]models.Permission, error)
+	Create(ctx context.Context, permission *models.Permission) error
+}
+
+type PermissionRepository struct {
+	db *gorm.DB
+}
+
+func NewPermissionRepository(db *gorm.DB) *PermissionRepository {
+	return &PermissionRepository{db: db}
+}
+
+func (r *PermissionRepository) FindAll(ctx context.Context) ([]models.Permission, error) {
+	var permissions []models.Permission
+	err := conn(ctx, r.db).Order("name").Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *PermissionRepository) FindByNames(ctx context.Context, names []string) ([]models.Permission, error) {
+	var permissions []models.Permission
+	if len(names) == 0 {
+		return permissions, nil
+	}
+	err := conn(ctx, r.db).Where("name IN ?", names).Find(&permissions).Error
+	return permissions, err
+}
+
+func (r *PermissionRepository) Create(ctx context.Context, permission *models.Permission) error {
+	err := conn(ctx, r.db).Create(permission).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicatedName
+	}
+	return err
+}
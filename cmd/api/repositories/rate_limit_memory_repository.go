@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+)
+
+// rateLimitSweepInterval cada cuánto se descartan los buckets que ya se recargaron por completo.
+const rateLimitSweepInterval = time.Minute
+
+// MemoryRateLimitRepository implementa IRateLimitRepository en memoria, para pruebas y para correr una sola
+// instancia sin base de datos.
+type MemoryRateLimitRepository struct {
+	mutex     sync.Mutex
+	buckets   map[string]*memoryRateLimitBucket
+	lastSweep time.Time
+}
+
+type memoryRateLimitBucket struct {
+	bucket models.RateLimitBucket
+	fullAt time.Time
+}
+
+func NewMemoryRateLimitRepository() *MemoryRateLimitRepository {
+	return &MemoryRateLimitRepository{buckets: map[string]*memoryRateLimitBucket{}}
+}
+
+func (r *MemoryRateLimitRepository) Take(_ context.Context, key string, rate float64, burst int, now time.Time) (time.Duration, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.sweep(now)
+
+	entry, ok := r.buckets[key]
+	if !ok {
+		entry = &memoryRateLimitBucket{bucket: models.RateLimitBucket{Key: key, Tokens: float64(burst), UpdatedAt: now}}
+		r.buckets[key] = entry
+	}
+
+	wait := entry.bucket.Take(rate, burst, now)
+	entry.fullAt = entry.bucket.FullAt(rate, burst)
+	return wait, nil
+}
+
+func (r *MemoryRateLimitRepository) DeleteStale(_ context.Context, before time.Time) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for key, entry := range r.buckets {
+		if entry.bucket.UpdatedAt.Before(before) {
+			delete(r.buckets, key)
+		}
+	}
+	return nil
+}
+
+// sweep evita que la memoria crezca con una clave por cada IP que alguna vez llamó al servicio.
+func (r *MemoryRateLimitRepository) sweep(now time.Time) {
+	if now.Sub(r.lastSweep) < rateLimitSweepInterval {
+		return
+	}
+	r.lastSweep = now
+
+	for key, entry := range r.buckets {
+		if !now.Before(entry.fullAt) {
+			delete(r.buckets, key)
+		}
+	}
+}
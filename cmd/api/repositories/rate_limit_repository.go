@@ -0,0 +1,57 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// IRateLimitRepository guarda los token buckets del rate limiting. Take consume un token de key y devuelve 0, o
+// cuánto hay que esperar si el bucket está vacío; rate es en tokens por segundo. DeleteStale borra los buckets
+// que no se usan desde before.
+type IRateLimitRepository interface {
+	Take(ctx context.Context, key string, rate float64, burst int, now time.Time) (time.Duration, error)
+	DeleteStale(ctx context.Context, before time.Time) error
+}
+
+// RateLimitRepository comparte los buckets entre todas las instancias del servicio.
+type RateLimitRepository struct {
+	db *gorm.DB
+}
+
+func NewRateLimitRepository(db *gorm.DB) *RateLimitRepository {
+	return &RateLimitRepository{db: db}
+}
+
+// Take bloquea la fila del bucket durante la transacción para que las peticiones concurrentes no consuman el
+// mismo token. Un bucket nuevo arranca lleno.
+func (r *RateLimitRepository) Take(ctx context.Context, key string, rate float64, burst int, now time.Time) (time.Duration, error) {
+	var wait time.Duration
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		fresh := models.RateLimitBucket{Key: key, Tokens: float64(burst), UpdatedAt: now}
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&fresh).Error; err != nil {
+			return err
+		}
+
+		var bucket models.RateLimitBucket
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).Where("key = ?", key).First(&bucket).Error; err != nil {
+			return err
+		}
+
+		wait = bucket.Take(rate, burst, now)
+		return tx.Model(&models.RateLimitBucket{}).
+			Where("key = ?", key).
+			Updates(map[string]interface{}{"tokens": bucket.Tokens, "updated_at": bucket.UpdatedAt}).Error
+	})
+	if err != nil {
+		return 0, err
+	}
+	return wait, nil
+}
+
+func (r *RateLimitRepository) DeleteStale(ctx context.Context, before time.Time) error {
+	return conn(ctx, r.db).Where("updated_at < ?", before).Delete(&models.RateLimitBucket{}).Error
+}
@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+)
+
+type IRecoveryCodeRepository interface {
+	ReplaceAll(ctx context.Context, userID uint, codes []models.RecoveryCode) error
+	FindActiveByHash(ctx context.Context, userID uint, codeHash string) (*models.RecoveryCode, error)
+	MarkUsed(ctx context.Context, id uint, usedAt time.Time) error
+	DeleteByUserID(ctx context.Context, userID uint) error
+}
+
+type RecoveryCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewRecoveryCodeRepository(db *gorm.DB) *RecoveryCodeRepository {
+	return &RecoveryCodeRepository{db: db}
+}
+
+// ReplaceAll borra los códigos anteriores del usuario y guarda los nuevos en la misma transacción.
+func (r *RecoveryCodeRepository) ReplaceAll(ctx context.Context, userID uint, codes []models.RecoveryCode) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error; err != nil {
+			return err
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		return tx.Create(&codes).Error
+	})
+}
+
+// FindActiveByHash devuelve nil, nil cuando no hay un código sin usar con ese hash.
+func (r *RecoveryCodeRepository) FindActiveByHash(ctx context.Context, userID uint, codeHash string) (*models.RecoveryCode, error) {
+	var code models.RecoveryCode
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		First(&code).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// MarkUsed solo marca el código si sigue sin usar, para que dos canjes concurrentes no lo acepten ambos.
+func (r *RecoveryCodeRepository) MarkUsed(ctx context.Context, id uint, usedAt time.Time) error {
+	result := conn(ctx, r.db).
+		Model(&models.RecoveryCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCodeAlreadyUsed
+	}
+	return nil
+}
+
+func (r *RecoveryCodeRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	return conn(ctx, r.db).Where("user_id = ?", userID).Delete(&models.RecoveryCode{}).Error
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IRevokedTokenRepository interface {
+	Create(ctx context.Context, token *models.RevokedToken) error
+	Exists(ctx context.Context, jti string) (bool, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type RevokedTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRevokedTokenRepository(db *gorm.DB) *RevokedTokenRepository {
+	return &RevokedTokenRepository{db: db}
+}
+
+// Create ignora los jti ya revocados.
+func (r *RevokedTokenRepository) Create(ctx context.Context, token *models.RevokedToken) error {
+	return conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(token).Error
+}
+
+func (r *RevokedTokenRepository) Exists(ctx context.Context, jti string) (bool, error) {
+	var count int64
+	err := conn(ctx, r.db).Model(&models.RevokedToken{}).Where("jti = ?", jti).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *RevokedTokenRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	return conn(ctx, r.db).Where("expires_at <= ?", now).Delete(&models.RevokedToken{}).Error
+}
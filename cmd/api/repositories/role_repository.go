@@ -0,0 +1,81 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+)
+
+type IRoleRepository interface {
+	FindAll(ctx context.Context) ([]models.Role, error)
+	FindByID(ctx context.Context, id uint) (*models.Role, error)
+	FindByName(ctx context.Context, name string) (*models.Role, error)
+	Create(ctx context.Context, role *models.Role) error
+	Update(ctx context.Context, role *models.Role) error
+	Delete(ctx context.Context, id uint) error
+}
+
+type RoleRepository struct {
+	db *gorm.DB
+}
+
+func NewRoleRepository(db *gorm.DB) *RoleRepository {
+	return &RoleRepository{db: db}
+}
+
+func (r *RoleRepository) FindAll(ctx context.Context) ([]models.Role, error) {
+	var roles []models.Role
+	err := conn(ctx, r.db).Preload("Permissions").Order("name").Find(&roles).Error
+	return roles, err
+}
+
+// FindByID devuelve nil, nil cuando el rol no existe.
+func (r *RoleRepository) FindByID(ctx context.Context, id uint) (*models.Role, error) {
+	var role models.Role
+	err := conn(ctx, r.db).Preload("Permissions").First(&role, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// FindByName devuelve nil, nil cuando el rol no existe.
+func (r *RoleRepository) FindByName(ctx context.Context, name string) (*models.Role, error) {
+	var role models.Role
+	err := conn(ctx, r.db).Preload("Permissions").Where("name = ?", name).First(&role).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &role, nil
+}
+
+// Create guarda el rol junto con la relación a sus permisos, que deben existir previamente.
+func (r *RoleRepository) Create(ctx context.Context, role *models.Role) error {
+	err := conn(ctx, r.db).Omit("Permissions.*").Create(role).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicatedName
+	}
+	return err
+}
+
+// Update guarda la descripción y reemplaza los permisos del rol.
+func (r *RoleRepository) Update(ctx context.Context, role *models.Role) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Model(role).Update("description", role.Description).Error; err != nil {
+			return err
+		}
+		return tx.Model(role).Omit("Permissions.*").Association("Permissions").Replace(role.Permissions)
+	})
+}
+
+func (r *RoleRepository) Delete(ctx context.Context, id uint) error {
+	return conn(ctx, r.db).Select("Permissions").Delete(&models.Role{ID: id}).Error
+}
@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+)
+
+type ISessionRepository interface {
+	Create(ctx context.Context, session *models.Session) error
+	FindByID(ctx context.Context, id uint) (*models.Session, error)
+	FindByFamilyID(ctx context.Context, familyID string) (*models.Session, error)
+	FindActiveByUserID(ctx context.Context, userID uint, now time.Time) ([]models.Session, error)
+	UpdateActivity(ctx context.Context, session *models.Session) error
+	RevokeByFamilyID(ctx context.Context, familyID string, revokedAt time.Time) error
+	RevokeAllForUser(ctx context.Context, userID uint, revokedAt time.Time) error
+}
+
+type SessionRepository struct {
+	db *gorm.DB
+}
+
+func NewSessionRepository(db *gorm.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+func (r *SessionRepository) Create(ctx context.Context, session *models.Session) error {
+	return conn(ctx, r.db).Create(session).Error
+}
+
+// FindByID devuelve nil, nil cuando la sesión no existe.
+func (r *SessionRepository) FindByID(ctx context.Context, id uint) (*models.Session, error) {
+	var session models.Session
+	err := conn(ctx, r.db).Where("id = ?", id).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// FindByFamilyID devuelve nil, nil cuando la familia no tiene sesión.
+func (r *SessionRepository) FindByFamilyID(ctx context.Context, familyID string) (*models.Session, error) {
+	var session models.Session
+	err := conn(ctx, r.db).Where("family_id = ?", familyID).First(&session).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// FindActiveByUserID devuelve las sesiones vigentes, la usada más recientemente primero.
+func (r *SessionRepository) FindActiveByUserID(ctx context.Context, userID uint, now time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// UpdateActivity guarda el último uso de la sesión: fecha, vencimiento, IP y user agent.
+func (r *SessionRepository) UpdateActivity(ctx context.Context, session *models.Session) error {
+	return conn(ctx, r.db).
+		Model(&models.Session{}).
+		Where("id = ?", session.ID).
+		Updates(map[string]interface{}{
+			"last_seen_at": session.LastSeenAt,
+			"expires_at":   session.ExpiresAt,
+			"device_name":  session.DeviceName,
+			"user_agent":   session.UserAgent,
+			"ip_address":   session.IPAddress,
+		}).Error
+}
+
+func (r *SessionRepository) RevokeByFamilyID(ctx context.Context, familyID string, revokedAt time.Time) error {
+	return conn(ctx, r.db).
+		Model(&models.Session{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Update("revoked_at", revokedAt).Error
+}
+
+func (r *SessionRepository) RevokeAllForUser(ctx context.Context, userID uint, revokedAt time.Time) error {
+	return conn(ctx, r.db).
+		Model(&models.Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", revokedAt).Error
+}
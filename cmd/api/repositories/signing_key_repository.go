@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+)
+
+type ISigningKeyRepository interface {
+	Create(ctx context.Context, key *models.SigningKey) error
+	FindPublished(ctx context.Context, now time.Time) ([]models.SigningKey, error)
+	ScheduleRetirement(ctx context.Context, exceptID uint, retiresAt time.Time) error
+	DeleteRetired(ctx context.Context, now time.Time) error
+}
+
+type SigningKeyRepository struct {
+	db *gorm.DB
+}
+
+func NewSigningKeyRepository(db *gorm.DB) *SigningKeyRepository {
+	return &SigningKeyRepository{db: db}
+}
+
+func (r *SigningKeyRepository) Create(ctx context.Context, key *models.SigningKey) error {
+	return conn(ctx, r.db).Create(key).Error
+}
+
+// FindPublished devuelve las claves no retiradas, de la activación más reciente a la más antigua.
+func (r *SigningKeyRepository) FindPublished(ctx context.Context, now time.Time) ([]models.SigningKey, error) {
+	var keys []models.SigningKey
+	err := conn(ctx, r.db).
+		Where("retires_at IS NULL OR retires_at > ?", now).
+		Order("activated_at DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+// ScheduleRetirement fija la fecha de retiro de todas las claves sin retiro programado salvo exceptID.
+func (r *SigningKeyRepository) ScheduleRetirement(ctx context.Context, exceptID uint, retiresAt time.Time) error {
+	return conn(ctx, r.db).
+		Model(&models.SigningKey{}).
+		Where("id <> ? AND retires_at IS NULL", exceptID).
+		Update("retires_at", retiresAt).Error
+}
+
+func (r *SigningKeyRepository) DeleteRetired(ctx context.Context, now time.Time) error {
+	return conn(ctx, r.db).
+		Where("retires_at IS NOT NULL AND retires_at <= ?", now).
+		Delete(&models.SigningKey{}).Error
+}
@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type ITOTPCredentialRepository interface {
+	FindByUserID(ctx context.Context, userID uint) (*models.TOTPCredential, error)
+	Save(ctx context.Context, credential *models.TOTPCredential) error
+	MarkStepUsed(ctx context.Context, id uint, step int64) error
+	DeleteByUserID(ctx context.Context, userID uint) error
+}
+
+type TOTPCredentialRepository struct {
+	db *gorm.DB
+}
+
+func NewTOTPCredentialRepository(db *gorm.DB) *TOTPCredentialRepository {
+	return &TOTPCredentialRepository{db: db}
+}
+
+// FindByUserID devuelve nil, nil cuando el usuario no tiene TOTP.
+func (r *TOTPCredentialRepository) FindByUserID(ctx context.Context, userID uint) (*models.TOTPCredential, error) {
+	var credential models.TOTPCredential
+	err := conn(ctx, r.db).Where("user_id = ?", userID).First(&credential).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &credential, nil
+}
+
+// Save crea la credencial o reemplaza la del usuario si ya existía.
+func (r *TOTPCredentialRepository) Save(ctx context.Context, credential *models.TOTPCredential) error {
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "user_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"secret", "confirmed_at", "last_used_step", "updated_at"}),
+		}).
+		Create(credential).Error
+}
+
+// MarkStepUsed solo avanza LastUsedStep, para que un código ya aceptado no se acepte de nuevo.
+func (r *TOTPCredentialRepository) MarkStepUsed(ctx context.Context, id uint, step int64) error {
+	result := conn(ctx, r.db).
+		Model(&models.TOTPCredential{}).
+		Where("id = ? AND last_used_step < ?", id, step).
+		Update("last_used_step", step)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCodeAlreadyUsed
+	}
+	return nil
+}
+
+func (r *TOTPCredentialRepository) DeleteByUserID(ctx context.Context, userID uint) error {
+	return conn(ctx, r.db).Where("user_id = ?", userID).Delete(&models.TOTPCredential{}).Error
+}
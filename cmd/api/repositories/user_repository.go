@@ -0,0 +1,82 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+)
+
+type IUserRepository interface {
+	Create(ctx context.Context, user *models.User) error
+	Update(ctx context.Context, user *models.User) error
+	FindByID(ctx context.Context, id uint) (*models.User, error)
+	FindByEmail(ctx context.Context, email string) (*models.User, error)
+	FindByPhoneNumber(ctx context.Context, phoneNumber string) (*models.User, error)
+}
+
+type UserRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRepository(db *gorm.DB) *UserRepository {
+	return &UserRepository{db: db}
+}
+
+func (r *UserRepository) Create(ctx context.Context, user *models.User) error {
+	err := conn(ctx, r.db).Create(user).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicatedEmail
+	}
+	return err
+}
+
+// Update devuelve ErrDuplicatedPhoneNumber si el número ya está verificado por otro usuario: el email no cambia
+// después del alta, así que es la única clave única que un update puede repetir.
+func (r *UserRepository) Update(ctx context.Context, user *models.User) error {
+	err := conn(ctx, r.db).Save(user).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicatedPhoneNumber
+	}
+	return err
+}
+
+// FindByID devuelve nil, nil cuando el usuario no existe.
+func (r *UserRepository) FindByID(ctx context.Context, id uint) (*models.User, error) {
+	var user models.User
+	err := conn(ctx, r.db).First(&user, id).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByEmail devuelve nil, nil cuando el usuario no existe.
+func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*models.User, error) {
+	var user models.User
+	err := conn(ctx, r.db).Where("email = ?", email).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
+
+// FindByPhoneNumber busca por número verificado y devuelve nil, nil cuando ningún usuario lo tiene.
+func (r *UserRepository) FindByPhoneNumber(ctx context.Context, phoneNumber string) (*models.User, error) {
+	var user models.User
+	err := conn(ctx, r.db).Where("phone_number = ?", phoneNumber).First(&user).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
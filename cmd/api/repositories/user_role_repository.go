@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IUserRoleRepository interface {
+	Assign(ctx context.Context, userID, roleID uint) error
+	Remove(ctx context.Context, userID, roleID uint) error
+	FindRolesByUserID(ctx context.Context, userID uint) ([]models.Role, error)
+}
+
+type UserRoleRepository struct {
+	db *gorm.DB
+}
+
+func NewUserRoleRepository(db *gorm.DB) *UserRoleRepository {
+	return &UserRoleRepository{db: db}
+}
+
+// Assign ignora las asignaciones que ya existen.
+func (r *UserRoleRepository) Assign(ctx context.Context, userID, roleID uint) error {
+	return conn(ctx, r.db).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Omit("Role").
+		Create(&models.UserRole{UserID: userID, RoleID: roleID}).Error
+}
+
+func (r *UserRoleRepository) Remove(ctx context.Context, userID, roleID uint) error {
+	return conn(ctx, r.db).
+		Where("user_id = ? AND role_id = ?", userID, roleID).
+		Delete(&models.UserRole{}).Error
+}
+
+// FindRolesByUserID devuelve los roles del usuario con sus permisos.
+func (r *UserRoleRepository) FindRolesByUserID(ctx context.Context, userID uint) ([]models.Role, error) {
+	var roles []models.Role
+	err := conn(ctx, r.db).
+		Joins("JOIN user_roles ON user_roles.role_id = roles.id").
+		Where("user_roles.user_id = ?", userID).
+		Preload("Permissions").
+		Order("roles.name").
+		Find(&roles).Error
+	return roles, err
+}
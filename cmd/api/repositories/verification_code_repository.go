@@ -0,0 +1,91 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+)
+
+type IVerificationCodeRepository interface {
+	Create(ctx context.Context, code *models.VerificationCode) error
+	FindLatestActive(ctx context.Context, userID uint, purpose string, now time.Time) (*models.VerificationCode, error)
+	FindActiveByHash(ctx context.Context, purpose, codeHash string, now time.Time) (*models.VerificationCode, error)
+	IncrementAttempts(ctx context.Context, id uint) error
+	MarkUsed(ctx context.Context, id uint, usedAt time.Time) error
+	InvalidateAll(ctx context.Context, userID uint, purpose string, usedAt time.Time) error
+}
+
+type VerificationCodeRepository struct {
+	db *gorm.DB
+}
+
+func NewVerificationCodeRepository(db *gorm.DB) *VerificationCodeRepository {
+	return &VerificationCodeRepository{db: db}
+}
+
+func (r *VerificationCodeRepository) Create(ctx context.Context, code *models.VerificationCode) error {
+	return conn(ctx, r.db).Create(code).Error
+}
+
+// FindLatestActive devuelve nil, nil cuando no hay un código vigente.
+func (r *VerificationCodeRepository) FindLatestActive(ctx context.Context, userID uint, purpose string, now time.Time) (*models.VerificationCode, error) {
+	var code models.VerificationCode
+	err := conn(ctx, r.db).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL AND expires_at > ?", userID, purpose, now).
+		Order("created_at DESC").
+		First(&code).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+// FindActiveByHash devuelve nil, nil cuando no hay un token vigente con ese hash.
+func (r *VerificationCodeRepository) FindActiveByHash(ctx context.Context, purpose, codeHash string, now time.Time) (*models.VerificationCode, error) {
+	var code models.VerificationCode
+	err := conn(ctx, r.db).
+		Where("purpose = ? AND code_hash = ? AND used_at IS NULL AND expires_at > ?", purpose, codeHash, now).
+		First(&code).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &code, nil
+}
+
+func (r *VerificationCodeRepository) IncrementAttempts(ctx context.Context, id uint) error {
+	return conn(ctx, r.db).
+		Model(&models.VerificationCode{}).
+		Where("id = ?", id).
+		UpdateColumn("attempts", gorm.Expr("attempts + 1")).Error
+}
+
+// MarkUsed solo marca el código si sigue sin usar, para que dos canjes concurrentes no lo acepten ambos.
+func (r *VerificationCodeRepository) MarkUsed(ctx context.Context, id uint, usedAt time.Time) error {
+	result := conn(ctx, r.db).
+		Model(&models.VerificationCode{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Update("used_at", usedAt)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrCodeAlreadyUsed
+	}
+	return nil
+}
+
+func (r *VerificationCodeRepository) InvalidateAll(ctx context.Context, userID uint, purpose string, usedAt time.Time) error {
+	return conn(ctx, r.db).
+		Model(&models.VerificationCode{}).
+		Where("user_id = ? AND purpose = ? AND used_at IS NULL", userID, purpose).
+		Update("used_at", usedAt).Error
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"context"
+	"errors"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+)
+
+type IWebAuthnCredentialRepository interface {
+	Create(ctx context.Context, credential *models.WebAuthnCredential) error
+	FindByUserID(ctx context.Context, userID uint) ([]models.WebAuthnCredential, error)
+	UpdateAfterLogin(ctx context.Context, credential *models.WebAuthnCredential) error
+	Delete(ctx context.Context, userID, id uint) error
+}
+
+type WebAuthnCredentialRepository struct {
+	db *gorm.DB
+}
+
+func NewWebAuthnCredentialRepository(db *gorm.DB) *WebAuthnCredentialRepository {
+	return &WebAuthnCredentialRepository{db: db}
+}
+
+func (r *WebAuthnCredentialRepository) Create(ctx context.Context, credential *models.WebAuthnCredential) error {
+	err := conn(ctx, r.db).Create(credential).Error
+	if errors.Is(err, gorm.ErrDuplicatedKey) {
+		return ErrDuplicatedCredential
+	}
+	return err
+}
+
+func (r *WebAuthnCredentialRepository) FindByUserID(ctx context.Context, userID uint) ([]models.WebAuthnCredential, error) {
+	var credentials []models.WebAuthnCredential
+	err := conn(ctx, r.db).Where("user_id = ?", userID).Order("id").Find(&credentials).Error
+	return credentials, err
+}
+
+// UpdateAfterLogin guarda el contador de firmas, la alerta de clonado y los flags que devolvió el último login.
+func (r *WebAuthnCredentialRepository) UpdateAfterLogin(ctx context.Context, credential *models.WebAuthnCredential) error {
+	return conn(ctx, r.db).
+		Model(&models.WebAuthnCredential{}).
+		Where("id = ?", credential.ID).
+		Updates(map[string]interface{}{
+			"sign_count":    credential.SignCount,
+			"clone_warning": credential.CloneWarning,
+			"flags":         credential.Flags,
+			"last_used_at":  credential.LastUsedAt,
+		}).Error
+}
+
+// Delete devuelve ErrNotFound si la credencial no existe o es de otro usuario.
+func (r *WebAuthnCredentialRepository) Delete(ctx context.Context, userID, id uint) error {
+	result := conn(ctx, r.db).Where("id = ? AND user_id = ?", id, userID).Delete(&models.WebAuthnCredential{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
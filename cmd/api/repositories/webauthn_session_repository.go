@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+type IWebAuthnSessionRepository interface {
+	Create(ctx context.Context, session *models.WebAuthnSession) error
+	Consume(ctx context.Context, ceremony, tokenHash string, now time.Time) (*models.WebAuthnSession, error)
+	DeleteExpired(ctx context.Context, now time.Time) error
+}
+
+type WebAuthnSessionRepository struct {
+	db *gorm.DB
+}
+
+func NewWebAuthnSessionRepository(db *gorm.DB) *WebAuthnSessionRepository {
+	return &WebAuthnSessionRepository{db: db}
+}
+
+func (r *WebAuthnSessionRepository) Create(ctx context.Context, session *models.WebAuthnSession) error {
+	return conn(ctx, r.db).Create(session).Error
+}
+
+// Consume borra la sesión vigente y la devuelve, de modo que cada ceremonia se pueda terminar una sola vez.
+// Devuelve nil, nil cuando no hay una sesión vigente con ese hash.
+func (r *WebAuthnSessionRepository) Consume(ctx context.Context, ceremony, tokenHash string, now time.Time) (*models.WebAuthnSession, error) {
+	var sessions []models.WebAuthnSession
+	err := conn(ctx, r.db).
+		Clauses(clause.Returning{}).
+		Where("ceremony = ? AND token_hash = ? AND expires_at > ?", ceremony, tokenHash, now).
+		Delete(&sessions).Error
+	if err != nil {
+		return nil, err
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	return &sessions[0], nil
+}
+
+func (r *WebAuthnSessionRepository) DeleteExpired(ctx context.Context, now time.Time) error {
+	return conn(ctx, r.db).Where("expires_at <= ?", now).Delete(&models.WebAuthnSession{}).Error
+}
@@ -10,6 +10,7 @@ import (
 	"github.com/taskalataminfo2026/tool-kit-lib-go/pkg/rusty"
 	"github.com/taskalataminfo2026/tool-kit-lib-go/pkg/transport/http_client"
 
+	"io"
 	"net/http"
 	"net/url"
 	"strings"
@@ -20,12 +21,18 @@ import (
 type IRustyClient interface {
 	Get(ctx context.Context, url string, headers map[string]string, queryParams map[string]string, tags []string) RustyResponse
 	Post(ctx context.Context, url string, headers map[string]string, body interface{}, tags []string) RustyResponse
+	PostForm(ctx context.Context, url string, headers map[string]string, form url.Values, tags []string) RustyResponse
 	Patch(ctx context.Context, url string, headers map[string]string, body interface{}, tags []string) RustyResponse
 	Put(ctx context.Context, url string, headers map[string]string, body interface{}, tags []string) RustyResponse
 	Delete(ctx context.Context, url string, headers map[string]string, params map[string]interface{}, tags []string) RustyResponse
 }
 
 type RustyClient struct {
+	config config.RustyClientConfig
+}
+
+func NewRustyClient(rustyConfig config.RustyClientConfig) *RustyClient {
+	return &RustyClient{config: rustyConfig}
 }
 
 type RustyResponse struct {
@@ -34,6 +41,18 @@ type RustyResponse struct {
 	Error      error
 }
 
+// loggableHeaders oculta las credenciales antes de escribir los headers en el log.
+func loggableHeaders(headers map[string]string) map[string]string {
+	loggable := make(map[string]string, len(headers))
+	for key, value := range headers {
+		if strings.EqualFold(key, "Authorization") || strings.EqualFold(key, "X-Vault-Token") {
+			value = "[REDACTED]"
+		}
+		loggable[key] = value
+	}
+	return loggable
+}
+
 func (client *RustyClient) getEndpointOptions(headers map[string]string) []rusty.EndpointOption {
 	var endpointOptions []rusty.EndpointOption
 	for key, value := range headers {
@@ -68,7 +87,7 @@ func (client *RustyClient) generateResponse(ctx context.Context, response *rusty
 	if response != nil {
 		rustyResponse.Body = response.Body
 		rustyResponse.StatusCode = response.StatusCode
-		tags = utils.Merge(tags, fmt.Sprintf("status code: %v, resp:%v", response.StatusCode, strings.TrimSpace(string(response.Body))))
+		tags = utils.Merge(tags, fmt.Sprintf("status code: %v", response.StatusCode))
 		log.Info(ctx, "response")
 	}
 	if rustyResponse.StatusCode == 0 {
@@ -79,13 +98,13 @@ func (client *RustyClient) generateResponse(ctx context.Context, response *rusty
 }
 
 func (client *RustyClient) Get(ctx context.Context, url string, headers map[string]string, queryParams map[string]string, tags []string) RustyResponse {
-	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:GET", fmt.Sprintf("headers:%v", headers), fmt.Sprintf("queryParams:%v", queryParams))
+	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:GET", fmt.Sprintf("headers:%v", loggableHeaders(headers)), fmt.Sprintf("queryParams:%v", queryParams))
 	requester := http_client.NewRetryable(
-		config.RustyConfig.RetryCount,
-		http_client.WithTimeout(config.RustyConfig.DefaultTimeOut),
+		client.config.RetryCount,
+		http_client.WithTimeout(client.config.DefaultTimeOut),
 	)
 
-	log.Info(ctx, fmt.Sprintf("GET - url: %v, headers: %v", url, headers))
+	log.Info(ctx, fmt.Sprintf("GET - url: %v, headers: %v", url, loggableHeaders(headers)))
 	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:GET")
 
 	endpoint, err := rusty.NewEndpoint(requester, url, client.getEndpointOptions(headers)...)
@@ -102,14 +121,15 @@ func (client *RustyClient) Get(ctx context.Context, url string, headers map[stri
 func (client *RustyClient) Post(ctx context.Context, url string, headers map[string]string, body interface{}, tags []string) RustyResponse {
 	headers["Content-type"] = "application/json"
 	bodyJSON, _ := json.Marshal(&body)
-	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:POST", fmt.Sprintf("headers:%v", headers), fmt.Sprintf("body:%v", string(bodyJSON)))
+	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:POST", fmt.Sprintf("headers:%v", loggableHeaders(headers)))
 	requester := http_client.NewRetryable(
 		0,
-		http_client.WithTimeout(config.RustyConfig.DefaultTimeOut),
+		http_client.WithTimeout(client.config.DefaultTimeOut),
 	)
 
-	log.Info(ctx, fmt.Sprintf("POST - url: %v, headers: %v, body %v", url, headers, string(bodyJSON)))
-	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), fmt.Sprintf("body:%v", string(bodyJSON)), "method:POST")
+	// El cuerpo no se registra: puede llevar client_secret, códigos de autorización u OTP.
+	log.Info(ctx, fmt.Sprintf("POST - url: %v, headers: %v, body bytes: %v", url, loggableHeaders(headers), len(bodyJSON)))
+	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:POST")
 
 	endpoint, err := rusty.NewEndpoint(requester, url, client.getEndpointOptions(headers)...)
 	if err != nil {
@@ -122,17 +142,48 @@ func (client *RustyClient) Post(ctx context.Context, url string, headers map[str
 	return client.generateResponse(ctx, response, err, tags)
 }
 
+// PostForm envía el formulario como application/x-www-form-urlencoded, el formato que exigen los token endpoints
+// de OAuth (RFC 6749 4.1.3). Usa net/http en lugar de rusty, que serializa los cuerpos como JSON.
+func (client *RustyClient) PostForm(ctx context.Context, url string, headers map[string]string, form url.Values, tags []string) RustyResponse {
+	body := form.Encode()
+	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:POST", fmt.Sprintf("headers:%v", loggableHeaders(headers)))
+
+	// El cuerpo no se registra: puede llevar client_secret, códigos de autorización u OTP.
+	log.Info(ctx, fmt.Sprintf("POST - url: %v, headers: %v, body bytes: %v", url, loggableHeaders(headers), len(body)))
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(body))
+	if err != nil {
+		return RustyResponse{
+			Error: err,
+		}
+	}
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	httpClient := &http.Client{Timeout: client.config.DefaultTimeOut}
+	response, err := httpClient.Do(request)
+	if err != nil {
+		return client.generateResponse(ctx, nil, err, tags)
+	}
+	defer response.Body.Close()
+
+	responseBody, err := io.ReadAll(response.Body)
+	return client.generateResponse(ctx, &rusty.Response{Body: responseBody, StatusCode: response.StatusCode}, err, tags)
+}
+
 func (client *RustyClient) Patch(ctx context.Context, url string, headers map[string]string, body interface{}, tags []string) RustyResponse {
 	headers["Content-type"] = "application/json"
 	bodyJSON, _ := json.Marshal(&body)
-	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:PATCH", fmt.Sprintf("headers:%v", headers), fmt.Sprintf("body:%v", string(bodyJSON)))
+	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:PATCH", fmt.Sprintf("headers:%v", loggableHeaders(headers)))
 	requester := http_client.NewRetryable(
 		0,
-		http_client.WithTimeout(config.RustyConfig.DefaultTimeOut),
+		http_client.WithTimeout(client.config.DefaultTimeOut),
 	)
 
-	log.Info(ctx, fmt.Sprintf("PATCH - url: %v, headers: %v, body %v", url, headers, string(bodyJSON)))
-	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), fmt.Sprintf("body:%v", string(bodyJSON)), "method:PATCH")
+	log.Info(ctx, fmt.Sprintf("PATCH - url: %v, headers: %v, body bytes: %v", url, loggableHeaders(headers), len(bodyJSON)))
+	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:PATCH")
 
 	endpoint, err := rusty.NewEndpoint(requester, url, client.getEndpointOptions(headers)...)
 	if err != nil {
@@ -148,14 +199,14 @@ func (client *RustyClient) Patch(ctx context.Context, url string, headers map[st
 func (client *RustyClient) Put(ctx context.Context, url string, headers map[string]string, body interface{}, tags []string) RustyResponse {
 	headers["Content-type"] = "application/json"
 	bodyJSON, _ := json.Marshal(&body)
-	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:PUT", fmt.Sprintf("headers:%v", headers), fmt.Sprintf("body:%v", string(bodyJSON)))
+	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:PUT", fmt.Sprintf("headers:%v", loggableHeaders(headers)))
 	requester := http_client.NewRetryable(
 		0,
-		http_client.WithTimeout(config.RustyConfig.DefaultTimeOut),
+		http_client.WithTimeout(client.config.DefaultTimeOut),
 	)
 
-	log.Info(ctx, fmt.Sprintf("PUT - url: %v, headers: %v, body %v", url, headers, string(bodyJSON)))
-	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), fmt.Sprintf("body:%v", string(bodyJSON)), "method:PUT")
+	log.Info(ctx, fmt.Sprintf("PUT - url: %v, headers: %v, body bytes: %v", url, loggableHeaders(headers), len(bodyJSON)))
+	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:PUT")
 
 	endpoint, err := rusty.NewEndpoint(requester, url, client.getEndpointOptions(headers)...)
 	if err != nil {
@@ -169,13 +220,13 @@ func (client *RustyClient) Put(ctx context.Context, url string, headers map[stri
 }
 
 func (client *RustyClient) Delete(ctx context.Context, url string, headers map[string]string, params map[string]interface{}, tags []string) RustyResponse {
-	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:DELETE", fmt.Sprintf("headers:%v", headers), fmt.Sprintf("params:%v", params))
+	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:DELETE", fmt.Sprintf("headers:%v", loggableHeaders(headers)), fmt.Sprintf("params:%v", params))
 	requester := http_client.NewRetryable(
 		0,
-		http_client.WithTimeout(config.RustyConfig.DefaultTimeOut),
+		http_client.WithTimeout(client.config.DefaultTimeOut),
 	)
 
-	log.Info(ctx, fmt.Sprintf("DELETE - url: %v, headers: %v", url, headers))
+	log.Info(ctx, fmt.Sprintf("DELETE - url: %v, headers: %v", url, loggableHeaders(headers)))
 	tags = utils.Merge(tags, fmt.Sprintf("url:%v", url), "method:DELETE")
 
 	endpoint, err := rusty.NewEndpoint(requester, url, client.getEndpointOptions(headers)...)
@@ -0,0 +1,52 @@
+package rusty
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+)
+
+func TestRustyClient(t *testing.T) {
+
+	t.Run("post form", func(t *testing.T) {
+		var contentType, accept, body string
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			contentType, accept = r.Header.Get("Content-Type"), r.Header.Get("Accept")
+			raw, _ := io.ReadAll(r.Body)
+			body = string(raw)
+			_, _ = w.Write([]byte(`{"access_token":"token"}`))
+		}))
+		defer server.Close()
+
+		form := url.Values{"code": {"a b&c=d"}, "client_secret": {"secret"}}
+		client := NewRustyClient(config.RustyClientConfig{DefaultTimeOut: time.Second})
+		response := client.PostForm(context.Background(), server.URL, map[string]string{"Accept": "application/json"}, form, nil)
+
+		if response.Error != nil || response.StatusCode != http.StatusOK || string(response.Body) != `{"access_token":"token"}` {
+			t.Fatalf("unexpected response: %d %q %v", response.StatusCode, response.Body, response.Error)
+		}
+		if contentType != "application/x-www-form-urlencoded" || accept != "application/json" {
+			t.Errorf("unexpected headers: content type %q, accept %q", contentType, accept)
+		}
+		if body != form.Encode() {
+			t.Errorf("unexpected body: got %q, want %q", body, form.Encode())
+		}
+	})
+
+	t.Run("post form without response", func(t *testing.T) {
+		server := httptest.NewServer(http.NotFoundHandler())
+		server.Close()
+
+		client := NewRustyClient(config.RustyClientConfig{DefaultTimeOut: time.Second})
+		response := client.PostForm(context.Background(), server.URL, map[string]string{}, url.Values{}, nil)
+		if response.Error == nil || response.StatusCode != http.StatusFailedDependency {
+			t.Errorf("unexpected response: %d %v", response.StatusCode, response.Error)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+// Package rustytest ofrece un rusty.IRustyClient para probar los clientes HTTP contra servidores httptest.
+package rustytest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/rusty"
+)
+
+// Client habla con net/http como el RustyClient: envía los cuerpos en JSON y responde 424 cuando no hubo
+// respuesta. PostForm usa el RustyClient real, que no depende de la librería rusty.
+type Client struct{}
+
+func (c Client) Get(ctx context.Context, url string, headers map[string]string, queryParams map[string]string, _ []string) rusty.RustyResponse {
+	return c.do(ctx, http.MethodGet, url, headers, queryParams, nil)
+}
+
+func (c Client) Post(ctx context.Context, url string, headers map[string]string, body interface{}, _ []string) rusty.RustyResponse {
+	return c.do(ctx, http.MethodPost, url, headers, nil, body)
+}
+
+func (c Client) PostForm(ctx context.Context, url string, headers map[string]string, form url.Values, tags []string) rusty.RustyResponse {
+	return rusty.NewRustyClient(config.RustyClientConfig{DefaultTimeOut: 5 * time.Second}).PostForm(ctx, url, headers, form, tags)
+}
+
+func (c Client) Patch(ctx context.Context, url string, headers map[string]string, body interface{}, _ []string) rusty.RustyResponse {
+	return c.do(ctx, http.MethodPatch, url, headers, nil, body)
+}
+
+func (c Client) Put(ctx context.Context, url string, headers map[string]string, body interface{}, _ []string) rusty.RustyResponse {
+	return c.do(ctx, http.MethodPut, url, headers, nil, body)
+}
+
+func (c Client) Delete(ctx context.Context, url string, headers map[string]string, _ map[string]interface{}, _ []string) rusty.RustyResponse {
+	return c.do(ctx, http.MethodDelete, url, headers, nil, nil)
+}
+
+func (c Client) do(ctx context.Context, method, rawURL string, headers, query map[string]string, body interface{}) rusty.RustyResponse {
+	target, err := url.Parse(rawURL)
+	if err != nil {
+		return rusty.RustyResponse{StatusCode: http.StatusFailedDependency, Error: err}
+	}
+	values := target.Query()
+	for key, value := range query {
+		values.Set(key, value)
+	}
+	target.RawQuery = values.Encode()
+
+	var reader io.Reader
+	if body != nil {
+		encoded, _ := json.Marshal(body)
+		reader = bytes.NewReader(encoded)
+	}
+	request, err := http.NewRequestWithContext(ctx, method, target.String(), reader)
+	if err != nil {
+		return rusty.RustyResponse{StatusCode: http.StatusFailedDependency, Error: err}
+	}
+	for key, value := range headers {
+		request.Header.Set(key, value)
+	}
+	if body != nil {
+		request.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return rusty.RustyResponse{StatusCode: http.StatusFailedDependency, Error: err}
+	}
+	defer response.Body.Close()
+	responseBody, err := io.ReadAll(response.Body)
+	return rusty.RustyResponse{Body: responseBody, StatusCode: response.StatusCode, Error: err}
+}
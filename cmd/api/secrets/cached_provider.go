@@ -0,0 +1,101 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/labstack/gommon/log"
+)
+
+type cachedSecret struct {
+	value     string
+	fetchedAt time.Time
+}
+
+// CachedProvider guarda los secretos ttl para no consultar al proveedor en cada uso, y Run los relee en segundo
+// plano para que una rotación se vea sin redeploy. Si el proveedor falla se sigue usando el último valor leído:
+// una caída del proveedor no debe cortar las conexiones nuevas a la base ni la firma de tokens.
+type CachedProvider struct {
+	Provider ISecretProvider
+	ttl      time.Duration
+
+	mutex   sync.RWMutex
+	secrets map[string]cachedSecret
+	now     func() time.Time
+}
+
+func NewCachedProvider(provider ISecretProvider, ttl time.Duration) *CachedProvider {
+	return &CachedProvider{
+		Provider: provider,
+		ttl:      ttl,
+		secrets:  map[string]cachedSecret{},
+		now:      time.Now,
+	}
+}
+
+func (p *CachedProvider) Secret(ctx context.Context, name string) (string, error) {
+	p.mutex.RLock()
+	cached, ok := p.secrets[name]
+	p.mutex.RUnlock()
+
+	if ok && p.now().Sub(cached.fetchedAt) < p.ttl {
+		return cached.value, nil
+	}
+
+	value, err := p.fetch(ctx, name)
+	if err != nil && ok {
+		log.Error(ctx, fmt.Sprintf("error refreshing secret %v, using the cached value: %v", name, err))
+		return cached.value, nil
+	}
+	return value, err
+}
+
+// Refresh relee todos los secretos pedidos hasta ahora; los que fallan conservan su valor anterior.
+func (p *CachedProvider) Refresh(ctx context.Context) error {
+	p.mutex.RLock()
+	names := make([]string, 0, len(p.secrets))
+	for name := range p.secrets {
+		names = append(names, name)
+	}
+	p.mutex.RUnlock()
+
+	var errs []error
+	for _, name := range names {
+		if _, err := p.fetch(ctx, name); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Run ejecuta Refresh cada interval hasta que se cancele ctx.
+func (p *CachedProvider) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := p.Refresh(ctx); err != nil {
+				log.Error(ctx, "error refreshing secrets: ", err)
+			}
+		}
+	}
+}
+
+func (p *CachedProvider) fetch(ctx context.Context, name string) (string, error) {
+	value, err := p.Provider.Secret(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	p.mutex.Lock()
+	p.secrets[name] = cachedSecret{value: value, fetchedAt: p.now()}
+	p.mutex.Unlock()
+	return value, nil
+}
@@ -0,0 +1,61 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ErrSecretNotFound indica que el proveedor no tiene el secreto pedido.
+var ErrSecretNotFound = errors.New("secret not found")
+
+// ISecretProvider devuelve el valor actual de un secreto. Los secretos pueden cambiar mientras el servicio corre:
+// quien los usa debe pedirlos cada vez en lugar de guardarlos.
+type ISecretProvider interface {
+	Secret(ctx context.Context, name string) (string, error)
+}
+
+// EnvProvider lee cada secreto de la variable de entorno con su nombre.
+type EnvProvider struct {
+	lookupEnv func(string) (string, bool)
+}
+
+func NewEnvProvider() *EnvProvider {
+	return &EnvProvider{lookupEnv: os.LookupEnv}
+}
+
+func (p *EnvProvider) Secret(_ context.Context, name string) (string, error) {
+	value, ok := p.lookupEnv(name)
+	if !ok || value == "" {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return value, nil
+}
+
+// FileProvider lee cada secreto del archivo con su nombre dentro de dir, como montan los secretos Docker y
+// Kubernetes. Los saltos de línea finales no son parte del valor.
+type FileProvider struct {
+	dir string
+}
+
+func NewFileProvider(dir string) *FileProvider {
+	return &FileProvider{dir: dir}
+}
+
+func (p *FileProvider) Secret(_ context.Context, name string) (string, error) {
+	if name == "" || filepath.Base(name) != name {
+		return "", fmt.Errorf("invalid secret name %q", name)
+	}
+
+	content, err := os.ReadFile(filepath.Join(p.dir, name))
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
@@ -0,0 +1,109 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// flakyProvider devuelve el valor actual o err si está definido, y cuenta las lecturas.
+type flakyProvider struct {
+	value string
+	err   error
+	reads int
+}
+
+func (p *flakyProvider) Secret(context.Context, string) (string, error) {
+	p.reads++
+	if p.err != nil {
+		return "", p.err
+	}
+	return p.value, nil
+}
+
+func TestProviders(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("env", func(t *testing.T) {
+		provider := &EnvProvider{lookupEnv: func(key string) (string, bool) {
+			value, ok := map[string]string{"DB_PASSWORD": "secret", "EMPTY": ""}[key]
+			return value, ok
+		}}
+
+		if value, err := provider.Secret(ctx, "DB_PASSWORD"); err != nil || value != "secret" {
+			t.Errorf("unexpected secret %q: %v", value, err)
+		}
+		for _, name := range []string{"MISSING", "EMPTY"} {
+			if _, err := provider.Secret(ctx, name); !errors.Is(err, ErrSecretNotFound) {
+				t.Errorf("%s: expected ErrSecretNotFound, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("file", func(t *testing.T) {
+		dir := t.TempDir()
+		if err := os.WriteFile(filepath.Join(dir, "db_password"), []byte("secret\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		provider := NewFileProvider(dir)
+
+		if value, err := provider.Secret(ctx, "db_password"); err != nil || value != "secret" {
+			t.Errorf("unexpected secret %q: %v", value, err)
+		}
+		if _, err := provider.Secret(ctx, "missing"); !errors.Is(err, ErrSecretNotFound) {
+			t.Errorf("expected ErrSecretNotFound, got %v", err)
+		}
+		if _, err := provider.Secret(ctx, "../db_password"); err == nil {
+			t.Errorf("expected names outside the directory to be rejected")
+		}
+	})
+}
+
+func TestCachedProvider(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	source := &flakyProvider{value: "v1"}
+	provider := NewCachedProvider(source, time.Minute)
+	provider.now = func() time.Time { return now }
+
+	t.Run("serves the cached value until the ttl expires", func(t *testing.T) {
+		_, _ = provider.Secret(ctx, "db_password")
+		source.value = "v2"
+
+		if value, _ := provider.Secret(ctx, "db_password"); value != "v1" || source.reads != 1 {
+			t.Errorf("expected the cached value, got %q after %d reads", value, source.reads)
+		}
+		now = now.Add(time.Minute)
+		if value, _ := provider.Secret(ctx, "db_password"); value != "v2" {
+			t.Errorf("expected the rotated value, got %q", value)
+		}
+	})
+
+	t.Run("refresh picks up rotations", func(t *testing.T) {
+		source.value = "v3"
+		if err := provider.Refresh(ctx); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if value, _ := provider.Secret(ctx, "db_password"); value != "v3" {
+			t.Errorf("expected the refreshed value, got %q", value)
+		}
+	})
+
+	t.Run("keeps the last value when the provider fails", func(t *testing.T) {
+		source.err = errors.New("vault is sealed")
+		if err := provider.Refresh(ctx); err == nil {
+			t.Errorf("expected the refresh error")
+		}
+
+		now = now.Add(time.Hour)
+		if value, err := provider.Secret(ctx, "db_password"); err != nil || value != "v3" {
+			t.Errorf("expected the stale value, got %q: %v", value, err)
+		}
+		if _, err := provider.Secret(ctx, "never_read"); err == nil {
+			t.Errorf("expected an error for secrets without a cached value")
+		}
+	})
+}
@@ -0,0 +1,70 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/rusty"
+)
+
+// defaultVaultField es el campo del secreto que se usa cuando el nombre no indica otro.
+const defaultVaultField = "value"
+
+// VaultProvider lee secretos del motor KV v2 de Vault: GET {baseURL}/v1/{mount}/data/{path} con el token en
+// X-Vault-Token. El nombre del secreto es "path#campo"; sin campo se usa "value".
+type VaultProvider struct {
+	RustyClient rusty.IRustyClient
+	baseURL     string
+	token       string
+	mount       string
+}
+
+func NewVaultProvider(rustyClient rusty.IRustyClient, baseURL, token, mount string) *VaultProvider {
+	return &VaultProvider{
+		RustyClient: rustyClient,
+		baseURL:     strings.TrimSuffix(baseURL, "/"),
+		token:       token,
+		mount:       strings.Trim(mount, "/"),
+	}
+}
+
+type vaultSecretResponse struct {
+	Data struct {
+		Data map[string]interface{} `json:"data"`
+	} `json:"data"`
+}
+
+func (p *VaultProvider) Secret(ctx context.Context, name string) (string, error) {
+	path, field, found := strings.Cut(name, "#")
+	if !found {
+		field = defaultVaultField
+	}
+
+	url := fmt.Sprintf("%s/v1/%s/data/%s", p.baseURL, p.mount, strings.Trim(path, "/"))
+	response := p.RustyClient.Get(ctx, url, map[string]string{
+		"Accept":        "application/json",
+		"X-Vault-Token": p.token,
+	}, nil, []string{"secret:" + path})
+
+	switch {
+	case response.StatusCode == http.StatusNotFound:
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	case response.Error != nil:
+		return "", response.Error
+	case response.StatusCode < 200 || response.StatusCode > 299:
+		return "", fmt.Errorf("vault answered %d", response.StatusCode)
+	}
+
+	var secret vaultSecretResponse
+	if err := json.Unmarshal(response.Body, &secret); err != nil {
+		return "", fmt.Errorf("invalid vault response: %w", err)
+	}
+	value, ok := secret.Data.Data[field].(string)
+	if !ok || value == "" {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	return value, nil
+}
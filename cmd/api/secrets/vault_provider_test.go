@@ -0,0 +1,63 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/rusty/rustytest"
+)
+
+// newFakeVault sirve los secretos de KV v2 montados en "secret" y exige el token "root-token", como Vault.
+func newFakeVault(t *testing.T, secrets map[string]map[string]interface{}) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root-token" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		data, ok := secrets[strings.TrimPrefix(r.URL.Path, "/v1/secret/data/")]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{"data": map[string]interface{}{"data": data}})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestVaultProvider(t *testing.T) {
+	ctx := context.Background()
+	vault := newFakeVault(t, map[string]map[string]interface{}{
+		"auth/database": {"value": "db-secret", "username": "auth"},
+	})
+	provider := NewVaultProvider(rustytest.Client{}, vault.URL+"/", "root-token", "/secret/")
+
+	t.Run("reads the default field and named fields", func(t *testing.T) {
+		if value, err := provider.Secret(ctx, "auth/database"); err != nil || value != "db-secret" {
+			t.Errorf("unexpected secret %q: %v", value, err)
+		}
+		if value, err := provider.Secret(ctx, "auth/database#username"); err != nil || value != "auth" {
+			t.Errorf("unexpected secret %q: %v", value, err)
+		}
+	})
+
+	t.Run("missing secrets and fields", func(t *testing.T) {
+		for _, name := range []string{"auth/missing", "auth/database#password"} {
+			if _, err := provider.Secret(ctx, name); !errors.Is(err, ErrSecretNotFound) {
+				t.Errorf("%s: expected ErrSecretNotFound, got %v", name, err)
+			}
+		}
+	})
+
+	t.Run("rejected token", func(t *testing.T) {
+		denied := NewVaultProvider(rustytest.Client{}, vault.URL, "wrong-token", "secret")
+		if _, err := denied.Secret(ctx, "auth/database"); err == nil || errors.Is(err, ErrSecretNotFound) {
+			t.Errorf("expected a provider error, got %v", err)
+		}
+	})
+}
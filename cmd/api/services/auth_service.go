@@ -0,0 +1,265 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+// fallbackDummyPasswordHash se usa si no se pudo calcular el hash de relleno con el algoritmo configurado.
+const fallbackDummyPasswordHash = "$2a$10$u17uxBJa3H4TUH3vivJT4OuN3BZo6LSMKGwrQHBkGFmkL.3MMr59e"
+
+type IAuthService interface {
+	Login(ctx context.Context, request dto.LoginRequest) (*dto.LoginResponse, error)
+	LoginMFA(ctx context.Context, request dto.MFALoginRequest) (*dto.TokenResponse, error)
+	LoginWebAuthn(ctx context.Context, request dto.WebAuthnLoginRequest) (*dto.TokenResponse, error)
+	LoginPasswordless(ctx context.Context, request dto.PasswordlessLoginRequest) (*dto.LoginResponse, error)
+	LoginFederated(ctx context.Context, request dto.FederatedCallbackRequest) (*dto.LoginResponse, error)
+	Refresh(ctx context.Context, request dto.RefreshRequest) (*dto.TokenResponse, error)
+}
+
+type AuthService struct {
+	UserRepository        repositories.IUserRepository
+	TokenService          ITokenService
+	RefreshTokenService   IRefreshTokenService
+	MFAService            IMFAService
+	VerificationService   IVerificationService
+	WebAuthnService       IWebAuthnService
+	PasswordlessService   IPasswordlessService
+	FederatedLoginService IFederatedLoginService
+	LockoutService        ILockoutService
+	PasswordHasher        IPasswordHasher
+	// dummyPasswordHash se compara cuando el email no existe para que la respuesta tarde lo mismo que con un
+	// usuario real; por eso se calcula con el algoritmo configurado.
+	dummyPasswordHash string
+}
+
+func NewAuthService(
+	userRepository repositories.IUserRepository,
+	tokenService ITokenService,
+	refreshTokenService IRefreshTokenService,
+	mfaService IMFAService,
+	verificationService IVerificationService,
+	webAuthnService IWebAuthnService,
+	passwordlessService IPasswordlessService,
+	federatedLoginService IFederatedLoginService,
+	lockoutService ILockoutService,
+	passwordHasher IPasswordHasher,
+) *AuthService {
+	dummyPasswordHash, err := passwordHasher.Hash("dummy-password")
+	if err != nil {
+		dummyPasswordHash = fallbackDummyPasswordHash
+	}
+
+	return &AuthService{
+		UserRepository:        userRepository,
+		TokenService:          tokenService,
+		RefreshTokenService:   refreshTokenService,
+		MFAService:            mfaService,
+		VerificationService:   verificationService,
+		WebAuthnService:       webAuthnService,
+		PasswordlessService:   passwordlessService,
+		FederatedLoginService: federatedLoginService,
+		LockoutService:        lockoutService,
+		PasswordHasher:        passwordHasher,
+		dummyPasswordHash:     dummyPasswordHash,
+	}
+}
+
+// Login devuelve un desafío MFA en lugar de tokens cuando el usuario tiene 2FA activo; el desafío se completa
+// con LoginMFA. Los intentos fallidos se cuentan por cuenta y por IP; al superar el umbral responde 429 sin
+// comprobar la contraseña.
+func (s *AuthService) Login(ctx context.Context, request dto.LoginRequest) (*dto.LoginResponse, error) {
+	email := NormalizeEmail(request.Email)
+	if err := s.LockoutService.Check(ctx, email); err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserRepository.FindByEmail(ctx, email)
+	if err != nil {
+		log.Error(ctx, "error finding user by email: ", err)
+		return nil, utils.NewInternalServerError("could not log in")
+	}
+
+	if user == nil {
+		utils.CheckPassword(s.dummyPasswordHash, request.Password)
+		s.LockoutService.RegisterFailure(ctx, email)
+		return nil, utils.NewUnauthorizedError("invalid email or password")
+	}
+	if !utils.CheckPassword(user.PasswordHash, request.Password) {
+		s.LockoutService.RegisterFailure(ctx, email)
+		return nil, utils.NewUnauthorizedError("invalid email or password")
+	}
+	s.LockoutService.Reset(ctx, email)
+	s.rehashPassword(ctx, user, request.Password)
+	if !user.EmailVerified {
+		return nil, utils.NewForbiddenError("email not verified")
+	}
+
+	return s.completeLogin(ctx, user)
+}
+
+// LoginMFA consume el desafío aunque el código sea incorrecto, así cada intento exige repetir la contraseña.
+func (s *AuthService) LoginMFA(ctx context.Context, request dto.MFALoginRequest) (*dto.TokenResponse, error) {
+	userID, err := s.VerificationService.RedeemToken(ctx, models.CodePurposeMFAChallenge, request.MFAToken)
+	if err != nil {
+		return nil, utils.NewUnauthorizedError("invalid or expired mfa token")
+	}
+
+	if err := s.MFAService.Verify(ctx, userID, request.MFACodeRequest); err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return nil, utils.NewInternalServerError("could not log in")
+	}
+	if user == nil {
+		return nil, utils.NewUnauthorizedError("invalid or expired mfa token")
+	}
+
+	return s.issueTokens(ctx, user)
+}
+
+// LoginWebAuthn no pide un segundo factor: la passkey exige verificación de usuario en el autenticador.
+func (s *AuthService) LoginWebAuthn(ctx context.Context, request dto.WebAuthnLoginRequest) (*dto.TokenResponse, error) {
+	user, err := s.WebAuthnService.FinishLogin(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if !user.EmailVerified {
+		return nil, utils.NewForbiddenError("email not verified")
+	}
+
+	return s.issueTokens(ctx, user)
+}
+
+// LoginPasswordless trata el enlace o código por email como un primer factor: con 2FA activo devuelve el desafío MFA.
+func (s *AuthService) LoginPasswordless(ctx context.Context, request dto.PasswordlessLoginRequest) (*dto.LoginResponse, error) {
+	user, err := s.PasswordlessService.Redeem(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completeLogin(ctx, user)
+}
+
+// LoginFederated trata el login con un proveedor social como un primer factor: con 2FA activo devuelve el desafío MFA.
+func (s *AuthService) LoginFederated(ctx context.Context, request dto.FederatedCallbackRequest) (*dto.LoginResponse, error) {
+	user, err := s.FederatedLoginService.Authenticate(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.completeLogin(ctx, user)
+}
+
+func (s *AuthService) Refresh(ctx context.Context, request dto.RefreshRequest) (*dto.TokenResponse, error) {
+	rotated, refreshToken, err := s.RefreshTokenService.Rotate(ctx, request.RefreshToken, "")
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.UserRepository.FindByID(ctx, rotated.UserID)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return nil, utils.NewInternalServerError("could not refresh token")
+	}
+	if user == nil {
+		return nil, utils.NewUnauthorizedError("invalid refresh token")
+	}
+
+	return s.tokenResponse(ctx, user, refreshToken, rotated.FamilyID)
+}
+
+// completeLogin emite los tokens tras el primer factor, o el desafío MFA si el usuario tiene 2FA activo.
+func (s *AuthService) completeLogin(ctx context.Context, user *models.User) (*dto.LoginResponse, error) {
+	mfaEnabled, err := s.MFAService.IsEnabled(ctx, user.ID)
+	if err != nil {
+		log.Error(ctx, "error checking two-factor status: ", err)
+		return nil, utils.NewInternalServerError("could not log in")
+	}
+	if mfaEnabled {
+		return s.mfaChallenge(ctx, user)
+	}
+
+	response, err := s.issueTokens(ctx, user)
+	if err != nil {
+		return nil, err
+	}
+	return &dto.LoginResponse{TokenResponse: response}, nil
+}
+
+func (s *AuthService) mfaChallenge(ctx context.Context, user *models.User) (*dto.LoginResponse, error) {
+	ttl := time.Duration(constants.MFAChallengeExpiration) * time.Minute
+	token, err := s.VerificationService.IssueToken(ctx, user.ID, models.CodePurposeMFAChallenge, ttl)
+	if err != nil {
+		log.Error(ctx, "error issuing mfa challenge: ", err)
+		return nil, utils.NewInternalServerError("could not log in")
+	}
+
+	return &dto.LoginResponse{MFAChallengeResponse: &dto.MFAChallengeResponse{
+		MFARequired: true,
+		MFAToken:    token,
+		Methods:     []string{constants.MFAMethodTOTP, constants.MFAMethodRecoveryCode},
+	}}, nil
+}
+
+func (s *AuthService) issueTokens(ctx context.Context, user *models.User) (*dto.TokenResponse, error) {
+	sessionID, err := utils.GenerateToken(16)
+	if err != nil {
+		log.Error(ctx, "error generating refresh token family: ", err)
+		return nil, utils.NewInternalServerError("could not log in")
+	}
+	if err := s.RefreshTokenService.StartSession(ctx, user.ID, sessionID, ""); err != nil {
+		log.Error(ctx, "error starting session: ", err)
+		return nil, utils.NewInternalServerError("could not log in")
+	}
+	refreshToken, err := s.RefreshTokenService.Issue(ctx, user.ID, sessionID)
+	if err != nil {
+		log.Error(ctx, "error issuing refresh token: ", err)
+		return nil, utils.NewInternalServerError("could not log in")
+	}
+
+	return s.tokenResponse(ctx, user, refreshToken, sessionID)
+}
+
+func (s *AuthService) tokenResponse(ctx context.Context, user *models.User, refreshToken, sessionID string) (*dto.TokenResponse, error) {
+	token, claims, err := s.TokenService.IssueAccessToken(ctx, user, sessionID)
+	if err != nil {
+		log.Error(ctx, "error issuing access token: ", err)
+		return nil, utils.NewInternalServerError("could not issue access token")
+	}
+
+	return &dto.TokenResponse{
+		AccessToken:  token,
+		TokenType:    TokenTypeBearer,
+		ExpiresIn:    int64(claims.ExpiresAt.Sub(claims.IssuedAt.Time).Seconds()),
+		RefreshToken: refreshToken,
+	}, nil
+}
+
+// rehashPassword reemplaza los hashes de otro algoritmo o con parámetros viejos aprovechando que se conoce la
+// contraseña. No toca PasswordChangedAt, así que las sesiones siguen valiendo, y un fallo no impide el login.
+func (s *AuthService) rehashPassword(ctx context.Context, user *models.User, password string) {
+	if !s.PasswordHasher.NeedsRehash(user.PasswordHash) {
+		return
+	}
+
+	passwordHash, err := s.PasswordHasher.Hash(password)
+	if err != nil {
+		log.Error(ctx, "error rehashing password: ", err)
+		return
+	}
+	user.PasswordHash = passwordHash
+	if err := s.UserRepository.Update(ctx, user); err != nil {
+		log.Error(ctx, "error updating rehashed password: ", err)
+	}
+}
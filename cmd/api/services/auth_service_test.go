@@ -0,0 +1,204 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func newTestAuthService(users *fakeUserRepository, now *time.Time) *AuthService {
+	verificationService := NewVerificationService(users, newFakeVerificationCodeRepository(), fakeTransactor{}, &fakeMailer{})
+	verificationService.now = func() time.Time { return *now }
+
+	return NewAuthService(
+		users,
+		newTestTokenService(now),
+		newTestRefreshTokenService(newFakeRefreshTokenRepository(), now),
+		newTestMFAService(users, now),
+		verificationService,
+		newTestWebAuthnService(users, now),
+		newTestPasswordlessService(users, verificationService, &fakeMailer{}),
+		newTestFederatedLoginService(users, nil, now),
+		newTestLockoutService(users, now),
+		newTestPasswordHasher(),
+	)
+}
+
+func TestAuthService(t *testing.T) {
+
+	t.Run("login", func(t *testing.T) {
+		users := newFakeUserRepository()
+		hash, _ := newTestPasswordHasher().Hash("s3cret-password")
+		_ = users.Create(context.Background(), &models.User{Email: "ana@example.com", PasswordHash: hash, EmailVerified: true})
+		_ = users.Create(context.Background(), &models.User{Email: "bob@example.com", PasswordHash: hash})
+		now := time.Now()
+		service := newTestAuthService(users, &now)
+
+		testCases := []struct {
+			name           string
+			request        dto.LoginRequest
+			expectedStatus int
+		}{
+			{
+				name:           "valid credentials",
+				request:        dto.LoginRequest{Email: "Ana@example.com", Password: "s3cret-password"},
+				expectedStatus: http.StatusOK,
+			},
+			{
+				name:           "wrong password",
+				request:        dto.LoginRequest{Email: "ana@example.com", Password: "wrong-password"},
+				expectedStatus: http.StatusUnauthorized,
+			},
+			{
+				name:           "unknown email",
+				request:        dto.LoginRequest{Email: "nobody@example.com", Password: "s3cret-password"},
+				expectedStatus: http.StatusUnauthorized,
+			},
+			{
+				name:           "email not verified",
+				request:        dto.LoginRequest{Email: "bob@example.com", Password: "s3cret-password"},
+				expectedStatus: http.StatusForbidden,
+			},
+		}
+
+		for _, tc := range testCases {
+			response, err := service.Login(context.Background(), tc.request)
+			if tc.expectedStatus == http.StatusOK {
+				if err != nil || response.AccessToken == "" || response.RefreshToken == "" || response.ExpiresIn != 3600 {
+					t.Errorf("%s: unexpected result: %+v, %v", tc.name, response, err)
+				}
+				continue
+			}
+
+			var apiErr *utils.APIError
+			if !errors.As(err, &apiErr) || apiErr.Status != tc.expectedStatus {
+				t.Errorf("%s: unexpected error: got %v, want status %d", tc.name, err, tc.expectedStatus)
+			}
+		}
+	})
+
+	t.Run("login rehashes imported passwords", func(t *testing.T) {
+		users := newFakeUserRepository()
+		legacy, _ := utils.HashBcrypt("s3cret-password", bcrypt.MinCost)
+		user := &models.User{Email: "ana@example.com", PasswordHash: legacy, EmailVerified: true}
+		_ = users.Create(context.Background(), user)
+		now := time.Now()
+		service := newTestAuthService(users, &now)
+
+		if _, err := service.Login(context.Background(), dto.LoginRequest{Email: "ana@example.com", Password: "wrong-password"}); err == nil {
+			t.Fatalf("expected wrong password to fail")
+		}
+		if stored, _ := users.FindByID(context.Background(), user.ID); stored.PasswordHash != legacy {
+			t.Fatalf("expected failed logins not to rehash")
+		}
+
+		if _, err := service.Login(context.Background(), dto.LoginRequest{Email: "ana@example.com", Password: "s3cret-password"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		stored, _ := users.FindByID(context.Background(), user.ID)
+		if utils.PasswordHashAlgorithm(stored.PasswordHash) != constants.PasswordHashArgon2id || service.PasswordHasher.NeedsRehash(stored.PasswordHash) {
+			t.Errorf("expected the password to be rehashed with argon2id, got %q", stored.PasswordHash)
+		}
+		if stored.PasswordChangedAt != nil {
+			t.Errorf("expected the rehash not to invalidate existing sessions")
+		}
+		if _, err := service.Login(context.Background(), dto.LoginRequest{Email: "ana@example.com", Password: "s3cret-password"}); err != nil {
+			t.Errorf("expected the rehashed password to keep working, got %v", err)
+		}
+	})
+}
+
+func TestAuthServiceRefresh(t *testing.T) {
+	users := newFakeUserRepository()
+	hash, _ := newTestPasswordHasher().Hash("s3cret-password")
+	_ = users.Create(context.Background(), &models.User{Email: "ana@example.com", PasswordHash: hash, EmailVerified: true})
+	now := time.Now()
+	service := newTestAuthService(users, &now)
+
+	login, _ := service.Login(context.Background(), dto.LoginRequest{Email: "ana@example.com", Password: "s3cret-password"})
+	refreshed, err := service.Refresh(context.Background(), dto.RefreshRequest{RefreshToken: login.RefreshToken})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refreshed.AccessToken == "" || refreshed.RefreshToken == login.RefreshToken {
+		t.Errorf("expected a new token pair: %+v", refreshed)
+	}
+}
+
+func TestAuthServiceMFA(t *testing.T) {
+	users := newFakeUserRepository()
+	hash, _ := newTestPasswordHasher().Hash("s3cret-password")
+	_ = users.Create(context.Background(), &models.User{Email: "ana@example.com", PasswordHash: hash, EmailVerified: true})
+	now := time.Unix(1_700_000_000, 0)
+	service := newTestAuthService(users, &now)
+	secret, _ := enrollTestTOTP(t, service.MFAService.(*MFAService), &now)
+	credentials := dto.LoginRequest{Email: "ana@example.com", Password: "s3cret-password"}
+
+	login, err := service.Login(context.Background(), credentials)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if login.TokenResponse != nil || login.MFAChallengeResponse == nil || !login.MFARequired || login.MFAToken == "" {
+		t.Fatalf("expected an mfa challenge instead of tokens: %+v", login)
+	}
+
+	_, err = service.LoginMFA(context.Background(), dto.MFALoginRequest{
+		MFAToken:       login.MFAToken,
+		MFACodeRequest: dto.MFACodeRequest{Code: "000000"},
+	})
+	assertAPIStatus(t, err, http.StatusUnauthorized)
+
+	code, _ := utils.TOTPCode(secret, utils.TOTPStep(now))
+	_, err = service.LoginMFA(context.Background(), dto.MFALoginRequest{
+		MFAToken:       login.MFAToken,
+		MFACodeRequest: dto.MFACodeRequest{Code: code},
+	})
+	assertAPIStatus(t, err, http.StatusUnauthorized)
+
+	login, _ = service.Login(context.Background(), credentials)
+	tokens, err := service.LoginMFA(context.Background(), dto.MFALoginRequest{
+		MFAToken:       login.MFAToken,
+		MFACodeRequest: dto.MFACodeRequest{Code: code},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tokens.AccessToken == "" || tokens.RefreshToken == "" {
+		t.Errorf("expected a token pair: %+v", tokens)
+	}
+}
+
+func TestAuthServicePasswordless(t *testing.T) {
+	users := newFakeUserRepository()
+	_ = users.Create(context.Background(), &models.User{Email: "ana@example.com", EmailVerified: true})
+	now := time.Unix(1_700_000_000, 0)
+	service := newTestAuthService(users, &now)
+	ttl := time.Duration(constants.PasswordlessExpiration) * time.Minute
+
+	token, _ := service.VerificationService.IssueToken(context.Background(), 1, models.CodePurposeMagicLink, ttl)
+	login, err := service.LoginPasswordless(context.Background(), dto.PasswordlessLoginRequest{Token: token})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if login.TokenResponse == nil || login.AccessToken == "" || login.RefreshToken == "" {
+		t.Fatalf("expected a token pair: %+v", login)
+	}
+
+	enrollTestTOTP(t, service.MFAService.(*MFAService), &now)
+	token, _ = service.VerificationService.IssueToken(context.Background(), 1, models.CodePurposeMagicLink, ttl)
+	login, err = service.LoginPasswordless(context.Background(), dto.PasswordlessLoginRequest{Token: token})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if login.TokenResponse != nil || login.MFAChallengeResponse == nil || login.MFAToken == "" {
+		t.Errorf("expected an mfa challenge instead of tokens: %+v", login)
+	}
+}
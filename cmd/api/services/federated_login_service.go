@@ -0,0 +1,430 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/rusty"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type IFederatedLoginService interface {
+	ListProviders() []string
+	Begin(ctx context.Context, provider string, userID uint) (*dto.FederatedBeginResponse, error)
+	Authenticate(ctx context.Context, request dto.FederatedCallbackRequest) (*models.User, error)
+	Link(ctx context.Context, userID uint, request dto.FederatedCallbackRequest) (*dto.ExternalIdentityResponse, error)
+	ListIdentities(ctx context.Context, userID uint) ([]dto.ExternalIdentityResponse, error)
+	Unlink(ctx context.Context, userID uint, provider string) error
+}
+
+// FederatedLoginService hace el flujo authorization code contra los proveedores sociales a través del cliente
+// Rusty. El login con un proveedor equivale a un primer factor: el 2FA del usuario se sigue exigiendo.
+type FederatedLoginService struct {
+	UserRepository                repositories.IUserRepository
+	Transactor                    repositories.ITransactor
+	ExternalIdentityRepository    repositories.IExternalIdentityRepository
+	FederatedLoginStateRepository repositories.IFederatedLoginStateRepository
+	RoleService                   IRoleService
+	RustyClient                   rusty.IRustyClient
+	IdentityProviders             map[string]config.IdentityProviderConfig
+	StateTTL                      time.Duration
+	now                           func() time.Time
+}
+
+func NewFederatedLoginService(
+	userRepository repositories.IUserRepository,
+	transactor repositories.ITransactor,
+	externalIdentityRepository repositories.IExternalIdentityRepository,
+	federatedLoginStateRepository repositories.IFederatedLoginStateRepository,
+	roleService IRoleService,
+	rustyClient rusty.IRustyClient,
+	identityProviders map[string]config.IdentityProviderConfig,
+) *FederatedLoginService {
+	return &FederatedLoginService{
+		UserRepository:                userRepository,
+		Transactor:                    transactor,
+		ExternalIdentityRepository:    externalIdentityRepository,
+		FederatedLoginStateRepository: federatedLoginStateRepository,
+		RoleService:                   roleService,
+		RustyClient:                   rustyClient,
+		IdentityProviders:             identityProviders,
+		StateTTL:                      time.Duration(constants.FederatedStateExpiration) * time.Minute,
+		now:                           time.Now,
+	}
+}
+
+// externalProfile son los datos del usuario que devuelve el userinfo del proveedor.
+type externalProfile struct {
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+}
+
+// ListProviders devuelve los proveedores configurados, ordenados por nombre.
+func (s *FederatedLoginService) ListProviders() []string {
+	providers := make([]string, 0, len(s.IdentityProviders))
+	for name, provider := range s.IdentityProviders {
+		if provider.ClientID != "" {
+			providers = append(providers, name)
+		}
+	}
+	slices.Sort(providers)
+	return providers
+}
+
+// Begin arma la URL de autorización del proveedor con un state de un solo uso y un desafío PKCE. Con userID
+// distinto de cero el state solo sirve para vincular el proveedor a esa cuenta.
+func (s *FederatedLoginService) Begin(ctx context.Context, name string, userID uint) (*dto.FederatedBeginResponse, error) {
+	provider, err := s.provider(name)
+	if err != nil {
+		return nil, err
+	}
+
+	authorizationURL, err := url.Parse(provider.AuthorizationURL)
+	if err != nil {
+		log.Error(ctx, "error parsing identity provider authorization url: ", err)
+		return nil, utils.NewInternalServerError("could not begin login")
+	}
+	state, err := utils.GenerateToken(constants.VerificationTokenSize)
+	if err != nil {
+		log.Error(ctx, "error generating federated login state: ", err)
+		return nil, utils.NewInternalServerError("could not begin login")
+	}
+	codeVerifier, err := utils.GenerateToken(constants.VerificationTokenSize)
+	if err != nil {
+		log.Error(ctx, "error generating code verifier: ", err)
+		return nil, utils.NewInternalServerError("could not begin login")
+	}
+
+	now := s.now()
+	if err := s.FederatedLoginStateRepository.DeleteExpired(ctx, now); err != nil {
+		log.Error(ctx, "error deleting expired federated login states: ", err)
+	}
+	stored := &models.FederatedLoginState{
+		StateHash:    utils.HashToken(state),
+		Provider:     name,
+		CodeVerifier: codeVerifier,
+		ExpiresAt:    now.Add(s.StateTTL),
+	}
+	if userID != 0 {
+		stored.UserID = &userID
+	}
+	if err := s.FederatedLoginStateRepository.Create(ctx, stored); err != nil {
+		log.Error(ctx, "error saving federated login state: ", err)
+		return nil, utils.NewInternalServerError("could not begin login")
+	}
+
+	query := authorizationURL.Query()
+	query.Set("response_type", constants.ResponseTypeCode)
+	query.Set("client_id", provider.ClientID)
+	query.Set("redirect_uri", provider.RedirectURL)
+	query.Set("scope", strings.Join(provider.Scopes, " "))
+	query.Set("state", state)
+	query.Set("code_challenge", utils.S256CodeChallenge(codeVerifier))
+	query.Set("code_challenge_method", constants.CodeChallengeMethodS256)
+	authorizationURL.RawQuery = query.Encode()
+
+	return &dto.FederatedBeginResponse{AuthorizationURL: authorizationURL.String(), State: state}, nil
+}
+
+// Authenticate devuelve el usuario local de la identidad externa. Si la identidad es nueva se vincula a la
+// cuenta con el mismo email solo cuando el proveedor lo permite y ambos lados tienen el email verificado; si no
+// hay cuenta con ese email se crea una.
+func (s *FederatedLoginService) Authenticate(ctx context.Context, request dto.FederatedCallbackRequest) (*models.User, error) {
+	state, provider, err := s.consumeState(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if state.UserID != nil {
+		return nil, utils.NewBadRequestError("invalid or expired state")
+	}
+
+	profile, err := s.fetchProfile(ctx, request, provider, state.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	identity, err := s.ExternalIdentityRepository.FindByProviderSubject(ctx, request.Provider, profile.Subject)
+	if err != nil {
+		log.Error(ctx, "error finding external identity: ", err)
+		return nil, utils.NewInternalServerError("could not log in")
+	}
+	if identity == nil {
+		return s.linkOrSignup(ctx, request.Provider, provider, profile)
+	}
+
+	user, err := s.UserRepository.FindByID(ctx, identity.UserID)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return nil, utils.NewInternalServerError("could not log in")
+	}
+	if user == nil {
+		return nil, utils.NewUnauthorizedError("could not log in with identity provider")
+	}
+	if err := s.ExternalIdentityRepository.UpdateLastLogin(ctx, identity.ID, s.now()); err != nil {
+		log.Error(ctx, "error updating external identity: ", err)
+	}
+	return user, nil
+}
+
+// Link vincula el proveedor a la cuenta del usuario autenticado sin mirar el email: haber completado el login
+// en el proveedor prueba que controla esa cuenta externa.
+func (s *FederatedLoginService) Link(ctx context.Context, userID uint, request dto.FederatedCallbackRequest) (*dto.ExternalIdentityResponse, error) {
+	state, provider, err := s.consumeState(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	if state.UserID == nil || *state.UserID != userID {
+		return nil, utils.NewBadRequestError("invalid or expired state")
+	}
+
+	profile, err := s.fetchProfile(ctx, request, provider, state.CodeVerifier)
+	if err != nil {
+		return nil, err
+	}
+
+	identities, err := s.ExternalIdentityRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error listing external identities: ", err)
+		return nil, utils.NewInternalServerError("could not link identity provider")
+	}
+	for _, identity := range identities {
+		if identity.Provider == request.Provider {
+			return nil, utils.NewConflictError("identity provider already linked")
+		}
+	}
+
+	identity, err := s.createIdentity(ctx, userID, request.Provider, profile)
+	if err != nil {
+		return nil, err
+	}
+	response := dto.NewExternalIdentityResponse(identity)
+	return &response, nil
+}
+
+func (s *FederatedLoginService) ListIdentities(ctx context.Context, userID uint) ([]dto.ExternalIdentityResponse, error) {
+	identities, err := s.ExternalIdentityRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error listing external identities: ", err)
+		return nil, utils.NewInternalServerError("could not list identity providers")
+	}
+
+	response := make([]dto.ExternalIdentityResponse, 0, len(identities))
+	for i := range identities {
+		response = append(response, dto.NewExternalIdentityResponse(&identities[i]))
+	}
+	return response, nil
+}
+
+// Unlink no deja al usuario sin acceso: siempre puede entrar con un enlace por email o restablecer la contraseña.
+func (s *FederatedLoginService) Unlink(ctx context.Context, userID uint, provider string) error {
+	err := s.ExternalIdentityRepository.Delete(ctx, userID, provider)
+	if errors.Is(err, repositories.ErrNotFound) {
+		return utils.NewNotFoundError("identity provider not linked")
+	}
+	if err != nil {
+		log.Error(ctx, "error deleting external identity: ", err)
+		return utils.NewInternalServerError("could not unlink identity provider")
+	}
+	return nil
+}
+
+// linkOrSignup resuelve el primer login con una identidad externa. Una cuenta local sin email verificado no se
+// vincula nunca: podría haberla creado otra persona para quedarse con la cuenta del dueño real del email.
+func (s *FederatedLoginService) linkOrSignup(ctx context.Context, name string, provider config.IdentityProviderConfig, profile *externalProfile) (*models.User, error) {
+	email := NormalizeEmail(profile.Email)
+	if email == "" || !(profile.EmailVerified || provider.TrustEmail) {
+		return nil, utils.NewForbiddenError("identity provider did not return a verified email")
+	}
+
+	user, err := s.UserRepository.FindByEmail(ctx, email)
+	if err != nil {
+		log.Error(ctx, "error finding user by email: ", err)
+		return nil, utils.NewInternalServerError("could not log in")
+	}
+	if user != nil && (!provider.LinkVerifiedEmail || !user.EmailVerified) {
+		return nil, utils.NewConflictError("email already registered, log in and link the identity provider from your account")
+	}
+	if user == nil {
+		if user, err = s.signup(ctx, provider, email, profile.Name); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := s.createIdentity(ctx, user.ID, name, profile); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// signup crea la cuenta sin contraseña y con el email ya verificado por el proveedor. El rol por defecto se
+// asigna en la misma transacción: no hay alta sin rol.
+func (s *FederatedLoginService) signup(ctx context.Context, provider config.IdentityProviderConfig, email, name string) (*models.User, error) {
+	if !provider.AllowSignup {
+		return nil, utils.NewForbiddenError("signup with this identity provider is disabled")
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		name, _, _ = strings.Cut(email, "@")
+	}
+	now := s.now()
+	user := &models.User{
+		Name:            name,
+		Email:           email,
+		EmailVerified:   true,
+		EmailVerifiedAt: &now,
+	}
+	err := s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.UserRepository.Create(ctx, user); err != nil {
+			return err
+		}
+		return s.RoleService.AssignRole(ctx, user.ID, constants.DefaultSignupRole)
+	})
+	if errors.Is(err, repositories.ErrDuplicatedEmail) {
+		return nil, utils.NewConflictError("email already registered")
+	}
+	if err != nil {
+		log.Error(ctx, "error creating user: ", err)
+		return nil, utils.NewInternalServerError("could not create user")
+	}
+	return user, nil
+}
+
+func (s *FederatedLoginService) createIdentity(ctx context.Context, userID uint, provider string, profile *externalProfile) (*models.ExternalIdentity, error) {
+	now := s.now()
+	identity := &models.ExternalIdentity{
+		UserID:      userID,
+		Provider:    provider,
+		Subject:     profile.Subject,
+		Email:       NormalizeEmail(profile.Email),
+		LastLoginAt: &now,
+		CreatedAt:   now,
+	}
+	if err := s.ExternalIdentityRepository.Create(ctx, identity); err != nil {
+		if errors.Is(err, repositories.ErrDuplicatedIdentity) {
+			return nil, utils.NewConflictError("identity already linked to another account")
+		}
+		log.Error(ctx, "error creating external identity: ", err)
+		return nil, utils.NewInternalServerError("could not link identity provider")
+	}
+	return identity, nil
+}
+
+// provider devuelve 404 para proveedores desconocidos o sin credenciales en este entorno.
+func (s *FederatedLoginService) provider(name string) (config.IdentityProviderConfig, error) {
+	provider, ok := s.IdentityProviders[name]
+	if !ok || provider.ClientID == "" {
+		return config.IdentityProviderConfig{}, utils.NewNotFoundError("identity provider not found")
+	}
+	return provider, nil
+}
+
+func (s *FederatedLoginService) consumeState(ctx context.Context, request dto.FederatedCallbackRequest) (*models.FederatedLoginState, config.IdentityProviderConfig, error) {
+	provider, err := s.provider(request.Provider)
+	if err != nil {
+		return nil, provider, err
+	}
+
+	state, err := s.FederatedLoginStateRepository.Consume(ctx, request.Provider, utils.HashToken(request.State), s.now())
+	if err != nil {
+		log.Error(ctx, "error finding federated login state: ", err)
+		return nil, provider, utils.NewInternalServerError("could not log in")
+	}
+	if state == nil {
+		return nil, provider, utils.NewBadRequestError("invalid or expired state")
+	}
+	return state, provider, nil
+}
+
+// fetchProfile canjea el code en el token endpoint del proveedor, con el formulario que exige RFC 6749 4.1.3, y
+// consulta su userinfo. Un code rechazado responde 401; cualquier otra falla del proveedor, 502.
+func (s *FederatedLoginService) fetchProfile(ctx context.Context, request dto.FederatedCallbackRequest, provider config.IdentityProviderConfig, codeVerifier string) (*externalProfile, error) {
+	tags := []string{fmt.Sprintf("identity_provider:%v", request.Provider)}
+
+	tokenResponse := s.RustyClient.PostForm(ctx, provider.TokenURL, map[string]string{"Accept": "application/json"}, url.Values{
+		"grant_type":    {constants.GrantTypeAuthorizationCode},
+		"code":          {request.Code},
+		"redirect_uri":  {provider.RedirectURL},
+		"client_id":     {provider.ClientID},
+		"client_secret": {provider.ClientSecret},
+		"code_verifier": {codeVerifier},
+	}, tags)
+	// RFC 6749 5.2: un code inválido, vencido o ya usado responde 400 invalid_grant.
+	if tokenResponse.StatusCode == http.StatusBadRequest {
+		return nil, utils.NewUnauthorizedError("could not log in with identity provider")
+	}
+	if tokenResponse.Error != nil || tokenResponse.StatusCode != http.StatusOK {
+		log.Error(ctx, "error exchanging identity provider code: ", tokenResponse.StatusCode, tokenResponse.Error)
+		return nil, utils.NewBadGatewayError("identity provider unavailable")
+	}
+
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.Unmarshal(tokenResponse.Body, &token); err != nil || token.AccessToken == "" {
+		log.Error(ctx, "error decoding identity provider token response: ", err)
+		return nil, utils.NewBadGatewayError("identity provider unavailable")
+	}
+
+	userInfoResponse := s.RustyClient.Get(ctx, provider.UserInfoURL, map[string]string{
+		"Accept":        "application/json",
+		"Authorization": "Bearer " + token.AccessToken,
+	}, provider.UserInfoParams, tags)
+	if userInfoResponse.Error != nil || userInfoResponse.StatusCode != http.StatusOK {
+		log.Error(ctx, "error getting identity provider userinfo: ", userInfoResponse.StatusCode, userInfoResponse.Error)
+		return nil, utils.NewBadGatewayError("identity provider unavailable")
+	}
+
+	var claims map[string]interface{}
+	decoder := json.NewDecoder(bytes.NewReader(userInfoResponse.Body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&claims); err != nil {
+		log.Error(ctx, "error decoding identity provider userinfo: ", err)
+		return nil, utils.NewBadGatewayError("identity provider unavailable")
+	}
+
+	profile := &externalProfile{
+		Subject: claimString(claims, provider.SubjectClaim),
+		Email:   claimString(claims, "email"),
+		Name:    claimString(claims, "name"),
+	}
+	// Algunos proveedores publican email_verified como texto.
+	switch verified := claims["email_verified"].(type) {
+	case bool:
+		profile.EmailVerified = verified
+	case string:
+		profile.EmailVerified = verified == "true"
+	}
+	if profile.Subject == "" {
+		log.Error(ctx, "identity provider userinfo without subject: ", request.Provider)
+		return nil, utils.NewBadGatewayError("identity provider unavailable")
+	}
+	return profile, nil
+}
+
+// claimString devuelve el claim como texto; los identificadores numéricos se conservan tal cual llegaron.
+func claimString(claims map[string]interface{}, name string) string {
+	switch value := claims[name].(type) {
+	case string:
+		return value
+	case json.Number:
+		return value.String()
+	}
+	return ""
+}
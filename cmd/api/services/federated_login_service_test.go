@@ -0,0 +1,459 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/rusty"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/rusty/rustytest"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+const (
+	testIdPClientID     = "tareaya-test"
+	testIdPClientSecret = "idp-secret"
+	testIdPRedirectURL  = "https://app.tareaya.test/auth/callback"
+)
+
+// testIdentityProvider es un proveedor OAuth mínimo: canjea codes verificando el PKCE, solo con el formulario
+// de RFC 6749 4.1.3, y sirve el userinfo del perfil asociado a cada access token.
+type testIdentityProvider struct {
+	server   *httptest.Server
+	mutex    sync.Mutex
+	codes    map[string]testAuthorization
+	profiles map[string]map[string]interface{}
+}
+
+type testAuthorization struct {
+	challenge string
+	profile   map[string]interface{}
+}
+
+func newTestIdentityProvider(t *testing.T) *testIdentityProvider {
+	provider := &testIdentityProvider{
+		codes:    map[string]testAuthorization{},
+		profiles: map[string]map[string]interface{}{},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("POST /token", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" || r.ParseForm() != nil {
+			http.Error(w, `{"error":"invalid_request"}`, http.StatusBadRequest)
+			return
+		}
+		request := r.PostForm
+		if request.Get("client_id") != testIdPClientID || request.Get("client_secret") != testIdPClientSecret {
+			http.Error(w, `{"error":"invalid_client"}`, http.StatusUnauthorized)
+			return
+		}
+
+		provider.mutex.Lock()
+		defer provider.mutex.Unlock()
+		authorization, ok := provider.codes[request.Get("code")]
+		delete(provider.codes, request.Get("code"))
+		if !ok || request.Get("grant_type") != constants.GrantTypeAuthorizationCode || request.Get("redirect_uri") != testIdPRedirectURL ||
+			!utils.VerifyCodeChallenge(request.Get("code_verifier"), authorization.challenge) {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+			return
+		}
+
+		accessToken, _ := utils.GenerateToken(16)
+		provider.profiles[accessToken] = authorization.profile
+		_ = json.NewEncoder(w).Encode(map[string]string{"access_token": accessToken, "token_type": "Bearer"})
+	})
+	mux.HandleFunc("GET /userinfo", func(w http.ResponseWriter, r *http.Request) {
+		provider.mutex.Lock()
+		defer provider.mutex.Unlock()
+		profile, ok := provider.profiles[strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")]
+		if !ok {
+			http.Error(w, `{"error":"invalid_token"}`, http.StatusUnauthorized)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(profile)
+	})
+
+	provider.server = httptest.NewServer(mux)
+	t.Cleanup(provider.server.Close)
+	return provider
+}
+
+func (p *testIdentityProvider) config() config.IdentityProviderConfig {
+	return config.IdentityProviderConfig{
+		ClientID:          testIdPClientID,
+		ClientSecret:      testIdPClientSecret,
+		AuthorizationURL:  p.server.URL + "/authorize?prompt=select_account",
+		TokenURL:          p.server.URL + "/token",
+		UserInfoURL:       p.server.URL + "/userinfo",
+		RedirectURL:       testIdPRedirectURL,
+		Scopes:            []string{"openid", "email", "profile"},
+		SubjectClaim:      "sub",
+		LinkVerifiedEmail: true,
+		AllowSignup:       true,
+	}
+}
+
+// authorize hace de usuario que acepta en el proveedor: valida la URL de autorización y devuelve el code que el
+// proveedor mandaría al callback.
+func (p *testIdentityProvider) authorize(t *testing.T, begin *dto.FederatedBeginResponse, profile map[string]interface{}) string {
+	t.Helper()
+
+	authorizationURL, err := url.Parse(begin.AuthorizationURL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	query := authorizationURL.Query()
+	if query.Get("state") != begin.State || query.Get("client_id") != testIdPClientID ||
+		query.Get("redirect_uri") != testIdPRedirectURL || query.Get("code_challenge_method") != constants.CodeChallengeMethodS256 ||
+		query.Get("scope") != "openid email profile" || query.Get("prompt") != "select_account" {
+		t.Fatalf("unexpected authorization url: %s", begin.AuthorizationURL)
+	}
+
+	code, _ := utils.GenerateToken(16)
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	p.codes[code] = testAuthorization{challenge: query.Get("code_challenge"), profile: profile}
+	return code
+}
+
+func newFederatedTestSetup(t *testing.T, users *fakeUserRepository, now *time.Time) (*FederatedLoginService, *testIdentityProvider) {
+	provider := newTestIdentityProvider(t)
+	service := newTestFederatedLoginService(users, rustytest.Client{}, now)
+	service.IdentityProviders["google"] = provider.config()
+	return service, provider
+}
+
+// federatedCallback hace el begin y el paso por el proveedor, y devuelve lo que el frontend reenvía al servicio.
+func federatedCallback(t *testing.T, service *FederatedLoginService, provider *testIdentityProvider, userID uint, profile map[string]interface{}) dto.FederatedCallbackRequest {
+	t.Helper()
+
+	begin, err := service.Begin(context.Background(), "google", userID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return dto.FederatedCallbackRequest{Provider: "google", Code: provider.authorize(t, begin, profile), State: begin.State}
+}
+
+func TestFederatedLoginService(t *testing.T) {
+	profile := func(subject interface{}, email string, verified interface{}) map[string]interface{} {
+		return map[string]interface{}{"sub": subject, "email": email, "email_verified": verified, "name": "Ana Pérez"}
+	}
+
+	t.Run("signup and later logins", func(t *testing.T) {
+		users := newFakeUserRepository()
+		now := time.Now()
+		service, provider := newFederatedTestSetup(t, users, &now)
+
+		user, err := service.Authenticate(context.Background(), federatedCallback(t, service, provider, 0, profile("g-1", "Ana@Example.com", true)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.Email != "ana@example.com" || user.Name != "Ana Pérez" || !user.EmailVerified || user.PasswordHash != "" {
+			t.Errorf("unexpected user: %+v", user)
+		}
+		authorization, _ := service.RoleService.UserAuthorization(context.Background(), user.ID)
+		if len(authorization.Roles) != 1 || authorization.Roles[0] != constants.DefaultSignupRole {
+			t.Errorf("expected the default role, got %+v", authorization.Roles)
+		}
+
+		again, err := service.Authenticate(context.Background(), federatedCallback(t, service, provider, 0, profile("g-1", "renamed@example.com", true)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if again.ID != user.ID || len(users.users) != 1 {
+			t.Errorf("expected the same account, got %+v", again)
+		}
+	})
+
+	t.Run("account linking rules", func(t *testing.T) {
+		testCases := []struct {
+			name              string
+			localVerified     bool
+			providerVerified  interface{}
+			linkVerifiedEmail bool
+			trustEmail        bool
+			expectedStatus    int
+		}{
+			{name: "links verified emails", localVerified: true, providerVerified: true, linkVerifiedEmail: true, expectedStatus: http.StatusOK},
+			{name: "accepts email_verified as text", localVerified: true, providerVerified: "true", linkVerifiedEmail: true, expectedStatus: http.StatusOK},
+			{name: "trusted provider", localVerified: true, providerVerified: nil, linkVerifiedEmail: true, trustEmail: true, expectedStatus: http.StatusOK},
+			{name: "unverified local account", localVerified: false, providerVerified: true, linkVerifiedEmail: true, expectedStatus: http.StatusConflict},
+			{name: "linking disabled", localVerified: true, providerVerified: true, expectedStatus: http.StatusConflict},
+			{name: "unverified provider email", localVerified: true, providerVerified: false, linkVerifiedEmail: true, expectedStatus: http.StatusForbidden},
+		}
+
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				users := newFakeUserRepository()
+				_ = users.Create(context.Background(), &models.User{Email: "ana@example.com", EmailVerified: tc.localVerified})
+				now := time.Now()
+				service, provider := newFederatedTestSetup(t, users, &now)
+				idp := provider.config()
+				idp.LinkVerifiedEmail = tc.linkVerifiedEmail
+				idp.TrustEmail = tc.trustEmail
+				service.IdentityProviders["google"] = idp
+
+				user, err := service.Authenticate(context.Background(), federatedCallback(t, service, provider, 0, profile("g-1", "ana@example.com", tc.providerVerified)))
+				if tc.expectedStatus != http.StatusOK {
+					assertAPIStatus(t, err, tc.expectedStatus)
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if user.ID != 1 || len(users.users) != 1 {
+					t.Errorf("expected the existing account, got %+v", user)
+				}
+			})
+		}
+	})
+
+	t.Run("signup disabled", func(t *testing.T) {
+		now := time.Now()
+		service, provider := newFederatedTestSetup(t, newFakeUserRepository(), &now)
+		idp := provider.config()
+		idp.AllowSignup = false
+		service.IdentityProviders["google"] = idp
+
+		_, err := service.Authenticate(context.Background(), federatedCallback(t, service, provider, 0, profile("g-1", "ana@example.com", true)))
+		assertAPIStatus(t, err, http.StatusForbidden)
+	})
+
+	t.Run("signup fails when the default role cannot be assigned", func(t *testing.T) {
+		now := time.Now()
+		service, provider := newFederatedTestSetup(t, newFakeUserRepository(), &now)
+		service.RoleService.(*RoleService).RoleRepository = newFakeRoleRepository()
+
+		_, err := service.Authenticate(context.Background(), federatedCallback(t, service, provider, 0, profile("g-1", "ana@example.com", true)))
+		assertAPIStatus(t, err, http.StatusInternalServerError)
+		if identity, _ := service.ExternalIdentityRepository.FindByProviderSubject(context.Background(), "google", "g-1"); identity != nil {
+			t.Errorf("expected no identity to be linked to a failed signup")
+		}
+	})
+
+	t.Run("numeric subject", func(t *testing.T) {
+		now := time.Now()
+		service, provider := newFederatedTestSetup(t, newFakeUserRepository(), &now)
+		idp := provider.config()
+		idp.SubjectClaim = "id"
+		service.IdentityProviders["google"] = idp
+
+		claims := profile(nil, "ana@example.com", true)
+		claims["id"] = 10223344556677889
+		user, err := service.Authenticate(context.Background(), federatedCallback(t, service, provider, 0, claims))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		identities, _ := service.ListIdentities(context.Background(), user.ID)
+		identity, _ := service.ExternalIdentityRepository.FindByProviderSubject(context.Background(), "google", "10223344556677889")
+		if len(identities) != 1 || identity == nil {
+			t.Errorf("expected the subject without float rounding, got %+v", identities)
+		}
+	})
+
+	t.Run("state and code checks", func(t *testing.T) {
+		now := time.Now()
+		service, provider := newFederatedTestSetup(t, newFakeUserRepository(), &now)
+
+		request := federatedCallback(t, service, provider, 0, profile("g-1", "ana@example.com", true))
+		if _, err := service.Authenticate(context.Background(), request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err := service.Authenticate(context.Background(), request)
+		assertAPIStatus(t, err, http.StatusBadRequest)
+
+		request = federatedCallback(t, service, provider, 0, profile("g-1", "ana@example.com", true))
+		request.Code = "forged-code"
+		_, err = service.Authenticate(context.Background(), request)
+		assertAPIStatus(t, err, http.StatusUnauthorized)
+
+		request = federatedCallback(t, service, provider, 0, profile("g-1", "ana@example.com", true))
+		now = now.Add(service.StateTTL)
+		_, err = service.Authenticate(context.Background(), request)
+		assertAPIStatus(t, err, http.StatusBadRequest)
+
+		_, err = service.Begin(context.Background(), "facebook", 0)
+		assertAPIStatus(t, err, http.StatusNotFound)
+	})
+
+	t.Run("provider unavailable", func(t *testing.T) {
+		now := time.Now()
+		service, provider := newFederatedTestSetup(t, newFakeUserRepository(), &now)
+		request := federatedCallback(t, service, provider, 0, profile("g-1", "ana@example.com", true))
+		provider.server.Close()
+
+		_, err := service.Authenticate(context.Background(), request)
+		assertAPIStatus(t, err, http.StatusBadGateway)
+	})
+
+	t.Run("link and unlink", func(t *testing.T) {
+		users := newFakeUserRepository()
+		_ = users.Create(context.Background(), &models.User{Email: "ana@example.com", EmailVerified: true})
+		_ = users.Create(context.Background(), &models.User{Email: "bob@example.com", EmailVerified: true})
+		now := time.Now()
+		service, provider := newFederatedTestSetup(t, users, &now)
+
+		_, err := service.Authenticate(context.Background(), federatedCallback(t, service, provider, 1, profile("g-1", "ana@gmail.com", true)))
+		assertAPIStatus(t, err, http.StatusBadRequest)
+		_, err = service.Link(context.Background(), 2, federatedCallback(t, service, provider, 1, profile("g-1", "ana@gmail.com", true)))
+		assertAPIStatus(t, err, http.StatusBadRequest)
+
+		identity, err := service.Link(context.Background(), 1, federatedCallback(t, service, provider, 1, profile("g-1", "ana@gmail.com", true)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if identity.Provider != "google" || identity.Email != "ana@gmail.com" {
+			t.Errorf("unexpected identity: %+v", identity)
+		}
+
+		user, err := service.Authenticate(context.Background(), federatedCallback(t, service, provider, 0, profile("g-1", "ana@gmail.com", true)))
+		if err != nil || user.ID != 1 {
+			t.Fatalf("expected to log in as the linked account, got %+v, %v", user, err)
+		}
+
+		_, err = service.Link(context.Background(), 2, federatedCallback(t, service, provider, 2, profile("g-1", "ana@gmail.com", true)))
+		assertAPIStatus(t, err, http.StatusConflict)
+		_, err = service.Link(context.Background(), 1, federatedCallback(t, service, provider, 1, profile("g-2", "ana@gmail.com", true)))
+		assertAPIStatus(t, err, http.StatusConflict)
+
+		if err := service.Unlink(context.Background(), 1, "google"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertAPIStatus(t, service.Unlink(context.Background(), 1, "google"), http.StatusNotFound)
+	})
+}
+
+func TestAuthServiceFederated(t *testing.T) {
+	users := newFakeUserRepository()
+	now := time.Unix(1_700_000_000, 0)
+	service := newTestAuthService(users, &now)
+	federated, provider := newFederatedTestSetup(t, users, &now)
+	service.FederatedLoginService = federated
+	claims := map[string]interface{}{"sub": "g-1", "email": "ana@example.com", "email_verified": true}
+
+	login, err := service.LoginFederated(context.Background(), federatedCallback(t, federated, provider, 0, claims))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if login.TokenResponse == nil || login.AccessToken == "" || login.RefreshToken == "" {
+		t.Fatalf("expected a token pair: %+v", login)
+	}
+
+	enrollTestTOTP(t, service.MFAService.(*MFAService), &now)
+	login, err = service.LoginFederated(context.Background(), federatedCallback(t, federated, provider, 0, claims))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if login.TokenResponse != nil || login.MFAChallengeResponse == nil {
+		t.Errorf("expected an mfa challenge instead of tokens: %+v", login)
+	}
+}
+
+type fakeExternalIdentityRepository struct {
+	identities []*models.ExternalIdentity
+	nextID     uint
+}
+
+func (r *fakeExternalIdentityRepository) Create(_ context.Context, identity *models.ExternalIdentity) error {
+	for _, existing := range r.identities {
+		if existing.Provider == identity.Provider && existing.Subject == identity.Subject {
+			return repositories.ErrDuplicatedIdentity
+		}
+	}
+	r.nextID++
+	identity.ID = r.nextID
+	stored := *identity
+	r.identities = append(r.identities, &stored)
+	return nil
+}
+
+func (r *fakeExternalIdentityRepository) FindByProviderSubject(_ context.Context, provider, subject string) (*models.ExternalIdentity, error) {
+	for _, identity := range r.identities {
+		if identity.Provider == provider && identity.Subject == subject {
+			found := *identity
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeExternalIdentityRepository) FindByUserID(_ context.Context, userID uint) ([]models.ExternalIdentity, error) {
+	var identities []models.ExternalIdentity
+	for _, identity := range r.identities {
+		if identity.UserID == userID {
+			identities = append(identities, *identity)
+		}
+	}
+	return identities, nil
+}
+
+func (r *fakeExternalIdentityRepository) UpdateLastLogin(_ context.Context, id uint, lastLoginAt time.Time) error {
+	for _, identity := range r.identities {
+		if identity.ID == id {
+			identity.LastLoginAt = &lastLoginAt
+		}
+	}
+	return nil
+}
+
+func (r *fakeExternalIdentityRepository) Delete(_ context.Context, userID uint, provider string) error {
+	for i, identity := range r.identities {
+		if identity.UserID == userID && identity.Provider == provider {
+			r.identities = append(r.identities[:i], r.identities[i+1:]...)
+			return nil
+		}
+	}
+	return repositories.ErrNotFound
+}
+
+type fakeFederatedLoginStateRepository struct {
+	states map[string]*models.FederatedLoginState
+}
+
+func (r *fakeFederatedLoginStateRepository) Create(_ context.Context, state *models.FederatedLoginState) error {
+	r.states[state.StateHash] = state
+	return nil
+}
+
+func (r *fakeFederatedLoginStateRepository) Consume(_ context.Context, provider, stateHash string, now time.Time) (*models.FederatedLoginState, error) {
+	state, ok := r.states[stateHash]
+	if !ok || state.Provider != provider || !now.Before(state.ExpiresAt) {
+		return nil, nil
+	}
+	delete(r.states, stateHash)
+	return state, nil
+}
+
+func (r *fakeFederatedLoginStateRepository) DeleteExpired(_ context.Context, now time.Time) error {
+	for hash, state := range r.states {
+		if !now.Before(state.ExpiresAt) {
+			delete(r.states, hash)
+		}
+	}
+	return nil
+}
+
+func newTestFederatedLoginService(users *fakeUserRepository, rustyClient rusty.IRustyClient, now *time.Time) *FederatedLoginService {
+	service := NewFederatedLoginService(
+		users,
+		fakeTransactor{},
+		&fakeExternalIdentityRepository{},
+		&fakeFederatedLoginStateRepository{states: map[string]*models.FederatedLoginState{}},
+		newTestRoleService(users),
+		rustyClient,
+		map[string]config.IdentityProviderConfig{},
+	)
+	service.now = func() time.Time { return *now }
+	return service
+}
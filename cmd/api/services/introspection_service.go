@@ -0,0 +1,144 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+)
+
+type IIntrospectionService interface {
+	ValidateAccessToken(ctx context.Context, token string) (*AccessTokenClaims, error)
+	Introspect(ctx context.Context, request dto.IntrospectionRequest) dto.IntrospectionResponse
+}
+
+type IntrospectionService struct {
+	UserRepository      repositories.IUserRepository
+	TokenService        ITokenService
+	RefreshTokenService IRefreshTokenService
+	RevocationService   IRevocationService
+	now                 func() time.Time
+}
+
+func NewIntrospectionService(
+	userRepository repositories.IUserRepository,
+	tokenService ITokenService,
+	refreshTokenService IRefreshTokenService,
+	revocationService IRevocationService,
+) *IntrospectionService {
+	return &IntrospectionService{
+		UserRepository:      userRepository,
+		TokenService:        tokenService,
+		RefreshTokenService: refreshTokenService,
+		RevocationService:   revocationService,
+		now:                 time.Now,
+	}
+}
+
+// ValidateAccessToken además de la firma comprueba que el jti no esté revocado, que la sesión del token (sid) siga
+// vigente y que el token no sea anterior al último cambio de contraseña del usuario.
+func (s *IntrospectionService) ValidateAccessToken(ctx context.Context, token string) (*AccessTokenClaims, error) {
+	claims, err := s.TokenService.ParseAccessToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := s.RevocationService.IsRevoked(ctx, claims.ID)
+	if err != nil {
+		return nil, err
+	}
+	if revoked {
+		return nil, ErrInvalidToken
+	}
+	if claims.IsClientToken() {
+		return claims, nil
+	}
+	if claims.SessionID != "" {
+		revoked, err := s.RefreshTokenService.SessionRevoked(ctx, claims.SessionID)
+		if err != nil {
+			return nil, err
+		}
+		if revoked {
+			return nil, ErrInvalidToken
+		}
+	}
+
+	userID, err := claims.UserID()
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// iat tiene resolución de segundos, por eso se trunca la fecha del cambio.
+	if user == nil || (user.PasswordChangedAt != nil && claims.IssuedAt.Before(user.PasswordChangedAt.Truncate(time.Second))) {
+		return nil, ErrInvalidToken
+	}
+
+	return claims, nil
+}
+
+// Introspect implementa RFC 7662 para access y refresh tokens.
+func (s *IntrospectionService) Introspect(ctx context.Context, request dto.IntrospectionRequest) dto.IntrospectionResponse {
+	if request.TokenTypeHint == dto.TokenTypeHintRefreshToken {
+		if response, ok := s.introspectRefreshToken(ctx, request.Token); ok {
+			return response
+		}
+		response, _ := s.introspectAccessToken(ctx, request.Token)
+		return response
+	}
+
+	if response, ok := s.introspectAccessToken(ctx, request.Token); ok {
+		return response
+	}
+	response, _ := s.introspectRefreshToken(ctx, request.Token)
+	return response
+}
+
+func (s *IntrospectionService) introspectAccessToken(ctx context.Context, token string) (dto.IntrospectionResponse, bool) {
+	claims, err := s.ValidateAccessToken(ctx, token)
+	if err != nil {
+		if err != ErrInvalidToken {
+			log.Error(ctx, "error validating access token: ", err)
+		}
+		return dto.IntrospectionResponse{Active: false}, false
+	}
+
+	return dto.IntrospectionResponse{
+		Active:    true,
+		TokenType: dto.TokenTypeHintAccessToken,
+		Sub:       claims.Subject,
+		Username:  claims.Email,
+		ClientID:  claims.ClientID,
+		Scope:     claims.Scope,
+		Iss:       claims.Issuer,
+		Jti:       claims.ID,
+		Exp:       claims.ExpiresAt.Unix(),
+		Iat:       claims.IssuedAt.Unix(),
+	}, true
+}
+
+func (s *IntrospectionService) introspectRefreshToken(ctx context.Context, token string) (dto.IntrospectionResponse, bool) {
+	stored, err := s.RefreshTokenService.Find(ctx, token)
+	if err != nil {
+		log.Error(ctx, "error finding refresh token: ", err)
+		return dto.IntrospectionResponse{Active: false}, false
+	}
+	if stored == nil || !stored.IsActive(s.now()) {
+		return dto.IntrospectionResponse{Active: false}, false
+	}
+
+	return dto.IntrospectionResponse{
+		Active:    true,
+		TokenType: dto.TokenTypeHintRefreshToken,
+		Sub:       strconv.FormatUint(uint64(stored.UserID), 10),
+		ClientID:  stored.ClientID,
+		Scope:     stored.Scope,
+		Exp:       stored.ExpiresAt.Unix(),
+		Iat:       stored.CreatedAt.Unix(),
+	}, true
+}
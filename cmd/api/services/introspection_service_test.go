@@ -0,0 +1,181 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+)
+
+type introspectionFixture struct {
+	users         *fakeUserRepository
+	revoked       *fakeRevokedTokenRepository
+	tokens        *TokenService
+	refreshTokens *RefreshTokenService
+	revocation    *RevocationService
+	introspection *IntrospectionService
+	user          *models.User
+	client        *models.OAuthClient
+}
+
+func newIntrospectionFixture(now *time.Time) *introspectionFixture {
+	f := &introspectionFixture{
+		users:   newFakeUserRepository(),
+		revoked: newFakeRevokedTokenRepository(),
+		tokens:  newTestTokenService(now),
+		user:    &models.User{Email: "ana@example.com", EmailVerified: true},
+		client:  &models.OAuthClient{ClientID: "partner-app"},
+	}
+	f.refreshTokens = newTestRefreshTokenService(newFakeRefreshTokenRepository(), now)
+	f.revocation = NewRevocationService(f.revoked, f.tokens, f.refreshTokens, config.AuthTokenConfig{AccessTokenTTL: time.Hour})
+	f.revocation.now = func() time.Time { return *now }
+	f.introspection = NewIntrospectionService(f.users, f.tokens, f.refreshTokens, f.revocation)
+	f.introspection.now = func() time.Time { return *now }
+	_ = f.users.Create(context.Background(), f.user)
+	return f
+}
+
+func TestIntrospectionService(t *testing.T) {
+
+	t.Run("access token", func(t *testing.T) {
+		now := time.Now()
+		f := newIntrospectionFixture(&now)
+		token, claims, _ := f.tokens.IssueOAuthAccessToken(context.Background(), f.user, f.client, "openid", "")
+
+		response := f.introspection.Introspect(context.Background(), dto.IntrospectionRequest{Token: token})
+		if !response.Active || response.Jti != claims.ID || response.TokenType != dto.TokenTypeHintAccessToken {
+			t.Errorf("unexpected response: %+v", response)
+		}
+
+		if err := f.revocation.Revoke(context.Background(), f.client.ClientID, dto.RevocationRequest{Token: token}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if response := f.introspection.Introspect(context.Background(), dto.IntrospectionRequest{Token: token}); response.Active {
+			t.Errorf("expected revoked token to be inactive")
+		}
+	})
+
+	t.Run("password change invalidates older tokens", func(t *testing.T) {
+		now := time.Now()
+		f := newIntrospectionFixture(&now)
+		token, _, _ := f.tokens.IssueAccessToken(context.Background(), f.user, "")
+
+		changedAt := now.Add(2 * time.Second)
+		f.user.PasswordChangedAt = &changedAt
+		_ = f.users.Update(context.Background(), f.user)
+
+		if _, err := f.introspection.ValidateAccessToken(context.Background(), token); err != ErrInvalidToken {
+			t.Errorf("unexpected error: got %v, want %v", err, ErrInvalidToken)
+		}
+	})
+
+	t.Run("revoking the session invalidates its access tokens", func(t *testing.T) {
+		now := time.Now()
+		f := newIntrospectionFixture(&now)
+		_ = f.refreshTokens.StartSession(context.Background(), f.user.ID, "family-1", "")
+		token, _, _ := f.tokens.IssueAccessToken(context.Background(), f.user, "family-1")
+		other, _, _ := f.tokens.IssueAccessToken(context.Background(), f.user, "family-2")
+
+		if _, err := f.introspection.ValidateAccessToken(context.Background(), token); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := f.refreshTokens.RevokeFamily(context.Background(), "family-1"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := f.introspection.ValidateAccessToken(context.Background(), token); err != ErrInvalidToken {
+			t.Errorf("unexpected error: got %v, want %v", err, ErrInvalidToken)
+		}
+		if _, err := f.introspection.ValidateAccessToken(context.Background(), other); err != nil {
+			t.Errorf("expected tokens of other sessions to stay valid, got %v", err)
+		}
+	})
+
+	t.Run("refresh token", func(t *testing.T) {
+		now := time.Now()
+		f := newIntrospectionFixture(&now)
+		token, _ := f.refreshTokens.IssueForClient(context.Background(), f.user.ID, "", f.client.ClientID, "openid")
+
+		request := dto.IntrospectionRequest{Token: token, TokenTypeHint: dto.TokenTypeHintRefreshToken}
+		if response := f.introspection.Introspect(context.Background(), request); !response.Active || response.Sub != "1" {
+			t.Errorf("unexpected response: %+v", response)
+		}
+
+		_ = f.revocation.Revoke(context.Background(), f.client.ClientID, dto.RevocationRequest{Token: token, TokenTypeHint: dto.TokenTypeHintRefreshToken})
+		if response := f.introspection.Introspect(context.Background(), request); response.Active {
+			t.Errorf("expected revoked refresh token to be inactive")
+		}
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		now := time.Now()
+		f := newIntrospectionFixture(&now)
+
+		if response := f.introspection.Introspect(context.Background(), dto.IntrospectionRequest{Token: "unknown"}); response.Active {
+			t.Errorf("expected unknown token to be inactive")
+		}
+		if err := f.revocation.Revoke(context.Background(), f.client.ClientID, dto.RevocationRequest{Token: "unknown"}); err != nil {
+			t.Errorf("unexpected error revoking an unknown token: %v", err)
+		}
+	})
+
+	t.Run("clients can only revoke their own tokens", func(t *testing.T) {
+		now := time.Now()
+		f := newIntrospectionFixture(&now)
+		accessToken, _, _ := f.tokens.IssueOAuthAccessToken(context.Background(), f.user, f.client, "openid", "")
+		refreshToken, _ := f.refreshTokens.IssueForClient(context.Background(), f.user.ID, "", f.client.ClientID, "openid")
+
+		for _, token := range []string{accessToken, refreshToken} {
+			err := f.revocation.Revoke(context.Background(), "other-app", dto.RevocationRequest{Token: token})
+			assertAPIStatus(t, err, http.StatusBadRequest)
+			if response := f.introspection.Introspect(context.Background(), dto.IntrospectionRequest{Token: token}); !response.Active {
+				t.Errorf("expected token revoked by another client to stay active")
+			}
+		}
+	})
+
+	t.Run("revocation lookups are cached", func(t *testing.T) {
+		now := time.Now()
+		f := newIntrospectionFixture(&now)
+		token, _, _ := f.tokens.IssueAccessToken(context.Background(), f.user, "")
+
+		for i := 0; i < 3; i++ {
+			_, _ = f.introspection.ValidateAccessToken(context.Background(), token)
+		}
+		if f.revoked.lookups != 1 {
+			t.Errorf("unexpected lookups: got %d, want 1", f.revoked.lookups)
+		}
+	})
+}
+
+type fakeRevokedTokenRepository struct {
+	tokens  map[string]*models.RevokedToken
+	lookups int
+}
+
+func newFakeRevokedTokenRepository() *fakeRevokedTokenRepository {
+	return &fakeRevokedTokenRepository{tokens: map[string]*models.RevokedToken{}}
+}
+
+func (r *fakeRevokedTokenRepository) Create(_ context.Context, token *models.RevokedToken) error {
+	r.tokens[token.JTI] = token
+	return nil
+}
+
+func (r *fakeRevokedTokenRepository) Exists(_ context.Context, jti string) (bool, error) {
+	r.lookups++
+	_, ok := r.tokens[jti]
+	return ok, nil
+}
+
+func (r *fakeRevokedTokenRepository) DeleteExpired(_ context.Context, now time.Time) error {
+	for jti, token := range r.tokens {
+		if !token.ExpiresAt.After(now) {
+			delete(r.tokens, jti)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,331 @@
+package services
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/secrets"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+const (
+	// keyCacheTTL limita cuánto tarda una instancia en ver las claves creadas por otra.
+	keyCacheTTL = time.Minute
+	// keyClockSkew se suma a la vida de los tokens antes de retirar la clave que los firmó.
+	keyClockSkew = 5 * time.Minute
+)
+
+var ErrNoSigningKey = errors.New("no active signing key")
+
+type SigningKeyPair struct {
+	KID         string
+	Algorithm   string
+	Signer      crypto.Signer
+	ActivatedAt time.Time
+}
+
+type IKeyService interface {
+	SigningKey(ctx context.Context) (*SigningKeyPair, error)
+	VerificationKey(ctx context.Context, kid string) (*SigningKeyPair, error)
+	JWKS(ctx context.Context) ([]utils.JWK, error)
+	RotateIfDue(ctx context.Context) error
+	Rotate(ctx context.Context) error
+}
+
+// KeyService genera sus propias claves de firma salvo que SigningKeySecret nombre un secreto con la clave PEM;
+// en ese caso la clave la rota quien administra el secreto.
+type KeyService struct {
+	SigningKeyRepository repositories.ISigningKeyRepository
+	Transactor           repositories.ITransactor
+	SecretProvider       secrets.ISecretProvider
+	SigningKeySecret     string
+	Algorithm            string
+	RotationInterval     time.Duration
+	PublishAhead         time.Duration
+	TokenTTL             time.Duration
+
+	mutex    sync.RWMutex
+	keys     []*SigningKeyPair
+	loadedAt time.Time
+	// missReloadedAt es la última recarga por un kid desconocido; limita esas recargas a una por keyCacheTTL.
+	missReloadedAt time.Time
+	now            func() time.Time
+}
+
+func NewKeyService(
+	signingKeyRepository repositories.ISigningKeyRepository,
+	transactor repositories.ITransactor,
+	secretProvider secrets.ISecretProvider,
+	authConfig config.AuthTokenConfig,
+) *KeyService {
+	return &KeyService{
+		SigningKeyRepository: signingKeyRepository,
+		Transactor:           transactor,
+		SecretProvider:       secretProvider,
+		SigningKeySecret:     authConfig.SigningKeySecret,
+		Algorithm:            authConfig.SigningAlgorithm,
+		RotationInterval:     authConfig.KeyRotationInterval,
+		PublishAhead:         authConfig.KeyPublishAhead,
+		TokenTTL:             authConfig.AccessTokenTTL,
+		now:                  time.Now,
+	}
+}
+
+// SigningKey devuelve la clave activa más reciente.
+func (s *KeyService) SigningKey(ctx context.Context) (*SigningKeyPair, error) {
+	keys, err := s.cachedKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	for _, key := range keys {
+		if !key.ActivatedAt.After(now) {
+			return key, nil
+		}
+	}
+	return nil, ErrNoSigningKey
+}
+
+// VerificationKey busca una clave publicada por kid; ante un kid desconocido recarga las claves por si otra
+// instancia acaba de crearla, como mucho una vez por keyCacheTTL para que tokens con kids inventados no lleguen a
+// la base en cada request.
+func (s *KeyService) VerificationKey(ctx context.Context, kid string) (*SigningKeyPair, error) {
+	keys, err := s.cachedKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if key := findKey(keys, kid); key != nil {
+		return key, nil
+	}
+	if !s.allowMissReload() {
+		return nil, fmt.Errorf("unknown signing key: %s", kid)
+	}
+
+	if keys, err = s.load(ctx); err != nil {
+		return nil, err
+	}
+	if key := findKey(keys, kid); key != nil {
+		return key, nil
+	}
+	return nil, fmt.Errorf("unknown signing key: %s", kid)
+}
+
+func (s *KeyService) JWKS(ctx context.Context) ([]utils.JWK, error) {
+	keys, err := s.cachedKeys(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	jwks := make([]utils.JWK, 0, len(keys))
+	for _, key := range keys {
+		jwk, err := utils.NewJWK(key.KID, key.Algorithm, key.Signer.Public())
+		if err != nil {
+			return nil, err
+		}
+		jwks = append(jwks, jwk)
+	}
+	return jwks, nil
+}
+
+// RotateIfDue crea una clave nueva cuando no hay ninguna o la más reciente cumplió RotationInterval, o importa la
+// clave del secreto si cambió, y elimina las claves cuyo retiro ya venció.
+func (s *KeyService) RotateIfDue(ctx context.Context) error {
+	keys, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	switch {
+	case s.SigningKeySecret != "":
+		if err := s.importSecretKey(ctx, keys); err != nil {
+			return err
+		}
+	case len(keys) == 0 || !keys[0].ActivatedAt.Add(s.RotationInterval).After(now):
+		if err := s.Rotate(ctx); err != nil {
+			return err
+		}
+	}
+
+	if err := s.SigningKeyRepository.DeleteRetired(ctx, now); err != nil {
+		return err
+	}
+	_, err = s.load(ctx)
+	return err
+}
+
+// Rotate publica una clave nueva que empieza a firmar tras PublishAhead, para que los consumidores la
+// descarguen antes de verla en un token. Las claves anteriores se retiran cuando expiran los últimos tokens
+// que pudieron firmar. La primera clave del servicio se activa de inmediato.
+func (s *KeyService) Rotate(ctx context.Context) error {
+	signer, err := utils.GenerateSigningKey(s.Algorithm)
+	if err != nil {
+		return err
+	}
+	kid, err := utils.GenerateToken(16)
+	if err != nil {
+		return err
+	}
+	return s.publish(ctx, kid, s.Algorithm, signer)
+}
+
+// importSecretKey publica la clave del secreto cuando todavía no está entre las publicadas, con la misma
+// transición que Rotate. El kid sale de la clave pública, así que todas las instancias importan la misma clave con
+// el mismo kid; si otra se adelantó, el alta choca con el índice único y alcanza con recargar.
+func (s *KeyService) importSecretKey(ctx context.Context, keys []*SigningKeyPair) error {
+	encoded, err := s.SecretProvider.Secret(ctx, s.SigningKeySecret)
+	if err != nil {
+		return fmt.Errorf("error reading signing key secret: %w", err)
+	}
+	signer, err := utils.ParsePrivateKey(encoded)
+	if err != nil {
+		return fmt.Errorf("error parsing signing key secret: %w", err)
+	}
+	algorithm, err := utils.SigningAlgorithmOf(signer)
+	if err != nil {
+		return err
+	}
+	kid, err := utils.KeyThumbprint(signer.Public())
+	if err != nil {
+		return err
+	}
+	if findKey(keys, kid) != nil {
+		return nil
+	}
+
+	if err := s.publish(ctx, kid, algorithm, signer); err != nil {
+		if keys, loadErr := s.load(ctx); loadErr == nil && findKey(keys, kid) != nil {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *KeyService) publish(ctx context.Context, kid, algorithm string, signer crypto.Signer) error {
+	keys, err := s.load(ctx)
+	if err != nil {
+		return err
+	}
+
+	now := s.now()
+	activatedAt := now
+	if len(keys) > 0 {
+		activatedAt = now.Add(s.PublishAhead)
+	}
+
+	privateKey, err := utils.EncodePrivateKey(signer)
+	if err != nil {
+		return err
+	}
+
+	key := &models.SigningKey{
+		KID:         kid,
+		Algorithm:   algorithm,
+		PrivateKey:  privateKey,
+		ActivatedAt: activatedAt,
+	}
+	// La clave nueva y el retiro de las anteriores van juntos: sin la clave no hay que retirar nada, y sin el
+	// retiro las claves viejas seguirían publicadas para siempre.
+	retiresAt := activatedAt.Add(s.TokenTTL + keyClockSkew)
+	err = s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.SigningKeyRepository.Create(ctx, key); err != nil {
+			return err
+		}
+		return s.SigningKeyRepository.ScheduleRetirement(ctx, key.ID, retiresAt)
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Info(ctx, fmt.Sprintf("signing key rotated, kid: %v, algorithm: %v, activated at: %v", kid, algorithm, activatedAt))
+	_, err = s.load(ctx)
+	return err
+}
+
+// Run ejecuta RotateIfDue cada interval hasta que se cancele ctx.
+func (s *KeyService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.RotateIfDue(ctx); err != nil {
+				log.Error(ctx, "error rotating signing keys: ", err)
+			}
+		}
+	}
+}
+
+func (s *KeyService) cachedKeys(ctx context.Context) ([]*SigningKeyPair, error) {
+	s.mutex.RLock()
+	keys, loadedAt := s.keys, s.loadedAt
+	s.mutex.RUnlock()
+
+	if keys != nil && s.now().Sub(loadedAt) < keyCacheTTL {
+		return keys, nil
+	}
+	return s.load(ctx)
+}
+
+// allowMissReload reserva la recarga por kid desconocido si pasó keyCacheTTL desde la anterior.
+func (s *KeyService) allowMissReload() bool {
+	now := s.now()
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.missReloadedAt.IsZero() && now.Sub(s.missReloadedAt) < keyCacheTTL {
+		return false
+	}
+	s.missReloadedAt = now
+	return true
+}
+
+func (s *KeyService) load(ctx context.Context) ([]*SigningKeyPair, error) {
+	now := s.now()
+	stored, err := s.SigningKeyRepository.FindPublished(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]*SigningKeyPair, 0, len(stored))
+	for _, key := range stored {
+		signer, err := utils.ParsePrivateKey(key.PrivateKey)
+		if err != nil {
+			log.Error(ctx, fmt.Sprintf("error parsing signing key %v: %v", key.KID, err))
+			continue
+		}
+		keys = append(keys, &SigningKeyPair{
+			KID:         key.KID,
+			Algorithm:   key.Algorithm,
+			Signer:      signer,
+			ActivatedAt: key.ActivatedAt,
+		})
+	}
+
+	s.mutex.Lock()
+	s.keys, s.loadedAt = keys, now
+	s.mutex.Unlock()
+	return keys, nil
+}
+
+func findKey(keys []*SigningKeyPair, kid string) *SigningKeyPair {
+	for _, key := range keys {
+		if key.KID == kid {
+			return key
+		}
+	}
+	return nil
+}
@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/secrets"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+func TestKeyService(t *testing.T) {
+
+	t.Run("bootstrap", func(t *testing.T) {
+		now := time.Now()
+		service := newTestKeyService(newFakeSigningKeyRepository(), &now)
+
+		if err := service.RotateIfDue(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := service.SigningKey(context.Background()); err != nil {
+			t.Errorf("expected the first key to sign immediately: %v", err)
+		}
+
+		jwks, _ := service.JWKS(context.Background())
+		if len(jwks) != 1 {
+			t.Errorf("unexpected published keys: got %d, want 1", len(jwks))
+		}
+	})
+
+	t.Run("rotation overlap", func(t *testing.T) {
+		now := time.Now()
+		repository := newFakeSigningKeyRepository()
+		service := newTestKeyService(repository, &now)
+		_ = service.RotateIfDue(context.Background())
+		first, _ := service.SigningKey(context.Background())
+
+		now = now.Add(time.Hour)
+		_ = service.RotateIfDue(context.Background())
+		if len(repository.keys) != 1 {
+			t.Fatalf("expected no rotation before the interval")
+		}
+
+		now = now.Add(24 * time.Hour)
+		_ = service.RotateIfDue(context.Background())
+		jwks, _ := service.JWKS(context.Background())
+		if len(jwks) != 2 {
+			t.Fatalf("unexpected published keys: got %d, want 2", len(jwks))
+		}
+		if current, _ := service.SigningKey(context.Background()); current.KID != first.KID {
+			t.Errorf("expected the old key to keep signing until the new one activates")
+		}
+
+		now = now.Add(10 * time.Minute)
+		second, _ := service.SigningKey(context.Background())
+		if second.KID == first.KID {
+			t.Errorf("expected the new key to sign after publish ahead")
+		}
+		if _, err := service.VerificationKey(context.Background(), first.KID); err != nil {
+			t.Errorf("expected the old key to verify tokens it signed: %v", err)
+		}
+
+		now = now.Add(time.Hour + keyClockSkew)
+		_ = service.RotateIfDue(context.Background())
+		if _, err := service.VerificationKey(context.Background(), first.KID); err == nil {
+			t.Errorf("expected the old key to be retired once its tokens expired")
+		}
+		if len(repository.keys) != 1 {
+			t.Errorf("expected retired keys to be deleted")
+		}
+		if repository.outsideTransaction != 0 {
+			t.Errorf("expected each key to be published in a transaction")
+		}
+	})
+
+	t.Run("throttles reloads for unknown kids", func(t *testing.T) {
+		now := time.Now()
+		repository := newFakeSigningKeyRepository()
+		service := newTestKeyService(repository, &now)
+		_ = service.RotateIfDue(context.Background())
+		other := newTestKeyService(repository, &now)
+
+		repository.findPublished = 0
+		for _, kid := range []string{"forged-1", "forged-2", "forged-3"} {
+			if _, err := service.VerificationKey(context.Background(), kid); err == nil {
+				t.Fatalf("expected %s to be unknown", kid)
+			}
+		}
+		if repository.findPublished != 1 {
+			t.Errorf("unexpected reloads: got %d, want 1", repository.findPublished)
+		}
+
+		now = now.Add(keyCacheTTL / 2)
+		_ = other.Rotate(context.Background())
+		created, _ := other.JWKS(context.Background())
+		now = now.Add(keyCacheTTL)
+		if _, err := service.VerificationKey(context.Background(), created[0].Kid); err != nil {
+			t.Errorf("expected a key created by another instance to be found after the reload interval: %v", err)
+		}
+	})
+
+	t.Run("imports the signing key from a secret", func(t *testing.T) {
+		now := time.Now()
+		repository := newFakeSigningKeyRepository()
+		service := newTestKeyService(repository, &now)
+		provider := fakeSecretProvider{"jwt_signing_key": newEncodedKey(t, utils.AlgorithmRS256)}
+		service.SecretProvider = provider
+		service.SigningKeySecret = "jwt_signing_key"
+
+		if err := service.RotateIfDue(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		first, err := service.SigningKey(context.Background())
+		if err != nil || first.Algorithm != utils.AlgorithmRS256 {
+			t.Fatalf("expected the imported key to sign immediately: %+v %v", first, err)
+		}
+
+		now = now.Add(48 * time.Hour)
+		_ = service.RotateIfDue(context.Background())
+		if len(repository.keys) != 1 {
+			t.Fatalf("expected no rotation while the secret does not change")
+		}
+
+		provider["jwt_signing_key"] = newEncodedKey(t, utils.AlgorithmES256)
+		if err := service.RotateIfDue(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if current, _ := service.SigningKey(context.Background()); current.KID != first.KID {
+			t.Errorf("expected the old key to keep signing until the new one activates")
+		}
+
+		now = now.Add(10 * time.Minute)
+		second, _ := service.SigningKey(context.Background())
+		if second.KID == first.KID || second.Algorithm != utils.AlgorithmES256 {
+			t.Errorf("expected the new secret to sign after publish ahead: %+v", second)
+		}
+	})
+
+	t.Run("fails when the secret is missing", func(t *testing.T) {
+		now := time.Now()
+		service := newTestKeyService(newFakeSigningKeyRepository(), &now)
+		service.SigningKeySecret = "jwt_signing_key"
+
+		if err := service.RotateIfDue(context.Background()); err == nil {
+			t.Errorf("expected an error")
+		}
+	})
+}
+
+func newEncodedKey(t *testing.T, algorithm string) string {
+	t.Helper()
+	key, err := utils.GenerateSigningKey(algorithm)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encoded, err := utils.EncodePrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return encoded
+}
+
+type fakeSigningKeyRepository struct {
+	keys          []*models.SigningKey
+	nextID        uint
+	findPublished int
+	// outsideTransaction cuenta las escrituras de una publicación hechas fuera de una transacción.
+	outsideTransaction int
+}
+
+func newFakeSigningKeyRepository() *fakeSigningKeyRepository {
+	return &fakeSigningKeyRepository{nextID: 1}
+}
+
+func (r *fakeSigningKeyRepository) Create(ctx context.Context, key *models.SigningKey) error {
+	if !inFakeTransaction(ctx) {
+		r.outsideTransaction++
+	}
+	key.ID = r.nextID
+	r.nextID++
+	r.keys = append(r.keys, key)
+	return nil
+}
+
+func (r *fakeSigningKeyRepository) FindPublished(_ context.Context, now time.Time) ([]models.SigningKey, error) {
+	r.findPublished++
+	var keys []models.SigningKey
+	for i := len(r.keys) - 1; i >= 0; i-- {
+		if r.keys[i].RetiresAt == nil || r.keys[i].RetiresAt.After(now) {
+			keys = append(keys, *r.keys[i])
+		}
+	}
+	return keys, nil
+}
+
+func (r *fakeSigningKeyRepository) ScheduleRetirement(ctx context.Context, exceptID uint, retiresAt time.Time) error {
+	if !inFakeTransaction(ctx) {
+		r.outsideTransaction++
+	}
+	for _, key := range r.keys {
+		if key.ID != exceptID && key.RetiresAt == nil {
+			key.RetiresAt = &retiresAt
+		}
+	}
+	return nil
+}
+
+func (r *fakeSigningKeyRepository) DeleteRetired(_ context.Context, now time.Time) error {
+	var keys []*models.SigningKey
+	for _, key := range r.keys {
+		if key.RetiresAt == nil || key.RetiresAt.After(now) {
+			keys = append(keys, key)
+		}
+	}
+	r.keys = keys
+	return nil
+}
+
+func newTestKeyService(repository *fakeSigningKeyRepository, now *time.Time) *KeyService {
+	service := NewKeyService(repository, fakeTransactor{}, fakeSecretProvider{}, config.AuthTokenConfig{
+		SigningAlgorithm:    utils.AlgorithmES256,
+		KeyRotationInterval: 24 * time.Hour,
+		KeyPublishAhead:     10 * time.Minute,
+		AccessTokenTTL:      time.Hour,
+	})
+	service.now = func() time.Time { return *now }
+	return service
+}
+
+// fakeSecretProvider devuelve los secretos del mapa; se puede modificar entre llamadas para simular una rotación.
+type fakeSecretProvider map[string]string
+
+func (p fakeSecretProvider) Secret(_ context.Context, name string) (string, error) {
+	value, ok := p[name]
+	if !ok {
+		return "", secrets.ErrSecretNotFound
+	}
+	return value, nil
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+const (
+	lockoutAccountPrefix = "account:"
+	lockoutIPPrefix      = "ip:"
+)
+
+type ILockoutService interface {
+	Check(ctx context.Context, email string) error
+	RegisterFailure(ctx context.Context, email string)
+	Reset(ctx context.Context, email string)
+	Unlock(ctx context.Context, userID uint) error
+}
+
+// LockoutService frena los ataques de fuerza bruta al login contando los fallos por email y por IP del cliente.
+// Los emails inexistentes se cuentan igual que los reales para no revelar qué cuentas existen. Si el almacén de
+// contadores falla el login sigue funcionando: se prefiere la disponibilidad a bloquear a todos los usuarios.
+type LockoutService struct {
+	LoginAttemptRepository repositories.ILoginAttemptRepository
+	UserRepository         repositories.IUserRepository
+	config                 config.LoginLockoutConfig
+	now                    func() time.Time
+}
+
+func NewLockoutService(
+	loginAttemptRepository repositories.ILoginAttemptRepository,
+	userRepository repositories.IUserRepository,
+	lockoutConfig config.LoginLockoutConfig,
+) *LockoutService {
+	return &LockoutService{
+		LoginAttemptRepository: loginAttemptRepository,
+		UserRepository:         userRepository,
+		config:                 lockoutConfig,
+		now:                    time.Now,
+	}
+}
+
+// Check responde 429 con Retry-After si la cuenta o la IP están bloqueadas o todavía no pasó la espera progresiva
+// desde el último fallo.
+func (s *LockoutService) Check(ctx context.Context, email string) error {
+	now := s.now()
+	var wait time.Duration
+	for _, key := range s.keys(ctx, email) {
+		attempt, err := s.LoginAttemptRepository.Find(ctx, key)
+		if err != nil {
+			log.Error(ctx, "error finding login attempts: ", err)
+			continue
+		}
+		if attempt == nil {
+			continue
+		}
+		if remaining := s.remainingWait(attempt, now); remaining > wait {
+			wait = remaining
+		}
+	}
+
+	if wait > 0 {
+		return utils.NewTooManyRequestsError("too many failed login attempts, try again later", wait)
+	}
+	return nil
+}
+
+// RegisterFailure bloquea la clave cuando alcanza su máximo de fallos dentro de la ventana.
+func (s *LockoutService) RegisterFailure(ctx context.Context, email string) {
+	now := s.now()
+	windowStart := now.Add(-s.config.FailureWindow)
+	for _, key := range s.keys(ctx, email) {
+		attempt, err := s.LoginAttemptRepository.RegisterFailure(ctx, key, now, windowStart)
+		if err != nil {
+			log.Error(ctx, "error registering failed login: ", err)
+			continue
+		}
+		if attempt.Failures < s.maxFailures(key) {
+			continue
+		}
+		if err := s.LoginAttemptRepository.Lock(ctx, key, now.Add(s.config.LockoutDuration)); err != nil {
+			log.Error(ctx, "error locking login: ", err)
+		}
+	}
+}
+
+// PurgeStale borra los contadores que ya no cuentan: sin fallos dentro de la ventana y sin bloqueo vigente. Sin
+// la purga la tabla crece con cada email inexistente y cada IP que alguna vez falló un login.
+func (s *LockoutService) PurgeStale(ctx context.Context) error {
+	now := s.now()
+	return s.LoginAttemptRepository.DeleteStale(ctx, now, now.Add(-s.config.FailureWindow))
+}
+
+// Run ejecuta PurgeStale cada interval hasta que se cancele ctx.
+func (s *LockoutService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.PurgeStale(ctx); err != nil {
+				log.Error(ctx, "error purging login attempts: ", err)
+			}
+		}
+	}
+}
+
+// Reset borra los fallos de la cuenta tras un login correcto o un reset de contraseña. El contador de la IP se
+// mantiene: una cuenta propia no debe servir para seguir probando contraseñas de otras.
+func (s *LockoutService) Reset(ctx context.Context, email string) {
+	if err := s.LoginAttemptRepository.Reset(ctx, lockoutAccountPrefix+NormalizeEmail(email)); err != nil {
+		log.Error(ctx, "error resetting login attempts: ", err)
+	}
+}
+
+// Unlock permite a un administrador desbloquear la cuenta de un usuario.
+func (s *LockoutService) Unlock(ctx context.Context, userID uint) error {
+	user, err := s.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return utils.NewInternalServerError("could not unlock user")
+	}
+	if user == nil {
+		return utils.NewNotFoundError("user not found")
+	}
+
+	if err := s.LoginAttemptRepository.Reset(ctx, lockoutAccountPrefix+NormalizeEmail(user.Email)); err != nil {
+		log.Error(ctx, "error resetting login attempts: ", err)
+		return utils.NewInternalServerError("could not unlock user")
+	}
+	return nil
+}
+
+func (s *LockoutService) keys(ctx context.Context, email string) []string {
+	keys := []string{lockoutAccountPrefix + NormalizeEmail(email)}
+	if ip := utils.ClientInfoFromContext(ctx).IPAddress; ip != "" {
+		keys = append(keys, lockoutIPPrefix+ip)
+	}
+	return keys
+}
+
+func (s *LockoutService) maxFailures(key string) int {
+	if strings.HasPrefix(key, lockoutIPPrefix) {
+		return s.config.MaxIPFailures
+	}
+	return s.config.MaxAccountFailures
+}
+
+// remainingWait ignora los fallos fuera de la ventana; dentro de ella la espera se duplica con cada fallo
+// desde DelayAfterFailures.
+func (s *LockoutService) remainingWait(attempt *models.LoginAttempt, now time.Time) time.Duration {
+	if attempt.IsLocked(now) {
+		return attempt.LockedUntil.Sub(now)
+	}
+	if attempt.LastFailureAt.Before(now.Add(-s.config.FailureWindow)) {
+		return 0
+	}
+	if attempt.Failures < s.config.DelayAfterFailures {
+		return 0
+	}
+
+	delay := s.config.BaseDelay
+	for i := s.config.DelayAfterFailures; i < attempt.Failures && delay < s.config.MaxDelay; i++ {
+		delay *= 2
+	}
+	if delay > s.config.MaxDelay {
+		delay = s.config.MaxDelay
+	}
+	return attempt.LastFailureAt.Add(delay).Sub(now)
+}
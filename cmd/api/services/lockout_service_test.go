@@ -0,0 +1,191 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+func newTestLockoutService(users *fakeUserRepository, now *time.Time) *LockoutService {
+	service := NewLockoutService(repositories.NewMemoryLoginAttemptRepository(), users, config.LoginLockoutConfig{
+		MaxAccountFailures: 5,
+		MaxIPFailures:      8,
+		DelayAfterFailures: 2,
+		BaseDelay:          time.Second,
+		MaxDelay:           4 * time.Second,
+		FailureWindow:      time.Hour,
+		LockoutDuration:    15 * time.Minute,
+	})
+	service.now = func() time.Time { return *now }
+	return service
+}
+
+func fromIP(ip string) context.Context {
+	return utils.WithClientInfo(context.Background(), utils.ClientInfo{IPAddress: ip})
+}
+
+func assertRetryAfter(t *testing.T, err error, retryAfter time.Duration) {
+	t.Helper()
+	var apiErr *utils.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != http.StatusTooManyRequests {
+		t.Fatalf("expected too many requests, got %v", err)
+	}
+	if apiErr.RetryAfter != retryAfter {
+		t.Errorf("expected retry after %v, got %v", retryAfter, apiErr.RetryAfter)
+	}
+}
+
+func TestLockoutService(t *testing.T) {
+
+	t.Run("delays grow with each failure", func(t *testing.T) {
+		now := time.Now()
+		service := newTestLockoutService(newFakeUserRepository(), &now)
+		ctx := fromIP("190.0.2.10")
+
+		for range 2 {
+			if err := service.Check(ctx, "ana@example.com"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			service.RegisterFailure(ctx, "ana@example.com")
+		}
+		assertRetryAfter(t, service.Check(ctx, "ANA@example.com"), time.Second)
+
+		for _, delay := range []time.Duration{2 * time.Second, 4 * time.Second} {
+			now = now.Add(delay / 2)
+			if err := service.Check(ctx, "ana@example.com"); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			service.RegisterFailure(ctx, "ana@example.com")
+			assertRetryAfter(t, service.Check(ctx, "ana@example.com"), delay)
+		}
+	})
+
+	t.Run("locks the account after the threshold", func(t *testing.T) {
+		now := time.Now()
+		service := newTestLockoutService(newFakeUserRepository(), &now)
+
+		for range 5 {
+			service.RegisterFailure(fromIP("190.0.2.10"), "nobody@example.com")
+		}
+
+		assertRetryAfter(t, service.Check(fromIP("190.0.2.99"), "nobody@example.com"), 15*time.Minute)
+		if err := service.Check(fromIP("190.0.2.99"), "ana@example.com"); err != nil {
+			t.Errorf("expected other accounts to keep working, got %v", err)
+		}
+
+		now = now.Add(15 * time.Minute)
+		if err := service.Check(fromIP("190.0.2.99"), "nobody@example.com"); err != nil {
+			t.Errorf("expected the lockout to expire, got %v", err)
+		}
+	})
+
+	t.Run("locks the ip across accounts", func(t *testing.T) {
+		now := time.Now()
+		service := newTestLockoutService(newFakeUserRepository(), &now)
+
+		for _, email := range []string{"a@example.com", "b@example.com", "c@example.com", "d@example.com"} {
+			service.RegisterFailure(fromIP("190.0.2.10"), email)
+			service.RegisterFailure(fromIP("190.0.2.10"), email)
+		}
+
+		assertRetryAfter(t, service.Check(fromIP("190.0.2.10"), "e@example.com"), 15*time.Minute)
+		if err := service.Check(fromIP("190.0.2.11"), "e@example.com"); err != nil {
+			t.Errorf("expected other clients to keep working, got %v", err)
+		}
+	})
+
+	t.Run("forgets failures outside the window", func(t *testing.T) {
+		now := time.Now()
+		service := newTestLockoutService(newFakeUserRepository(), &now)
+
+		for range 4 {
+			service.RegisterFailure(context.Background(), "ana@example.com")
+		}
+		now = now.Add(time.Hour + time.Second)
+		if err := service.Check(context.Background(), "ana@example.com"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		service.RegisterFailure(context.Background(), "ana@example.com")
+		if err := service.Check(context.Background(), "ana@example.com"); err != nil {
+			t.Errorf("expected the count to start over, got %v", err)
+		}
+	})
+
+	t.Run("purges stale counters but keeps active locks", func(t *testing.T) {
+		now := time.Now()
+		service := newTestLockoutService(newFakeUserRepository(), &now)
+		service.config.LockoutDuration = 2 * time.Hour
+
+		for range 5 {
+			service.RegisterFailure(context.Background(), "ana@example.com")
+		}
+		service.RegisterFailure(context.Background(), "bob@example.com")
+		now = now.Add(time.Hour + time.Second)
+
+		if err := service.PurgeStale(context.Background()); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if attempt, _ := service.LoginAttemptRepository.Find(context.Background(), "account:bob@example.com"); attempt != nil {
+			t.Errorf("expected the stale counter to be purged: %+v", attempt)
+		}
+		if attempt, _ := service.LoginAttemptRepository.Find(context.Background(), "account:ana@example.com"); attempt == nil {
+			t.Errorf("expected the locked account to keep its counter")
+		}
+	})
+
+	t.Run("admin unlock", func(t *testing.T) {
+		users := newFakeUserRepository()
+		user := &models.User{Email: "ana@example.com"}
+		_ = users.Create(context.Background(), user)
+		now := time.Now()
+		service := newTestLockoutService(users, &now)
+
+		for range 5 {
+			service.RegisterFailure(context.Background(), "ana@example.com")
+		}
+		if err := service.Unlock(context.Background(), user.ID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := service.Check(context.Background(), "ana@example.com"); err != nil {
+			t.Errorf("expected the account to be unlocked, got %v", err)
+		}
+
+		assertAPIStatus(t, service.Unlock(context.Background(), 999), http.StatusNotFound)
+	})
+
+	t.Run("login is rejected while locked and a password reset unlocks it", func(t *testing.T) {
+		users := newFakeUserRepository()
+		hash, _ := newTestPasswordHasher().Hash("s3cret-password")
+		user := &models.User{Email: "ana@example.com", PasswordHash: hash, EmailVerified: true}
+		_ = users.Create(context.Background(), user)
+		now := time.Now()
+		service := newTestAuthService(users, &now)
+		ctx := fromIP("190.0.2.10")
+
+		for range 5 {
+			_, err := service.Login(ctx, dto.LoginRequest{Email: "ana@example.com", Password: "wrong"})
+			assertAPIStatus(t, err, http.StatusUnauthorized)
+			now = now.Add(5 * time.Second)
+		}
+		_, err := service.Login(ctx, dto.LoginRequest{Email: "ana@example.com", Password: "s3cret-password"})
+		assertRetryAfter(t, err, 15*time.Minute-5*time.Second)
+
+		passwords := NewPasswordService(users, fakeTransactor{}, service.VerificationService, service.RefreshTokenService, service.LockoutService, newTestPasswordPolicyService(), newTestPasswordHasher(), &fakeMailer{})
+		token, _ := service.VerificationService.IssueToken(context.Background(), user.ID, models.CodePurposePasswordReset, time.Hour)
+		if err := passwords.ResetPassword(context.Background(), dto.ResetPasswordRequest{Token: token, Password: "new-password"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := service.Login(ctx, dto.LoginRequest{Email: "ana@example.com", Password: "new-password"}); err != nil {
+			t.Errorf("expected the reset to unlock the account, got %v", err)
+		}
+	})
+}
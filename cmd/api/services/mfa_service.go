@@ -0,0 +1,241 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type IMFAService interface {
+	IsEnabled(ctx context.Context, userID uint) (bool, error)
+	Status(ctx context.Context, userID uint) (*dto.MFAStatusResponse, error)
+	EnrollTOTP(ctx context.Context, userID uint) (*dto.TOTPEnrollmentResponse, error)
+	ConfirmTOTP(ctx context.Context, userID uint, request dto.TOTPConfirmRequest) (*dto.RecoveryCodesResponse, error)
+	Verify(ctx context.Context, userID uint, request dto.MFACodeRequest) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uint, request dto.MFACodeRequest) (*dto.RecoveryCodesResponse, error)
+	Disable(ctx context.Context, userID uint, request dto.MFACodeRequest) error
+	Reset(ctx context.Context, userID uint) error
+}
+
+type MFAService struct {
+	UserRepository           repositories.IUserRepository
+	TOTPCredentialRepository repositories.ITOTPCredentialRepository
+	RecoveryCodeRepository   repositories.IRecoveryCodeRepository
+	Issuer                   string
+	now                      func() time.Time
+}
+
+func NewMFAService(
+	userRepository repositories.IUserRepository,
+	totpCredentialRepository repositories.ITOTPCredentialRepository,
+	recoveryCodeRepository repositories.IRecoveryCodeRepository,
+	authConfig config.AuthTokenConfig,
+) *MFAService {
+	return &MFAService{
+		UserRepository:           userRepository,
+		TOTPCredentialRepository: totpCredentialRepository,
+		RecoveryCodeRepository:   recoveryCodeRepository,
+		Issuer:                   authConfig.Issuer,
+		now:                      time.Now,
+	}
+}
+
+func (s *MFAService) IsEnabled(ctx context.Context, userID uint) (bool, error) {
+	credential, err := s.TOTPCredentialRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	return credential != nil && credential.IsConfirmed(), nil
+}
+
+func (s *MFAService) Status(ctx context.Context, userID uint) (*dto.MFAStatusResponse, error) {
+	credential, err := s.TOTPCredentialRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding totp credential: ", err)
+		return nil, utils.NewInternalServerError("could not get two-factor status")
+	}
+
+	response := &dto.MFAStatusResponse{Methods: []string{}}
+	if credential != nil && credential.IsConfirmed() {
+		response.Enabled = true
+		response.Methods = []string{constants.MFAMethodTOTP, constants.MFAMethodRecoveryCode}
+		response.ConfirmedAt = credential.ConfirmedAt
+	}
+	return response, nil
+}
+
+// EnrollTOTP genera un secreto nuevo que no protege el login hasta que el usuario lo confirma con un código.
+// Repetir la inscripción antes de confirmar reemplaza el secreto pendiente.
+func (s *MFAService) EnrollTOTP(ctx context.Context, userID uint) (*dto.TOTPEnrollmentResponse, error) {
+	user, err := s.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return nil, utils.NewInternalServerError("could not enroll two-factor authentication")
+	}
+	if user == nil {
+		return nil, utils.NewNotFoundError("user not found")
+	}
+
+	credential, err := s.TOTPCredentialRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding totp credential: ", err)
+		return nil, utils.NewInternalServerError("could not enroll two-factor authentication")
+	}
+	if credential != nil && credential.IsConfirmed() {
+		return nil, utils.NewConflictError("two-factor authentication already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		log.Error(ctx, "error generating totp secret: ", err)
+		return nil, utils.NewInternalServerError("could not enroll two-factor authentication")
+	}
+
+	if err := s.TOTPCredentialRepository.Save(ctx, &models.TOTPCredential{UserID: userID, Secret: secret}); err != nil {
+		log.Error(ctx, "error saving totp credential: ", err)
+		return nil, utils.NewInternalServerError("could not enroll two-factor authentication")
+	}
+
+	return &dto.TOTPEnrollmentResponse{
+		Secret:     secret,
+		OTPAuthURI: utils.TOTPURI(s.Issuer, user.Email, secret),
+	}, nil
+}
+
+func (s *MFAService) ConfirmTOTP(ctx context.Context, userID uint, request dto.TOTPConfirmRequest) (*dto.RecoveryCodesResponse, error) {
+	credential, err := s.TOTPCredentialRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding totp credential: ", err)
+		return nil, utils.NewInternalServerError("could not confirm two-factor authentication")
+	}
+	if credential == nil {
+		return nil, utils.NewBadRequestError("no pending two-factor enrollment")
+	}
+	if credential.IsConfirmed() {
+		return nil, utils.NewConflictError("two-factor authentication already enabled")
+	}
+
+	now := s.now()
+	step, ok := utils.ValidateTOTP(credential.Secret, request.Code, now, constants.TOTPAllowedSkew)
+	if !ok {
+		return nil, utils.NewBadRequestError("invalid two-factor code")
+	}
+
+	credential.ConfirmedAt = &now
+	credential.LastUsedStep = step
+	if err := s.TOTPCredentialRepository.Save(ctx, credential); err != nil {
+		log.Error(ctx, "error saving totp credential: ", err)
+		return nil, utils.NewInternalServerError("could not confirm two-factor authentication")
+	}
+
+	return s.issueRecoveryCodes(ctx, userID)
+}
+
+// Verify acepta un código TOTP no usado antes o un código de recuperación, que queda consumido.
+func (s *MFAService) Verify(ctx context.Context, userID uint, request dto.MFACodeRequest) error {
+	credential, err := s.TOTPCredentialRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding totp credential: ", err)
+		return utils.NewInternalServerError("could not verify two-factor code")
+	}
+	if credential == nil || !credential.IsConfirmed() {
+		return utils.NewBadRequestError("two-factor authentication is not enabled")
+	}
+
+	if request.Code != "" {
+		return s.verifyTOTP(ctx, credential, request.Code)
+	}
+	return s.redeemRecoveryCode(ctx, userID, request.RecoveryCode)
+}
+
+func (s *MFAService) RegenerateRecoveryCodes(ctx context.Context, userID uint, request dto.MFACodeRequest) (*dto.RecoveryCodesResponse, error) {
+	if err := s.Verify(ctx, userID, request); err != nil {
+		return nil, err
+	}
+	return s.issueRecoveryCodes(ctx, userID)
+}
+
+func (s *MFAService) Disable(ctx context.Context, userID uint, request dto.MFACodeRequest) error {
+	if err := s.Verify(ctx, userID, request); err != nil {
+		return err
+	}
+	return s.Reset(ctx, userID)
+}
+
+// Reset quita el 2FA sin pedir código; es para que un administrador ayude a quien perdió el dispositivo y los
+// códigos de recuperación.
+func (s *MFAService) Reset(ctx context.Context, userID uint) error {
+	if err := s.TOTPCredentialRepository.DeleteByUserID(ctx, userID); err != nil {
+		log.Error(ctx, "error deleting totp credential: ", err)
+		return utils.NewInternalServerError("could not disable two-factor authentication")
+	}
+	if err := s.RecoveryCodeRepository.DeleteByUserID(ctx, userID); err != nil {
+		log.Error(ctx, "error deleting recovery codes: ", err)
+		return utils.NewInternalServerError("could not disable two-factor authentication")
+	}
+	return nil
+}
+
+func (s *MFAService) verifyTOTP(ctx context.Context, credential *models.TOTPCredential, code string) error {
+	step, ok := utils.ValidateTOTP(credential.Secret, code, s.now(), constants.TOTPAllowedSkew)
+	if !ok || step <= credential.LastUsedStep {
+		return utils.NewUnauthorizedError("invalid two-factor code")
+	}
+
+	if err := s.TOTPCredentialRepository.MarkStepUsed(ctx, credential.ID, step); err != nil {
+		if errors.Is(err, repositories.ErrCodeAlreadyUsed) {
+			return utils.NewUnauthorizedError("invalid two-factor code")
+		}
+		log.Error(ctx, "error marking totp step as used: ", err)
+		return utils.NewInternalServerError("could not verify two-factor code")
+	}
+	return nil
+}
+
+func (s *MFAService) redeemRecoveryCode(ctx context.Context, userID uint, code string) error {
+	stored, err := s.RecoveryCodeRepository.FindActiveByHash(ctx, userID, utils.HashToken(utils.NormalizeRecoveryCode(code)))
+	if err != nil {
+		log.Error(ctx, "error finding recovery code: ", err)
+		return utils.NewInternalServerError("could not verify two-factor code")
+	}
+	if stored == nil {
+		return utils.NewUnauthorizedError("invalid two-factor code")
+	}
+
+	if err := s.RecoveryCodeRepository.MarkUsed(ctx, stored.ID, s.now()); err != nil {
+		return utils.NewUnauthorizedError("invalid two-factor code")
+	}
+	return nil
+}
+
+// issueRecoveryCodes reemplaza los códigos de recuperación del usuario; los devuelve en claro una única vez.
+func (s *MFAService) issueRecoveryCodes(ctx context.Context, userID uint) (*dto.RecoveryCodesResponse, error) {
+	response := &dto.RecoveryCodesResponse{RecoveryCodes: make([]string, 0, constants.RecoveryCodeCount)}
+	codes := make([]models.RecoveryCode, 0, constants.RecoveryCodeCount)
+	for i := 0; i < constants.RecoveryCodeCount; i++ {
+		code, err := utils.GenerateRecoveryCode()
+		if err != nil {
+			log.Error(ctx, "error generating recovery code: ", err)
+			return nil, utils.NewInternalServerError("could not generate recovery codes")
+		}
+		response.RecoveryCodes = append(response.RecoveryCodes, code)
+		codes = append(codes, models.RecoveryCode{
+			UserID:   userID,
+			CodeHash: utils.HashToken(utils.NormalizeRecoveryCode(code)),
+		})
+	}
+
+	if err := s.RecoveryCodeRepository.ReplaceAll(ctx, userID, codes); err != nil {
+		log.Error(ctx, "error saving recovery codes: ", err)
+		return nil, utils.NewInternalServerError("could not generate recovery codes")
+	}
+	return response, nil
+}
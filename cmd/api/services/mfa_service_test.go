@@ -0,0 +1,248 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+// enrollTestTOTP inscribe y confirma TOTP para el usuario 1 y devuelve el secreto y los códigos de recuperación.
+func enrollTestTOTP(t *testing.T, service *MFAService, now *time.Time) (string, []string) {
+	t.Helper()
+
+	enrollment, err := service.EnrollTOTP(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	code, _ := utils.TOTPCode(enrollment.Secret, utils.TOTPStep(*now))
+	recovery, err := service.ConfirmTOTP(context.Background(), 1, dto.TOTPConfirmRequest{Code: code})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Avanza el reloj para que el siguiente código no sea el ya usado al confirmar.
+	*now = now.Add(30 * time.Second)
+	return enrollment.Secret, recovery.RecoveryCodes
+}
+
+func assertAPIStatus(t *testing.T, err error, status int) {
+	t.Helper()
+
+	var apiErr *utils.APIError
+	if !errors.As(err, &apiErr) || apiErr.Status != status {
+		t.Errorf("unexpected error: got %v, want status %d", err, status)
+	}
+}
+
+func TestMFAService(t *testing.T) {
+
+	t.Run("enroll and confirm", func(t *testing.T) {
+		now := time.Unix(1_700_000_000, 0)
+		users := newFakeUserRepository()
+		_ = users.Create(context.Background(), &models.User{Email: "ana@example.com"})
+		service := newTestMFAService(users, &now)
+
+		enrollment, err := service.EnrollTOTP(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !strings.HasPrefix(enrollment.OTPAuthURI, "otpauth://totp/tareaya:ana@example.com?") {
+			t.Errorf("unexpected uri: %s", enrollment.OTPAuthURI)
+		}
+		if enabled, _ := service.IsEnabled(context.Background(), 1); enabled {
+			t.Errorf("expected two-factor to stay disabled until confirmed")
+		}
+
+		_, err = service.ConfirmTOTP(context.Background(), 1, dto.TOTPConfirmRequest{Code: "000000"})
+		assertAPIStatus(t, err, http.StatusBadRequest)
+
+		code, _ := utils.TOTPCode(enrollment.Secret, utils.TOTPStep(now))
+		recovery, err := service.ConfirmTOTP(context.Background(), 1, dto.TOTPConfirmRequest{Code: code})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(recovery.RecoveryCodes) != 10 {
+			t.Errorf("unexpected recovery codes: %v", recovery.RecoveryCodes)
+		}
+		if enabled, _ := service.IsEnabled(context.Background(), 1); !enabled {
+			t.Errorf("expected two-factor to be enabled")
+		}
+
+		_, err = service.EnrollTOTP(context.Background(), 1)
+		assertAPIStatus(t, err, http.StatusConflict)
+	})
+
+	t.Run("verify totp rejects replay", func(t *testing.T) {
+		now := time.Unix(1_700_000_000, 0)
+		users := newFakeUserRepository()
+		_ = users.Create(context.Background(), &models.User{Email: "ana@example.com"})
+		service := newTestMFAService(users, &now)
+		secret, _ := enrollTestTOTP(t, service, &now)
+
+		code, _ := utils.TOTPCode(secret, utils.TOTPStep(now))
+		if err := service.Verify(context.Background(), 1, dto.MFACodeRequest{Code: code}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertAPIStatus(t, service.Verify(context.Background(), 1, dto.MFACodeRequest{Code: code}), http.StatusUnauthorized)
+
+		previous, _ := utils.TOTPCode(secret, utils.TOTPStep(now)-1)
+		assertAPIStatus(t, service.Verify(context.Background(), 1, dto.MFACodeRequest{Code: previous}), http.StatusUnauthorized)
+	})
+
+	t.Run("recovery codes are single use", func(t *testing.T) {
+		now := time.Unix(1_700_000_000, 0)
+		users := newFakeUserRepository()
+		_ = users.Create(context.Background(), &models.User{Email: "ana@example.com"})
+		service := newTestMFAService(users, &now)
+		_, codes := enrollTestTOTP(t, service, &now)
+
+		request := dto.MFACodeRequest{RecoveryCode: strings.ToUpper(codes[0])}
+		if err := service.Verify(context.Background(), 1, request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertAPIStatus(t, service.Verify(context.Background(), 1, request), http.StatusUnauthorized)
+
+		regenerated, err := service.RegenerateRecoveryCodes(context.Background(), 1, dto.MFACodeRequest{RecoveryCode: codes[1]})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertAPIStatus(t, service.Verify(context.Background(), 1, dto.MFACodeRequest{RecoveryCode: codes[2]}), http.StatusUnauthorized)
+		if err := service.Verify(context.Background(), 1, dto.MFACodeRequest{RecoveryCode: regenerated.RecoveryCodes[0]}); err != nil {
+			t.Errorf("expected regenerated code to be accepted: %v", err)
+		}
+	})
+
+	t.Run("disable and reset", func(t *testing.T) {
+		now := time.Unix(1_700_000_000, 0)
+		users := newFakeUserRepository()
+		_ = users.Create(context.Background(), &models.User{Email: "ana@example.com"})
+		service := newTestMFAService(users, &now)
+		secret, _ := enrollTestTOTP(t, service, &now)
+
+		assertAPIStatus(t, service.Disable(context.Background(), 1, dto.MFACodeRequest{Code: "000000"}), http.StatusUnauthorized)
+
+		code, _ := utils.TOTPCode(secret, utils.TOTPStep(now))
+		if err := service.Disable(context.Background(), 1, dto.MFACodeRequest{Code: code}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if enabled, _ := service.IsEnabled(context.Background(), 1); enabled {
+			t.Errorf("expected two-factor to be disabled")
+		}
+
+		enrollTestTOTP(t, service, &now)
+		if err := service.Reset(context.Background(), 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		status, _ := service.Status(context.Background(), 1)
+		if status.Enabled {
+			t.Errorf("expected two-factor to be reset")
+		}
+	})
+}
+
+type fakeTOTPCredentialRepository struct {
+	credentials map[uint]*models.TOTPCredential
+	nextID      uint
+}
+
+func newFakeTOTPCredentialRepository() *fakeTOTPCredentialRepository {
+	return &fakeTOTPCredentialRepository{credentials: map[uint]*models.TOTPCredential{}, nextID: 1}
+}
+
+func (r *fakeTOTPCredentialRepository) FindByUserID(_ context.Context, userID uint) (*models.TOTPCredential, error) {
+	credential, ok := r.credentials[userID]
+	if !ok {
+		return nil, nil
+	}
+	found := *credential
+	return &found, nil
+}
+
+func (r *fakeTOTPCredentialRepository) Save(_ context.Context, credential *models.TOTPCredential) error {
+	if existing, ok := r.credentials[credential.UserID]; ok {
+		credential.ID = existing.ID
+	} else {
+		credential.ID = r.nextID
+		r.nextID++
+	}
+	stored := *credential
+	r.credentials[credential.UserID] = &stored
+	return nil
+}
+
+func (r *fakeTOTPCredentialRepository) MarkStepUsed(_ context.Context, id uint, step int64) error {
+	for _, credential := range r.credentials {
+		if credential.ID == id && credential.LastUsedStep < step {
+			credential.LastUsedStep = step
+			return nil
+		}
+	}
+	return repositories.ErrCodeAlreadyUsed
+}
+
+func (r *fakeTOTPCredentialRepository) DeleteByUserID(_ context.Context, userID uint) error {
+	delete(r.credentials, userID)
+	return nil
+}
+
+type fakeRecoveryCodeRepository struct {
+	codes  map[uint][]*models.RecoveryCode
+	nextID uint
+}
+
+func newFakeRecoveryCodeRepository() *fakeRecoveryCodeRepository {
+	return &fakeRecoveryCodeRepository{codes: map[uint][]*models.RecoveryCode{}, nextID: 1}
+}
+
+func (r *fakeRecoveryCodeRepository) ReplaceAll(_ context.Context, userID uint, codes []models.RecoveryCode) error {
+	r.codes[userID] = nil
+	for i := range codes {
+		codes[i].ID = r.nextID
+		r.nextID++
+		code := codes[i]
+		r.codes[userID] = append(r.codes[userID], &code)
+	}
+	return nil
+}
+
+func (r *fakeRecoveryCodeRepository) FindActiveByHash(_ context.Context, userID uint, codeHash string) (*models.RecoveryCode, error) {
+	for _, code := range r.codes[userID] {
+		if code.CodeHash == codeHash && code.UsedAt == nil {
+			found := *code
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRecoveryCodeRepository) MarkUsed(_ context.Context, id uint, usedAt time.Time) error {
+	for _, codes := range r.codes {
+		for _, code := range codes {
+			if code.ID == id && code.UsedAt == nil {
+				code.UsedAt = &usedAt
+				return nil
+			}
+		}
+	}
+	return repositories.ErrCodeAlreadyUsed
+}
+
+func (r *fakeRecoveryCodeRepository) DeleteByUserID(_ context.Context, userID uint) error {
+	delete(r.codes, userID)
+	return nil
+}
+
+func newTestMFAService(users *fakeUserRepository, now *time.Time) *MFAService {
+	service := NewMFAService(users, newFakeTOTPCredentialRepository(), newFakeRecoveryCodeRepository(), config.AuthTokenConfig{Issuer: "tareaya"})
+	service.now = func() time.Time { return *now }
+	return service
+}
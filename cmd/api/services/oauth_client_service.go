@@ -0,0 +1,150 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type IOAuthClientService interface {
+	CreateClient(ctx context.Context, request dto.CreateOAuthClientRequest) (*dto.OAuthClientResponse, error)
+	ListClients(ctx context.Context) ([]models.OAuthClient, error)
+	DeleteClient(ctx context.Context, clientID string) error
+}
+
+type OAuthClientService struct {
+	OAuthClientRepository repositories.IOAuthClientRepository
+}
+
+func NewOAuthClientService(oauthClientRepository repositories.IOAuthClientRepository) *OAuthClientService {
+	return &OAuthClientService{OAuthClientRepository: oauthClientRepository}
+}
+
+// CreateClient registra el cliente y devuelve su secreto en claro, que solo se muestra esta vez.
+func (s *OAuthClientService) CreateClient(ctx context.Context, request dto.CreateOAuthClientRequest) (*dto.OAuthClientResponse, error) {
+	if err := validateClientRequest(request); err != nil {
+		return nil, err
+	}
+
+	clientID, err := utils.GenerateToken(constants.OAuthClientIDSize)
+	if err != nil {
+		log.Error(ctx, "error generating client id: ", err)
+		return nil, utils.NewInternalServerError("could not create client")
+	}
+
+	client := &models.OAuthClient{
+		ClientID:     clientID,
+		Name:         strings.TrimSpace(request.Name),
+		RedirectURIs: strings.Join(request.RedirectURIs, " "),
+		GrantTypes:   strings.Join(request.GrantTypes, " "),
+		Scopes:       strings.Join(request.Scopes, " "),
+		Public:       request.Public,
+		FirstParty:   request.FirstParty,
+	}
+
+	var secret string
+	if !client.Public {
+		if secret, err = utils.GenerateToken(constants.OAuthClientSecretSize); err != nil {
+			log.Error(ctx, "error generating client secret: ", err)
+			return nil, utils.NewInternalServerError("could not create client")
+		}
+		client.SecretHash = utils.HashToken(secret)
+	}
+
+	if err := s.OAuthClientRepository.Create(ctx, client); err != nil {
+		log.Error(ctx, "error creating oauth client: ", err)
+		return nil, utils.NewInternalServerError("could not create client")
+	}
+
+	response := dto.NewOAuthClientResponse(client)
+	response.ClientSecret = secret
+	return &response, nil
+}
+
+func (s *OAuthClientService) ListClients(ctx context.Context) ([]models.OAuthClient, error) {
+	clients, err := s.OAuthClientRepository.FindAll(ctx)
+	if err != nil {
+		log.Error(ctx, "error listing oauth clients: ", err)
+		return nil, utils.NewInternalServerError("could not list clients")
+	}
+	return clients, nil
+}
+
+func (s *OAuthClientService) DeleteClient(ctx context.Context, clientID string) error {
+	if err := s.OAuthClientRepository.Delete(ctx, clientID); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return utils.NewNotFoundError("client not found")
+		}
+		log.Error(ctx, "error deleting oauth client: ", err)
+		return utils.NewInternalServerError("could not delete client")
+	}
+	return nil
+}
+
+func validateClientRequest(request dto.CreateOAuthClientRequest) error {
+	usesCode := slices.Contains(request.GrantTypes, constants.GrantTypeAuthorizationCode)
+	if usesCode && len(request.RedirectURIs) == 0 {
+		return utils.NewBadRequestError("authorization_code clients need at least one redirect uri")
+	}
+	if slices.Contains(request.GrantTypes, constants.GrantTypeRefreshToken) && !usesCode {
+		return utils.NewBadRequestError("refresh_token requires the authorization_code grant")
+	}
+	if request.Public && slices.Contains(request.GrantTypes, constants.GrantTypeClientCredentials) {
+		return utils.NewBadRequestError("public clients cannot use client_credentials")
+	}
+
+	for _, redirectURI := range request.RedirectURIs {
+		if !validRedirectURI(redirectURI) {
+			return utils.NewBadRequestError("invalid redirect uri: " + redirectURI)
+		}
+	}
+	for _, scope := range request.Scopes {
+		if scope == "" || strings.ContainsAny(scope, " \"\\") {
+			return utils.NewBadRequestError("invalid scope: " + scope)
+		}
+	}
+	return nil
+}
+
+// validRedirectURI acepta URIs absolutas sin fragmento (RFC 6749 3.1.2). http solo se permite en loopback y, de
+// los demás esquemas, solo los privados de las apps móviles en notación de dominio invertido (RFC 8252 7.1,
+// com.tareaya.app:/callback); así quedan fuera javascript:, data: o file:.
+func validRedirectURI(redirectURI string) bool {
+	parsed, err := url.Parse(redirectURI)
+	if err != nil || parsed.Scheme == "" || parsed.Fragment != "" || strings.Contains(redirectURI, " ") {
+		return false
+	}
+
+	switch parsed.Scheme {
+	case "https":
+		return parsed.Host != ""
+	case "http":
+		host := parsed.Hostname()
+		return host == "localhost" || host == "127.0.0.1" || host == "::1"
+	default:
+		return isPrivateUseScheme(parsed.Scheme) && parsed.Opaque == ""
+	}
+}
+
+// isPrivateUseScheme comprueba que el esquema tenga al menos dos etiquetas no vacías separadas por puntos.
+func isPrivateUseScheme(scheme string) bool {
+	labels := strings.Split(scheme, ".")
+	if len(labels) < 2 {
+		return false
+	}
+	for _, label := range labels {
+		if label == "" {
+			return false
+		}
+	}
+	return true
+}
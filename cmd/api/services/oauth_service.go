@@ -0,0 +1,487 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type IOAuthService interface {
+	Authorize(ctx context.Context, userID uint, request dto.AuthorizeRequest) (*dto.AuthorizeResponse, error)
+	Consent(ctx context.Context, userID uint, request dto.ConsentRequest) (*dto.AuthorizeResponse, error)
+	Token(ctx context.Context, request dto.TokenRequest) (*dto.TokenResponse, error)
+	AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*models.OAuthClient, error)
+}
+
+type OAuthService struct {
+	UserRepository              repositories.IUserRepository
+	OAuthClientRepository       repositories.IOAuthClientRepository
+	AuthorizationCodeRepository repositories.IOAuthAuthorizationCodeRepository
+	ConsentRepository           repositories.IOAuthConsentRepository
+	TokenService                ITokenService
+	RefreshTokenService         IRefreshTokenService
+	// CodeRetention es cuánto se guardan los códigos ya vencidos para reconocer un reúso mientras la familia de
+	// refresh tokens que emitieron siga viva.
+	CodeRetention time.Duration
+	now           func() time.Time
+}
+
+func NewOAuthService(
+	userRepository repositories.IUserRepository,
+	oauthClientRepository repositories.IOAuthClientRepository,
+	authorizationCodeRepository repositories.IOAuthAuthorizationCodeRepository,
+	consentRepository repositories.IOAuthConsentRepository,
+	tokenService ITokenService,
+	refreshTokenService IRefreshTokenService,
+	authConfig config.AuthTokenConfig,
+) *OAuthService {
+	return &OAuthService{
+		UserRepository:              userRepository,
+		OAuthClientRepository:       oauthClientRepository,
+		AuthorizationCodeRepository: authorizationCodeRepository,
+		ConsentRepository:           consentRepository,
+		TokenService:                tokenService,
+		RefreshTokenService:         refreshTokenService,
+		CodeRetention:               authConfig.RefreshTokenTTL,
+		now:                         time.Now,
+	}
+}
+
+// Authorize valida la petición de /oauth/authorize para el usuario autenticado. Si el cliente es propio o el
+// usuario ya consintió los scopes pedidos, emite el código; si no, devuelve lo que hay que mostrarle para
+// consentir. Un client_id o redirect_uri inválidos son errores; el resto se devuelven en la redirección.
+func (s *OAuthService) Authorize(ctx context.Context, userID uint, request dto.AuthorizeRequest) (*dto.AuthorizeResponse, error) {
+	client, redirectURI, err := s.resolveClient(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := validateAuthorizeRequest(client, request)
+	if err != nil {
+		return redirectError(client, redirectURI, request.State, err), nil
+	}
+
+	if !client.FirstParty {
+		consent, err := s.ConsentRepository.Find(ctx, userID, client.ClientID)
+		if err != nil {
+			log.Error(ctx, "error finding oauth consent: ", err)
+			return nil, utils.NewInternalServerError("could not authorize client")
+		}
+		if consent == nil || !containsAll(consent.ScopeList(), scopes) {
+			return &dto.AuthorizeResponse{
+				ConsentRequired: true,
+				ClientID:        client.ClientID,
+				ClientName:      client.Name,
+				Scopes:          scopes,
+			}, nil
+		}
+	}
+
+	return s.grantCode(ctx, userID, client, redirectURI, scopes, request)
+}
+
+// Consent registra la decisión del usuario; si la aprueba, guarda los scopes consentidos y emite el código.
+func (s *OAuthService) Consent(ctx context.Context, userID uint, request dto.ConsentRequest) (*dto.AuthorizeResponse, error) {
+	client, redirectURI, err := s.resolveClient(ctx, request.AuthorizeRequest)
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := validateAuthorizeRequest(client, request.AuthorizeRequest)
+	if err != nil {
+		return redirectError(client, redirectURI, request.State, err), nil
+	}
+	if !request.Approve {
+		return redirectError(client, redirectURI, request.State, utils.NewOAuthError("access_denied", "the user denied the request")), nil
+	}
+
+	granted := scopes
+	consent, err := s.ConsentRepository.Find(ctx, userID, client.ClientID)
+	if err != nil {
+		log.Error(ctx, "error finding oauth consent: ", err)
+		return nil, utils.NewInternalServerError("could not authorize client")
+	}
+	if consent != nil {
+		granted = mergeScopes(consent.ScopeList(), scopes)
+	}
+	err = s.ConsentRepository.Save(ctx, &models.OAuthConsent{
+		UserID:   userID,
+		ClientID: client.ClientID,
+		Scope:    strings.Join(granted, " "),
+	})
+	if err != nil {
+		log.Error(ctx, "error saving oauth consent: ", err)
+		return nil, utils.NewInternalServerError("could not authorize client")
+	}
+
+	return s.grantCode(ctx, userID, client, redirectURI, scopes, request.AuthorizeRequest)
+}
+
+// Token implementa /oauth/token para los grants authorization_code, refresh_token y client_credentials. Los
+// errores son *utils.APIError con el código de RFC 6749 5.2.
+func (s *OAuthService) Token(ctx context.Context, request dto.TokenRequest) (*dto.TokenResponse, error) {
+	switch request.GrantType {
+	case constants.GrantTypeAuthorizationCode, constants.GrantTypeRefreshToken, constants.GrantTypeClientCredentials:
+	default:
+		return nil, utils.NewOAuthError("unsupported_grant_type", "unsupported grant type")
+	}
+
+	client, err := s.AuthenticateClient(ctx, request.ClientID, request.ClientSecret)
+	if err != nil {
+		return nil, err
+	}
+	if !client.AllowsGrantType(request.GrantType) {
+		return nil, utils.NewOAuthError("unauthorized_client", "the client is not allowed to use this grant type")
+	}
+
+	switch request.GrantType {
+	case constants.GrantTypeAuthorizationCode:
+		return s.exchangeCode(ctx, client, request)
+	case constants.GrantTypeRefreshToken:
+		return s.refresh(ctx, client, request)
+	default:
+		return s.clientCredentials(ctx, client, request)
+	}
+}
+
+// resolveClient busca el cliente y la redirect_uri exacta registrada. Sin redirect_uri solo se acepta un
+// cliente con una única URI registrada.
+func (s *OAuthService) resolveClient(ctx context.Context, request dto.AuthorizeRequest) (*models.OAuthClient, string, error) {
+	client, err := s.OAuthClientRepository.FindByClientID(ctx, request.ClientID)
+	if err != nil {
+		log.Error(ctx, "error finding oauth client: ", err)
+		return nil, "", utils.NewInternalServerError("could not authorize client")
+	}
+	if client == nil {
+		return nil, "", utils.NewBadRequestError("invalid client_id")
+	}
+
+	registered := client.RedirectURIList()
+	if request.RedirectURI == "" && len(registered) == 1 {
+		return client, registered[0], nil
+	}
+	if request.RedirectURI == "" || !slices.Contains(registered, request.RedirectURI) {
+		return nil, "", utils.NewBadRequestError("invalid redirect_uri")
+	}
+	return client, request.RedirectURI, nil
+}
+
+// validateAuthorizeRequest exige PKCE con S256 a todos los clientes y devuelve los scopes pedidos.
+func validateAuthorizeRequest(client *models.OAuthClient, request dto.AuthorizeRequest) ([]string, error) {
+	if request.ResponseType != constants.ResponseTypeCode {
+		return nil, utils.NewOAuthError("unsupported_response_type", "only the code response type is supported")
+	}
+	if !client.AllowsGrantType(constants.GrantTypeAuthorizationCode) {
+		return nil, utils.NewOAuthError("unauthorized_client", "the client is not allowed to use the authorization code grant")
+	}
+	if request.CodeChallenge == "" || request.CodeChallengeMethod != constants.CodeChallengeMethodS256 {
+		return nil, utils.NewOAuthError("invalid_request", "code_challenge with code_challenge_method S256 is required")
+	}
+
+	scopes, ok := resolveScopes(request.Scope, client.ScopeList())
+	if !ok {
+		return nil, utils.NewOAuthError("invalid_scope", "the requested scope is not allowed for this client")
+	}
+	return scopes, nil
+}
+
+func (s *OAuthService) grantCode(
+	ctx context.Context,
+	userID uint,
+	client *models.OAuthClient,
+	redirectURI string,
+	scopes []string,
+	request dto.AuthorizeRequest,
+) (*dto.AuthorizeResponse, error) {
+	code, err := utils.GenerateToken(constants.VerificationTokenSize)
+	if err != nil {
+		log.Error(ctx, "error generating authorization code: ", err)
+		return nil, utils.NewInternalServerError("could not authorize client")
+	}
+
+	now := s.now()
+	if err := s.AuthorizationCodeRepository.DeleteExpired(ctx, now.Add(-s.CodeRetention)); err != nil {
+		log.Error(ctx, "error deleting expired authorization codes: ", err)
+	}
+	err = s.AuthorizationCodeRepository.Create(ctx, &models.OAuthAuthorizationCode{
+		CodeHash:      utils.HashToken(code),
+		ClientID:      client.ClientID,
+		UserID:        userID,
+		RedirectURI:   request.RedirectURI,
+		Scope:         strings.Join(scopes, " "),
+		CodeChallenge: request.CodeChallenge,
+		Nonce:         request.Nonce,
+		ExpiresAt:     now.Add(time.Duration(constants.OAuthCodeExpiration) * time.Minute),
+	})
+	if err != nil {
+		log.Error(ctx, "error creating authorization code: ", err)
+		return nil, utils.NewInternalServerError("could not authorize client")
+	}
+
+	return &dto.AuthorizeResponse{
+		ClientID:   client.ClientID,
+		ClientName: client.Name,
+		Scopes:     scopes,
+		RedirectTo: redirectWith(redirectURI, request.State, map[string]string{"code": code}),
+	}, nil
+}
+
+// AuthenticateClient acepta clientes públicos sin secreto y confidenciales con su secreto (RFC 6749 2.3.1).
+func (s *OAuthService) AuthenticateClient(ctx context.Context, clientID, clientSecret string) (*models.OAuthClient, error) {
+	if clientID == "" {
+		return nil, utils.NewOAuthError("invalid_client", "client authentication failed")
+	}
+
+	client, err := s.OAuthClientRepository.FindByClientID(ctx, clientID)
+	if err != nil {
+		log.Error(ctx, "error finding oauth client: ", err)
+		return nil, utils.NewInternalServerError("could not authenticate client")
+	}
+	if client == nil {
+		return nil, utils.NewOAuthError("invalid_client", "client authentication failed")
+	}
+
+	if client.Public {
+		if clientSecret != "" {
+			return nil, utils.NewOAuthError("invalid_client", "client authentication failed")
+		}
+		return client, nil
+	}
+	if subtle.ConstantTimeCompare([]byte(client.SecretHash), []byte(utils.HashToken(clientSecret))) != 1 {
+		return nil, utils.NewOAuthError("invalid_client", "client authentication failed")
+	}
+	return client, nil
+}
+
+// exchangeCode canjea el código una sola vez; presentarlo de nuevo revoca los tokens que emitió (RFC 6749 4.1.2).
+func (s *OAuthService) exchangeCode(ctx context.Context, client *models.OAuthClient, request dto.TokenRequest) (*dto.TokenResponse, error) {
+	if request.Code == "" || request.CodeVerifier == "" {
+		return nil, utils.NewOAuthError("invalid_request", "code and code_verifier are required")
+	}
+
+	now := s.now()
+	stored, err := s.AuthorizationCodeRepository.FindByHash(ctx, utils.HashToken(request.Code))
+	if err != nil {
+		log.Error(ctx, "error finding authorization code: ", err)
+		return nil, utils.NewInternalServerError("could not issue token")
+	}
+	if stored == nil || stored.ClientID != client.ClientID {
+		return nil, utils.NewOAuthError("invalid_grant", "invalid authorization code")
+	}
+	if stored.UsedAt != nil {
+		s.revokeCodeFamily(ctx, stored)
+		return nil, utils.NewOAuthError("invalid_grant", "invalid authorization code")
+	}
+	if !stored.IsActive(now) || stored.RedirectURI != request.RedirectURI {
+		return nil, utils.NewOAuthError("invalid_grant", "invalid authorization code")
+	}
+	if !utils.VerifyCodeChallenge(request.CodeVerifier, stored.CodeChallenge) {
+		return nil, utils.NewOAuthError("invalid_grant", "invalid code_verifier")
+	}
+
+	familyID, err := utils.GenerateToken(16)
+	if err != nil {
+		log.Error(ctx, "error generating refresh token family: ", err)
+		return nil, utils.NewInternalServerError("could not issue token")
+	}
+	if err := s.AuthorizationCodeRepository.MarkUsed(ctx, stored.ID, now, familyID); err != nil {
+		if errors.Is(err, repositories.ErrCodeAlreadyUsed) {
+			return nil, utils.NewOAuthError("invalid_grant", "invalid authorization code")
+		}
+		log.Error(ctx, "error marking authorization code as used: ", err)
+		return nil, utils.NewInternalServerError("could not issue token")
+	}
+
+	user, err := s.UserRepository.FindByID(ctx, stored.UserID)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return nil, utils.NewInternalServerError("could not issue token")
+	}
+	if user == nil {
+		return nil, utils.NewOAuthError("invalid_grant", "invalid authorization code")
+	}
+
+	// Sin refresh token no hay sesión que revocar y el access token queda sin sid.
+	var refreshToken, sessionID string
+	if client.AllowsGrantType(constants.GrantTypeRefreshToken) {
+		if err := s.RefreshTokenService.StartSession(ctx, user.ID, familyID, client.ClientID); err != nil {
+			log.Error(ctx, "error starting session: ", err)
+			return nil, utils.NewInternalServerError("could not issue token")
+		}
+		refreshToken, err = s.RefreshTokenService.IssueForClient(ctx, user.ID, familyID, client.ClientID, stored.Scope)
+		if err != nil {
+			log.Error(ctx, "error issuing refresh token: ", err)
+			return nil, utils.NewInternalServerError("could not issue token")
+		}
+		sessionID = familyID
+	}
+	return s.tokenResponse(ctx, user, client, stored.Scope, refreshToken, stored.Nonce, sessionID)
+}
+
+// refresh rota el refresh token del cliente; scope solo puede reducir los scopes concedidos originalmente.
+func (s *OAuthService) refresh(ctx context.Context, client *models.OAuthClient, request dto.TokenRequest) (*dto.TokenResponse, error) {
+	if request.RefreshToken == "" {
+		return nil, utils.NewOAuthError("invalid_request", "refresh_token is required")
+	}
+
+	stored, err := s.RefreshTokenService.Find(ctx, request.RefreshToken)
+	if err != nil {
+		log.Error(ctx, "error finding refresh token: ", err)
+		return nil, utils.NewInternalServerError("could not issue token")
+	}
+	if stored == nil || stored.ClientID != client.ClientID {
+		return nil, utils.NewOAuthError("invalid_grant", "invalid refresh token")
+	}
+	scopes, ok := resolveScopes(request.Scope, strings.Fields(stored.Scope))
+	if !ok {
+		return nil, utils.NewOAuthError("invalid_scope", "the requested scope exceeds the granted scope")
+	}
+
+	rotated, refreshToken, err := s.RefreshTokenService.Rotate(ctx, request.RefreshToken, client.ClientID)
+	if err != nil {
+		var apiErr *utils.APIError
+		if errors.As(err, &apiErr) && apiErr.Status == http.StatusUnauthorized {
+			return nil, utils.NewOAuthError("invalid_grant", "invalid refresh token")
+		}
+		return nil, err
+	}
+
+	user, err := s.UserRepository.FindByID(ctx, rotated.UserID)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return nil, utils.NewInternalServerError("could not issue token")
+	}
+	if user == nil {
+		return nil, utils.NewOAuthError("invalid_grant", "invalid refresh token")
+	}
+
+	return s.tokenResponse(ctx, user, client, strings.Join(scopes, " "), refreshToken, "", rotated.FamilyID)
+}
+
+// clientCredentials emite un token a nombre del propio cliente, sin refresh token (RFC 6749 4.4.3).
+func (s *OAuthService) clientCredentials(ctx context.Context, client *models.OAuthClient, request dto.TokenRequest) (*dto.TokenResponse, error) {
+	if client.Public {
+		return nil, utils.NewOAuthError("unauthorized_client", "public clients cannot use client_credentials")
+	}
+	scopes, ok := resolveScopes(request.Scope, client.ScopeList())
+	if !ok {
+		return nil, utils.NewOAuthError("invalid_scope", "the requested scope is not allowed for this client")
+	}
+
+	return s.tokenResponse(ctx, nil, client, strings.Join(scopes, " "), "", "", "")
+}
+
+// tokenResponse agrega un ID token cuando hay usuario y se concedió el scope openid.
+func (s *OAuthService) tokenResponse(
+	ctx context.Context,
+	user *models.User,
+	client *models.OAuthClient,
+	scope, refreshToken, nonce, sessionID string,
+) (*dto.TokenResponse, error) {
+	token, claims, err := s.TokenService.IssueOAuthAccessToken(ctx, user, client, scope, sessionID)
+	if err != nil {
+		log.Error(ctx, "error issuing access token: ", err)
+		return nil, utils.NewInternalServerError("could not issue token")
+	}
+
+	response := &dto.TokenResponse{
+		AccessToken:  token,
+		TokenType:    TokenTypeBearer,
+		ExpiresIn:    int64(claims.ExpiresAt.Sub(claims.IssuedAt.Time).Seconds()),
+		RefreshToken: refreshToken,
+		Scope:        scope,
+	}
+
+	scopes := strings.Fields(scope)
+	if user != nil && slices.Contains(scopes, constants.OAuthScopeOpenID) {
+		response.IDToken, err = s.TokenService.IssueIDToken(ctx, user, client.ClientID, nonce, token, scopes)
+		if err != nil {
+			log.Error(ctx, "error issuing id token: ", err)
+			return nil, utils.NewInternalServerError("could not issue token")
+		}
+	}
+	return response, nil
+}
+
+func (s *OAuthService) revokeCodeFamily(ctx context.Context, code *models.OAuthAuthorizationCode) {
+	log.Warn(ctx, fmt.Sprintf("authorization code reuse detected, client: %v, user: %v", code.ClientID, code.UserID))
+	if code.FamilyID == "" {
+		return
+	}
+	if err := s.RefreshTokenService.RevokeFamily(ctx, code.FamilyID); err != nil {
+		log.Error(ctx, "error revoking refresh token family: ", err)
+	}
+}
+
+// resolveScopes devuelve los scopes pedidos si todos están permitidos; sin scope se conceden todos los permitidos.
+func resolveScopes(requested string, allowed []string) ([]string, bool) {
+	scopes := strings.Fields(requested)
+	if len(scopes) == 0 {
+		return allowed, true
+	}
+	return mergeScopes(nil, scopes), containsAll(allowed, scopes)
+}
+
+func containsAll(values, required []string) bool {
+	for _, value := range required {
+		if !slices.Contains(values, value) {
+			return false
+		}
+	}
+	return true
+}
+
+// mergeScopes une las dos listas sin repetir y conservando el orden.
+func mergeScopes(current, added []string) []string {
+	merged := slices.Clone(current)
+	for _, scope := range added {
+		if !slices.Contains(merged, scope) {
+			merged = append(merged, scope)
+		}
+	}
+	return merged
+}
+
+func redirectError(client *models.OAuthClient, redirectURI, state string, err error) *dto.AuthorizeResponse {
+	params := map[string]string{"error": "server_error"}
+	var apiErr *utils.APIError
+	if errors.As(err, &apiErr) {
+		params = map[string]string{"error": apiErr.Code, "error_description": apiErr.Message}
+	}
+
+	return &dto.AuthorizeResponse{
+		ClientID:   client.ClientID,
+		ClientName: client.Name,
+		RedirectTo: redirectWith(redirectURI, state, params),
+	}
+}
+
+// redirectWith agrega los parámetros y el state a la query de la redirect_uri registrada.
+func redirectWith(redirectURI, state string, params map[string]string) string {
+	parsed, err := url.Parse(redirectURI)
+	if err != nil {
+		return redirectURI
+	}
+
+	query := parsed.Query()
+	for key, value := range params {
+		query.Set(key, value)
+	}
+	if state != "" {
+		query.Set("state", state)
+	}
+	parsed.RawQuery = query.Encode()
+	return parsed.String()
+}
@@ -0,0 +1,623 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+const testCodeVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+
+func createTestClient(t *testing.T, service *OAuthService, request dto.CreateOAuthClientRequest) *dto.OAuthClientResponse {
+	t.Helper()
+
+	client, err := NewOAuthClientService(service.OAuthClientRepository).CreateClient(context.Background(), request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return client
+}
+
+func newPartnerClient(t *testing.T, service *OAuthService) *dto.OAuthClientResponse {
+	return createTestClient(t, service, dto.CreateOAuthClientRequest{
+		Name:         "Partner",
+		RedirectURIs: []string{"https://partner.example/callback"},
+		GrantTypes:   []string{constants.GrantTypeAuthorizationCode, constants.GrantTypeRefreshToken},
+		Scopes:       []string{"profile", "tasks:read", "tasks:write"},
+	})
+}
+
+func authorizeRequest(client *dto.OAuthClientResponse, scope string) dto.AuthorizeRequest {
+	return dto.AuthorizeRequest{
+		ResponseType:        constants.ResponseTypeCode,
+		ClientID:            client.ClientID,
+		RedirectURI:         client.RedirectURIs[0],
+		Scope:               scope,
+		State:               "xyz",
+		CodeChallenge:       utils.S256CodeChallenge(testCodeVerifier),
+		CodeChallengeMethod: constants.CodeChallengeMethodS256,
+	}
+}
+
+func redirectParams(t *testing.T, response *dto.AuthorizeResponse) url.Values {
+	t.Helper()
+
+	redirect, err := url.Parse(response.RedirectTo)
+	if err != nil || response.RedirectTo == "" {
+		t.Fatalf("unexpected redirect: %+v", response)
+	}
+	return redirect.Query()
+}
+
+func assertOAuthError(t *testing.T, err error, code string) {
+	t.Helper()
+
+	var apiErr *utils.APIError
+	if !errors.As(err, &apiErr) || apiErr.Code != code {
+		t.Errorf("unexpected error: got %v, want %s", err, code)
+	}
+}
+
+func TestOAuthServiceAuthorizationCode(t *testing.T) {
+	users := newFakeUserRepository()
+	_ = users.Create(context.Background(), &models.User{Email: "ana@example.com", EmailVerified: true})
+	now := time.Now()
+	service := newTestOAuthService(users, &now)
+	client := newPartnerClient(t, service)
+
+	request := authorizeRequest(client, "profile tasks:read")
+	response, err := service.Authorize(context.Background(), 1, request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !response.ConsentRequired || response.RedirectTo != "" || response.ClientName != "Partner" || len(response.Scopes) != 2 {
+		t.Fatalf("expected a consent request: %+v", response)
+	}
+
+	response, err = service.Consent(context.Background(), 1, dto.ConsentRequest{AuthorizeRequest: request, Approve: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	params := redirectParams(t, response)
+	if params.Get("code") == "" || params.Get("state") != "xyz" || !strings.HasPrefix(response.RedirectTo, "https://partner.example/callback?") {
+		t.Fatalf("unexpected redirect: %s", response.RedirectTo)
+	}
+
+	exchange := dto.TokenRequest{
+		GrantType:    constants.GrantTypeAuthorizationCode,
+		Code:         params.Get("code"),
+		RedirectURI:  client.RedirectURIs[0],
+		CodeVerifier: strings.Repeat("a", 43),
+		ClientID:     client.ClientID,
+		ClientSecret: client.ClientSecret,
+	}
+	_, err = service.Token(context.Background(), exchange)
+	assertOAuthError(t, err, "invalid_grant")
+
+	exchange.CodeVerifier = testCodeVerifier
+	tokens, err := service.Token(context.Background(), exchange)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tokens.AccessToken == "" || tokens.RefreshToken == "" || tokens.Scope != "profile tasks:read" {
+		t.Fatalf("unexpected tokens: %+v", tokens)
+	}
+	claims, err := service.TokenService.ParseAccessToken(context.Background(), tokens.AccessToken)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims.Subject != "1" || claims.ClientID != client.ClientID || claims.Scope != "profile tasks:read" || len(claims.Permissions) != 0 {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+
+	_, err = service.Token(context.Background(), exchange)
+	assertOAuthError(t, err, "invalid_grant")
+	refresh := dto.TokenRequest{
+		GrantType:    constants.GrantTypeRefreshToken,
+		RefreshToken: tokens.RefreshToken,
+		ClientID:     client.ClientID,
+		ClientSecret: client.ClientSecret,
+	}
+	_, err = service.Token(context.Background(), refresh)
+	assertOAuthError(t, err, "invalid_grant")
+
+	response, err = service.Authorize(context.Background(), 1, authorizeRequest(client, "tasks:read"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.ConsentRequired || redirectParams(t, response).Get("code") == "" {
+		t.Errorf("expected the stored consent to be reused: %+v", response)
+	}
+}
+
+func TestOAuthServiceAuthorize(t *testing.T) {
+	users := newFakeUserRepository()
+	_ = users.Create(context.Background(), &models.User{Email: "ana@example.com", EmailVerified: true})
+	now := time.Now()
+	service := newTestOAuthService(users, &now)
+	client := newPartnerClient(t, service)
+	firstParty := createTestClient(t, service, dto.CreateOAuthClientRequest{
+		Name:         "Tareaya",
+		RedirectURIs: []string{"com.tareaya.app:/callback"},
+		GrantTypes:   []string{constants.GrantTypeAuthorizationCode},
+		Scopes:       []string{"profile"},
+		Public:       true,
+		FirstParty:   true,
+	})
+
+	testCases := []struct {
+		name           string
+		request        func() dto.AuthorizeRequest
+		expectedStatus int
+		expectedError  string
+	}{
+		{
+			name: "unknown client",
+			request: func() dto.AuthorizeRequest {
+				request := authorizeRequest(client, "")
+				request.ClientID = "unknown"
+				return request
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "unregistered redirect uri",
+			request: func() dto.AuthorizeRequest {
+				request := authorizeRequest(client, "")
+				request.RedirectURI = "https://evil.example/callback"
+				return request
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "missing pkce",
+			request: func() dto.AuthorizeRequest {
+				request := authorizeRequest(client, "")
+				request.CodeChallenge = ""
+				return request
+			},
+			expectedError: "invalid_request",
+		},
+		{
+			name: "plain pkce",
+			request: func() dto.AuthorizeRequest {
+				request := authorizeRequest(client, "")
+				request.CodeChallengeMethod = "plain"
+				return request
+			},
+			expectedError: "invalid_request",
+		},
+		{
+			name: "scope not allowed",
+			request: func() dto.AuthorizeRequest {
+				return authorizeRequest(client, "profile admin")
+			},
+			expectedError: "invalid_scope",
+		},
+		{
+			name: "unsupported response type",
+			request: func() dto.AuthorizeRequest {
+				request := authorizeRequest(client, "")
+				request.ResponseType = "token"
+				return request
+			},
+			expectedError: "unsupported_response_type",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response, err := service.Authorize(context.Background(), 1, tc.request())
+			if tc.expectedStatus != 0 {
+				assertAPIStatus(t, err, tc.expectedStatus)
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			params := redirectParams(t, response)
+			if params.Get("error") != tc.expectedError || params.Get("state") != "xyz" || params.Get("code") != "" {
+				t.Errorf("unexpected redirect: %s", response.RedirectTo)
+			}
+		})
+	}
+
+	t.Run("denied consent", func(t *testing.T) {
+		response, err := service.Consent(context.Background(), 1, dto.ConsentRequest{AuthorizeRequest: authorizeRequest(client, "")})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if redirectParams(t, response).Get("error") != "access_denied" {
+			t.Errorf("unexpected redirect: %s", response.RedirectTo)
+		}
+	})
+
+	t.Run("first-party clients skip consent", func(t *testing.T) {
+		request := authorizeRequest(firstParty, "")
+		request.RedirectURI = ""
+		response, err := service.Authorize(context.Background(), 1, request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		params := redirectParams(t, response)
+		if response.ConsentRequired || params.Get("code") == "" || !strings.HasPrefix(response.RedirectTo, "com.tareaya.app:/callback?") {
+			t.Fatalf("unexpected response: %+v", response)
+		}
+
+		tokens, err := service.Token(context.Background(), dto.TokenRequest{
+			GrantType:    constants.GrantTypeAuthorizationCode,
+			Code:         params.Get("code"),
+			CodeVerifier: testCodeVerifier,
+			ClientID:     firstParty.ClientID,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tokens.RefreshToken != "" || tokens.Scope != "profile" {
+			t.Errorf("unexpected tokens: %+v", tokens)
+		}
+	})
+}
+
+func TestOAuthServiceToken(t *testing.T) {
+	users := newFakeUserRepository()
+	_ = users.Create(context.Background(), &models.User{Email: "ana@example.com", EmailVerified: true})
+	now := time.Now()
+	service := newTestOAuthService(users, &now)
+	client := newPartnerClient(t, service)
+	backend := createTestClient(t, service, dto.CreateOAuthClientRequest{
+		Name:       "Billing",
+		GrantTypes: []string{constants.GrantTypeClientCredentials},
+		Scopes:     []string{"tasks:read", "users:read"},
+	})
+
+	t.Run("refresh token grant", func(t *testing.T) {
+		refreshToken, _ := service.RefreshTokenService.IssueForClient(context.Background(), 1, "", client.ClientID, "profile tasks:read")
+		request := dto.TokenRequest{
+			GrantType:    constants.GrantTypeRefreshToken,
+			RefreshToken: refreshToken,
+			Scope:        "profile tasks:write",
+			ClientID:     client.ClientID,
+			ClientSecret: client.ClientSecret,
+		}
+
+		_, err := service.Token(context.Background(), request)
+		assertOAuthError(t, err, "invalid_scope")
+
+		request.Scope = "tasks:read"
+		tokens, err := service.Token(context.Background(), request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tokens.Scope != "tasks:read" || tokens.RefreshToken == "" || tokens.RefreshToken == refreshToken {
+			t.Errorf("unexpected tokens: %+v", tokens)
+		}
+
+		stored, _ := service.RefreshTokenService.Find(context.Background(), tokens.RefreshToken)
+		if stored.Scope != "profile tasks:read" {
+			t.Errorf("expected the rotated token to keep the granted scope: %q", stored.Scope)
+		}
+	})
+
+	t.Run("refresh token of another client", func(t *testing.T) {
+		refreshToken, _ := service.RefreshTokenService.Issue(context.Background(), 1, "")
+		_, err := service.Token(context.Background(), dto.TokenRequest{
+			GrantType:    constants.GrantTypeRefreshToken,
+			RefreshToken: refreshToken,
+			ClientID:     client.ClientID,
+			ClientSecret: client.ClientSecret,
+		})
+		assertOAuthError(t, err, "invalid_grant")
+	})
+
+	t.Run("client credentials grant", func(t *testing.T) {
+		tokens, err := service.Token(context.Background(), dto.TokenRequest{
+			GrantType:    constants.GrantTypeClientCredentials,
+			Scope:        "tasks:read",
+			ClientID:     backend.ClientID,
+			ClientSecret: backend.ClientSecret,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tokens.RefreshToken != "" || tokens.Scope != "tasks:read" {
+			t.Errorf("unexpected tokens: %+v", tokens)
+		}
+
+		claims, _ := service.TokenService.ParseAccessToken(context.Background(), tokens.AccessToken)
+		if !claims.IsClientToken() || claims.Subject != backend.ClientID {
+			t.Errorf("unexpected claims: %+v", claims)
+		}
+	})
+
+	testCases := []struct {
+		name          string
+		request       dto.TokenRequest
+		expectedError string
+	}{
+		{
+			name:          "wrong secret",
+			request:       dto.TokenRequest{GrantType: constants.GrantTypeClientCredentials, ClientID: backend.ClientID, ClientSecret: "wrong"},
+			expectedError: "invalid_client",
+		},
+		{
+			name:          "unknown client",
+			request:       dto.TokenRequest{GrantType: constants.GrantTypeClientCredentials, ClientID: "unknown"},
+			expectedError: "invalid_client",
+		},
+		{
+			name:          "grant not allowed for the client",
+			request:       dto.TokenRequest{GrantType: constants.GrantTypeClientCredentials, ClientID: client.ClientID, ClientSecret: client.ClientSecret},
+			expectedError: "unauthorized_client",
+		},
+		{
+			name:          "unsupported grant",
+			request:       dto.TokenRequest{GrantType: "password", ClientID: client.ClientID, ClientSecret: client.ClientSecret},
+			expectedError: "unsupported_grant_type",
+		},
+		{
+			name:          "scope not allowed",
+			request:       dto.TokenRequest{GrantType: constants.GrantTypeClientCredentials, Scope: "roles:manage", ClientID: backend.ClientID, ClientSecret: backend.ClientSecret},
+			expectedError: "invalid_scope",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.Token(context.Background(), tc.request)
+			assertOAuthError(t, err, tc.expectedError)
+		})
+	}
+}
+
+func TestOAuthClientService(t *testing.T) {
+	service := NewOAuthClientService(newFakeOAuthClientRepository())
+
+	testCases := []struct {
+		name    string
+		request dto.CreateOAuthClientRequest
+	}{
+		{
+			name:    "code grant without redirect uri",
+			request: dto.CreateOAuthClientRequest{Name: "App", GrantTypes: []string{constants.GrantTypeAuthorizationCode}},
+		},
+		{
+			name:    "plain http redirect uri",
+			request: dto.CreateOAuthClientRequest{Name: "App", RedirectURIs: []string{"http://app.example/callback"}, GrantTypes: []string{constants.GrantTypeAuthorizationCode}},
+		},
+		{
+			name:    "redirect uri with fragment",
+			request: dto.CreateOAuthClientRequest{Name: "App", RedirectURIs: []string{"https://app.example/callback#x"}, GrantTypes: []string{constants.GrantTypeAuthorizationCode}},
+		},
+		{
+			name:    "script redirect uri",
+			request: dto.CreateOAuthClientRequest{Name: "App", RedirectURIs: []string{"javascript:alert(document.cookie)"}, GrantTypes: []string{constants.GrantTypeAuthorizationCode}},
+		},
+		{
+			name:    "data redirect uri",
+			request: dto.CreateOAuthClientRequest{Name: "App", RedirectURIs: []string{"data:text/html,<script>alert(1)</script>"}, GrantTypes: []string{constants.GrantTypeAuthorizationCode}},
+		},
+		{
+			name:    "file redirect uri",
+			request: dto.CreateOAuthClientRequest{Name: "App", RedirectURIs: []string{"file:///etc/passwd"}, GrantTypes: []string{constants.GrantTypeAuthorizationCode}},
+		},
+		{
+			name:    "public client with client credentials",
+			request: dto.CreateOAuthClientRequest{Name: "App", GrantTypes: []string{constants.GrantTypeClientCredentials}, Public: true},
+		},
+		{
+			name:    "refresh token without code grant",
+			request: dto.CreateOAuthClientRequest{Name: "App", GrantTypes: []string{constants.GrantTypeClientCredentials, constants.GrantTypeRefreshToken}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := service.CreateClient(context.Background(), tc.request)
+			assertAPIStatus(t, err, http.StatusBadRequest)
+		})
+	}
+
+	t.Run("create and delete", func(t *testing.T) {
+		client, err := service.CreateClient(context.Background(), dto.CreateOAuthClientRequest{
+			Name:         "Local",
+			RedirectURIs: []string{"http://localhost:3000/callback"},
+			GrantTypes:   []string{constants.GrantTypeAuthorizationCode},
+			Public:       true,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if client.ClientID == "" || client.ClientSecret != "" {
+			t.Errorf("unexpected client: %+v", client)
+		}
+
+		if err := service.DeleteClient(context.Background(), client.ClientID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertAPIStatus(t, service.DeleteClient(context.Background(), client.ClientID), http.StatusNotFound)
+	})
+}
+
+func TestOAuthServiceOpenID(t *testing.T) {
+	users := newFakeUserRepository()
+	_ = users.Create(context.Background(), &models.User{Name: "Ana", Email: "ana@example.com", EmailVerified: true})
+	now := time.Now()
+	service := newTestOAuthService(users, &now)
+	client := createTestClient(t, service, dto.CreateOAuthClientRequest{
+		Name:         "Tooling",
+		RedirectURIs: []string{"https://tooling.example/callback"},
+		GrantTypes:   []string{constants.GrantTypeAuthorizationCode, constants.GrantTypeRefreshToken},
+		Scopes:       []string{"openid", "email"},
+		FirstParty:   true,
+	})
+
+	request := authorizeRequest(client, "openid email")
+	request.Nonce = "n-0S6_WzA2Mj"
+	response, err := service.Authorize(context.Background(), 1, request)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tokens, err := service.Token(context.Background(), dto.TokenRequest{
+		GrantType:    constants.GrantTypeAuthorizationCode,
+		Code:         redirectParams(t, response).Get("code"),
+		RedirectURI:  client.RedirectURIs[0],
+		CodeVerifier: testCodeVerifier,
+		ClientID:     client.ClientID,
+		ClientSecret: client.ClientSecret,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	tokenService := service.TokenService.(*TokenService)
+	claims := parseTestIDToken(t, tokenService, tokens.IDToken, client.ClientID)
+	if claims.Nonce != "n-0S6_WzA2Mj" || claims.AccessTokenHash != accessTokenHash(tokens.AccessToken) || claims.Email != "ana@example.com" {
+		t.Errorf("unexpected id token claims: %+v", claims)
+	}
+
+	refreshed, err := service.Token(context.Background(), dto.TokenRequest{
+		GrantType:    constants.GrantTypeRefreshToken,
+		RefreshToken: tokens.RefreshToken,
+		ClientID:     client.ClientID,
+		ClientSecret: client.ClientSecret,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if claims := parseTestIDToken(t, tokenService, refreshed.IDToken, client.ClientID); claims.Nonce != "" {
+		t.Errorf("expected refreshed id token without nonce: %+v", claims)
+	}
+}
+
+type fakeOAuthClientRepository struct {
+	clients map[string]*models.OAuthClient
+	nextID  uint
+}
+
+func newFakeOAuthClientRepository() *fakeOAuthClientRepository {
+	return &fakeOAuthClientRepository{clients: map[string]*models.OAuthClient{}, nextID: 1}
+}
+
+func (r *fakeOAuthClientRepository) Create(_ context.Context, client *models.OAuthClient) error {
+	client.ID = r.nextID
+	client.CreatedAt = time.Now()
+	r.nextID++
+	r.clients[client.ClientID] = client
+	return nil
+}
+
+func (r *fakeOAuthClientRepository) FindByClientID(_ context.Context, clientID string) (*models.OAuthClient, error) {
+	client, ok := r.clients[clientID]
+	if !ok {
+		return nil, nil
+	}
+	found := *client
+	return &found, nil
+}
+
+func (r *fakeOAuthClientRepository) FindAll(_ context.Context) ([]models.OAuthClient, error) {
+	clients := make([]models.OAuthClient, 0, len(r.clients))
+	for _, client := range r.clients {
+		clients = append(clients, *client)
+	}
+	return clients, nil
+}
+
+func (r *fakeOAuthClientRepository) Delete(_ context.Context, clientID string) error {
+	if _, ok := r.clients[clientID]; !ok {
+		return repositories.ErrNotFound
+	}
+	delete(r.clients, clientID)
+	return nil
+}
+
+type fakeOAuthAuthorizationCodeRepository struct {
+	codes  []*models.OAuthAuthorizationCode
+	nextID uint
+}
+
+func (r *fakeOAuthAuthorizationCodeRepository) Create(_ context.Context, code *models.OAuthAuthorizationCode) error {
+	r.nextID++
+	code.ID = r.nextID
+	r.codes = append(r.codes, code)
+	return nil
+}
+
+func (r *fakeOAuthAuthorizationCodeRepository) FindByHash(_ context.Context, codeHash string) (*models.OAuthAuthorizationCode, error) {
+	for _, code := range r.codes {
+		if code.CodeHash == codeHash {
+			found := *code
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeOAuthAuthorizationCodeRepository) MarkUsed(_ context.Context, id uint, usedAt time.Time, familyID string) error {
+	for _, code := range r.codes {
+		if code.ID == id {
+			if code.UsedAt != nil {
+				return repositories.ErrCodeAlreadyUsed
+			}
+			code.UsedAt = &usedAt
+			code.FamilyID = familyID
+		}
+	}
+	return nil
+}
+
+func (r *fakeOAuthAuthorizationCodeRepository) DeleteExpired(_ context.Context, before time.Time) error {
+	codes := r.codes[:0]
+	for _, code := range r.codes {
+		if !code.ExpiresAt.Before(before) {
+			codes = append(codes, code)
+		}
+	}
+	r.codes = codes
+	return nil
+}
+
+type fakeOAuthConsentRepository struct {
+	consents map[string]*models.OAuthConsent
+}
+
+func (r *fakeOAuthConsentRepository) Find(_ context.Context, userID uint, clientID string) (*models.OAuthConsent, error) {
+	consent, ok := r.consents[fmt.Sprintf("%d/%s", userID, clientID)]
+	if !ok {
+		return nil, nil
+	}
+	found := *consent
+	return &found, nil
+}
+
+func (r *fakeOAuthConsentRepository) Save(_ context.Context, consent *models.OAuthConsent) error {
+	r.consents[fmt.Sprintf("%d/%s", consent.UserID, consent.ClientID)] = consent
+	return nil
+}
+
+func newTestOAuthService(users *fakeUserRepository, now *time.Time) *OAuthService {
+	service := NewOAuthService(
+		users,
+		newFakeOAuthClientRepository(),
+		&fakeOAuthAuthorizationCodeRepository{},
+		&fakeOAuthConsentRepository{consents: map[string]*models.OAuthConsent{}},
+		newTestTokenService(now),
+		newTestRefreshTokenService(newFakeRefreshTokenRepository(), now),
+		config.AuthTokenConfig{RefreshTokenTTL: 24 * time.Hour},
+	)
+	service.now = func() time.Time { return *now }
+	return service
+}
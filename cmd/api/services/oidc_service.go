@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type IOIDCService interface {
+	Configuration() dto.OpenIDConfiguration
+	UserInfo(ctx context.Context, userID uint, scopes []string) (*dto.UserInfoResponse, error)
+}
+
+type OIDCService struct {
+	UserRepository repositories.IUserRepository
+	PublicURL      string
+}
+
+func NewOIDCService(userRepository repositories.IUserRepository, authConfig config.AuthTokenConfig) *OIDCService {
+	return &OIDCService{
+		UserRepository: userRepository,
+		PublicURL:      authConfig.PublicURL,
+	}
+}
+
+// Configuration arma el documento de discovery a partir de PublicURL, que también es el issuer de los ID tokens.
+func (s *OIDCService) Configuration() dto.OpenIDConfiguration {
+	issuer := strings.TrimSuffix(s.PublicURL, "/")
+	return dto.OpenIDConfiguration{
+		Issuer:                 issuer,
+		AuthorizationEndpoint:  issuer + "/oauth/authorize",
+		TokenEndpoint:          issuer + "/oauth/token",
+		UserInfoEndpoint:       issuer + "/oauth/userinfo",
+		JWKSURI:                issuer + "/.well-known/jwks.json",
+		IntrospectionEndpoint:  issuer + "/oauth/introspect",
+		RevocationEndpoint:     issuer + "/oauth/revoke",
+		ScopesSupported:        []string{constants.OAuthScopeOpenID, constants.OAuthScopeProfile, constants.OAuthScopeEmail},
+		ResponseTypesSupported: []string{constants.ResponseTypeCode},
+		GrantTypesSupported: []string{
+			constants.GrantTypeAuthorizationCode,
+			constants.GrantTypeRefreshToken,
+			constants.GrantTypeClientCredentials,
+		},
+		SubjectTypesSupported:                     []string{"public"},
+		IDTokenSigningAlgValuesSupported:          []string{utils.AlgorithmRS256, utils.AlgorithmES256},
+		TokenEndpointAuthMethodsSupported:         []string{"client_secret_basic", "client_secret_post", "none"},
+		IntrospectionEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post"},
+		RevocationEndpointAuthMethodsSupported:    []string{"client_secret_basic", "client_secret_post", "none"},
+		CodeChallengeMethodsSupported:             []string{constants.CodeChallengeMethodS256},
+		ClaimsSupported:                           []string{"sub", "iss", "aud", "exp", "iat", "nonce", "at_hash", "name", "email", "email_verified"},
+	}
+}
+
+// UserInfo devuelve los claims que los scopes del access token permiten ver (OpenID Connect Core 5.3).
+func (s *OIDCService) UserInfo(ctx context.Context, userID uint, scopes []string) (*dto.UserInfoResponse, error) {
+	user, err := s.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return nil, utils.NewInternalServerError("could not load user info")
+	}
+	if user == nil {
+		return nil, utils.NewUnauthorizedError("missing or invalid access token")
+	}
+
+	return &dto.UserInfoResponse{
+		Sub:            strconv.FormatUint(uint64(user.ID), 10),
+		StandardClaims: dto.NewStandardClaims(user, scopes),
+	}, nil
+}
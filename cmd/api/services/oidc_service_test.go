@@ -0,0 +1,55 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+)
+
+func TestOIDCService(t *testing.T) {
+	users := newFakeUserRepository()
+	_ = users.Create(context.Background(), &models.User{Name: "Ana", Email: "ana@example.com"})
+	service := NewOIDCService(users, config.AuthTokenConfig{PublicURL: "https://auth.tareaya.test/"})
+
+	t.Run("discovery", func(t *testing.T) {
+		configuration := service.Configuration()
+		if configuration.Issuer != "https://auth.tareaya.test" ||
+			configuration.TokenEndpoint != "https://auth.tareaya.test/oauth/token" ||
+			configuration.JWKSURI != "https://auth.tareaya.test/.well-known/jwks.json" {
+			t.Errorf("unexpected configuration: %+v", configuration)
+		}
+	})
+
+	testCases := []struct {
+		name          string
+		scopes        []string
+		expectedName  string
+		expectedEmail string
+	}{
+		{name: "openid only", scopes: []string{"openid"}},
+		{name: "profile and email", scopes: []string{"openid", "profile", "email"}, expectedName: "Ana", expectedEmail: "ana@example.com"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			response, err := service.UserInfo(context.Background(), 1, tc.scopes)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if response.Sub != "1" || response.Name != tc.expectedName || response.Email != tc.expectedEmail {
+				t.Errorf("unexpected user info: %+v", response)
+			}
+			if tc.expectedEmail != "" && (response.EmailVerified == nil || *response.EmailVerified) {
+				t.Errorf("expected email_verified to be published as false")
+			}
+		})
+	}
+
+	t.Run("unknown user", func(t *testing.T) {
+		_, err := service.UserInfo(context.Background(), 9, []string{"openid"})
+		assertAPIStatus(t, err, http.StatusUnauthorized)
+	})
+}
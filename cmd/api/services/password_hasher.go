@@ -0,0 +1,67 @@
+package services
+
+import (
+	"fmt"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// IPasswordHasher calcula los hashes de las contraseñas nuevas. La verificación no depende de la configuración:
+// utils.CheckPassword lee el algoritmo y los parámetros del propio hash.
+type IPasswordHasher interface {
+	Hash(password string) (string, error)
+	NeedsRehash(hash string) bool
+}
+
+// PasswordHasher usa config.PasswordHashingConfig. Los hashes importados del sistema anterior (bcrypt) siguen sirviendo
+// para iniciar sesión y se reemplazan por el algoritmo configurado en el primer login correcto.
+type PasswordHasher struct {
+	config config.PasswordHashingConfig
+}
+
+func NewPasswordHasher(hashingConfig config.PasswordHashingConfig) *PasswordHasher {
+	return &PasswordHasher{config: hashingConfig}
+}
+
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	switch h.config.Algorithm {
+	case constants.PasswordHashArgon2id:
+		return utils.HashArgon2id(password, h.argon2Params())
+	case constants.PasswordHashBcrypt:
+		return utils.HashBcrypt(password, h.config.BcryptCost)
+	default:
+		return "", fmt.Errorf("unsupported password hash algorithm %q", h.config.Algorithm)
+	}
+}
+
+// NeedsRehash indica si el hash es de otro algoritmo o tiene parámetros distintos a los configurados. Bajar los
+// parámetros también recalcula los hashes: la configuración es la fuente de verdad.
+func (h *PasswordHasher) NeedsRehash(hash string) bool {
+	if utils.PasswordHashAlgorithm(hash) != h.config.Algorithm {
+		return true
+	}
+
+	switch h.config.Algorithm {
+	case constants.PasswordHashArgon2id:
+		params, _, _, err := utils.ParseArgon2idHash(hash)
+		return err != nil || params != h.argon2Params()
+	case constants.PasswordHashBcrypt:
+		cost, err := bcrypt.Cost([]byte(hash))
+		return err != nil || cost != h.config.BcryptCost
+	default:
+		return false
+	}
+}
+
+func (h *PasswordHasher) argon2Params() utils.Argon2Params {
+	return utils.Argon2Params{
+		Memory:      h.config.Argon2Memory,
+		Iterations:  h.config.Argon2Iterations,
+		Parallelism: h.config.Argon2Parallelism,
+		SaltLength:  h.config.Argon2SaltLength,
+		KeyLength:   h.config.Argon2KeyLength,
+	}
+}
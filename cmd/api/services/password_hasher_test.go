@@ -0,0 +1,58 @@
+package services
+
+import (
+	"testing"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordHasher(t *testing.T) {
+	hasher := newTestPasswordHasher()
+	current, _ := hasher.Hash("s3cret-password")
+	weaker, _ := utils.HashArgon2id("s3cret-password", utils.Argon2Params{Memory: 32, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	legacy, _ := utils.HashBcrypt("s3cret-password", bcrypt.MinCost)
+
+	bcryptHasher := newTestPasswordHasher()
+	bcryptHasher.config.Algorithm = constants.PasswordHashBcrypt
+	bcryptCurrent, _ := bcryptHasher.Hash("s3cret-password")
+	bcryptCheaper, _ := utils.HashBcrypt("s3cret-password", bcrypt.MinCost+1)
+
+	testCases := []struct {
+		name     string
+		hasher   *PasswordHasher
+		hash     string
+		expected bool
+	}{
+		{name: "current argon2id parameters", hasher: hasher, hash: current},
+		{name: "other argon2id parameters", hasher: hasher, hash: weaker, expected: true},
+		{name: "legacy bcrypt", hasher: hasher, hash: legacy, expected: true},
+		{name: "current bcrypt cost", hasher: bcryptHasher, hash: bcryptCurrent},
+		{name: "other bcrypt cost", hasher: bcryptHasher, hash: bcryptCheaper, expected: true},
+		{name: "switching back from argon2id", hasher: bcryptHasher, hash: current, expected: true},
+	}
+
+	for _, tc := range testCases {
+		if !utils.CheckPassword(tc.hash, "s3cret-password") {
+			t.Fatalf("%s: expected the hash to verify", tc.name)
+		}
+		if got := tc.hasher.NeedsRehash(tc.hash); got != tc.expected {
+			t.Errorf("%s: unexpected result: got %v, want %v", tc.name, got, tc.expected)
+		}
+	}
+}
+
+// newTestPasswordHasher usa argon2id con parámetros mínimos para que las pruebas sean rápidas.
+func newTestPasswordHasher() *PasswordHasher {
+	return &PasswordHasher{config: config.PasswordHashingConfig{
+		Algorithm:         constants.PasswordHashArgon2id,
+		Argon2Memory:      64,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+		Argon2SaltLength:  16,
+		Argon2KeyLength:   32,
+		BcryptCost:        bcrypt.MinCost,
+	}}
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+// minPersonalInfoLength evita rechazar contraseñas por coincidir con fragmentos triviales del nombre o el email.
+const minPersonalInfoLength = 3
+
+type IPasswordPolicyService interface {
+	Validate(ctx context.Context, user *models.User, password string) error
+	RecordChange(ctx context.Context, userID uint, previousHash string) error
+}
+
+// PasswordPolicyService aplica config.PasswordPolicyConfig a las contraseñas nuevas en el signup, el reset y el cambio
+// de contraseña.
+type PasswordPolicyService struct {
+	PasswordHistoryRepository  repositories.IPasswordHistoryRepository
+	BreachedPasswordRepository repositories.IBreachedPasswordRepository
+	policy                     config.PasswordPolicyConfig
+	now                        func() time.Time
+}
+
+func NewPasswordPolicyService(
+	passwordHistoryRepository repositories.IPasswordHistoryRepository,
+	breachedPasswordRepository repositories.IBreachedPasswordRepository,
+	policy config.PasswordPolicyConfig,
+) *PasswordPolicyService {
+	return &PasswordPolicyService{
+		PasswordHistoryRepository:  passwordHistoryRepository,
+		BreachedPasswordRepository: breachedPasswordRepository,
+		policy:                     policy,
+		now:                        time.Now,
+	}
+}
+
+// Validate responde 400 con código weak_password y todas las reglas incumplidas. user aporta el nombre y el email
+// a excluir; si ya existe (ID distinto de 0) tampoco se acepta su contraseña actual ni las del historial.
+func (s *PasswordPolicyService) Validate(ctx context.Context, user *models.User, password string) error {
+	var violations []string
+
+	if utf8.RuneCountInString(password) < s.policy.MinLength {
+		violations = append(violations, fmt.Sprintf("must be at least %d characters long", s.policy.MinLength))
+	}
+	if s.policy.MaxLength > 0 && len(password) > s.policy.MaxLength {
+		violations = append(violations, fmt.Sprintf("must be at most %d bytes long", s.policy.MaxLength))
+	}
+	if characterClasses(password) < s.policy.MinCharacterClasses {
+		violations = append(violations, fmt.Sprintf("must mix at least %d of lowercase letters, uppercase letters, digits and symbols", s.policy.MinCharacterClasses))
+	}
+	if s.policy.DisallowPersonalInfo && containsPersonalInfo(user, password) {
+		violations = append(violations, "must not contain your name or email")
+	}
+
+	breached, err := s.isBreached(ctx, password)
+	if err != nil {
+		log.Error(ctx, "error checking breached passwords: ", err)
+		return utils.NewInternalServerError("could not validate password")
+	}
+	if breached {
+		violations = append(violations, "appears in a list of breached passwords")
+	}
+
+	if len(violations) == 0 && user.ID != 0 {
+		reused, err := s.isReused(ctx, user, password)
+		if err != nil {
+			log.Error(ctx, "error finding password history: ", err)
+			return utils.NewInternalServerError("could not validate password")
+		}
+		if reused {
+			violations = append(violations, "must not reuse a recent password")
+		}
+	}
+
+	if len(violations) > 0 {
+		return utils.NewAPIError(http.StatusBadRequest, "weak_password", "password "+strings.Join(violations, "; "))
+	}
+	return nil
+}
+
+// RecordChange guarda el hash reemplazado y recorta el historial a HistorySize entradas.
+func (s *PasswordPolicyService) RecordChange(ctx context.Context, userID uint, previousHash string) error {
+	if s.policy.HistorySize <= 0 || previousHash == "" {
+		return nil
+	}
+
+	entry := &models.PasswordHistory{UserID: userID, PasswordHash: previousHash, CreatedAt: s.now()}
+	if err := s.PasswordHistoryRepository.Create(ctx, entry); err != nil {
+		return err
+	}
+	return s.PasswordHistoryRepository.Prune(ctx, userID, s.policy.HistorySize)
+}
+
+// isBreached solo envía al repositorio el prefijo del hash, como pide el k-anonimato.
+func (s *PasswordPolicyService) isBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, err := s.BreachedPasswordRepository.FindSuffixes(ctx, hash[:5])
+	if err != nil {
+		return false, err
+	}
+	for _, suffix := range suffixes {
+		if strings.EqualFold(suffix, hash[5:]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// isReused compara contra la contraseña actual y las HistorySize anteriores; con HistorySize 0 no hay chequeo.
+func (s *PasswordPolicyService) isReused(ctx context.Context, user *models.User, password string) (bool, error) {
+	if s.policy.HistorySize <= 0 {
+		return false, nil
+	}
+	if user.PasswordHash != "" && utils.CheckPassword(user.PasswordHash, password) {
+		return true, nil
+	}
+
+	history, err := s.PasswordHistoryRepository.FindRecentByUserID(ctx, user.ID, s.policy.HistorySize)
+	if err != nil {
+		return false, err
+	}
+	for _, entry := range history {
+		if utils.CheckPassword(entry.PasswordHash, password) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func characterClasses(password string) int {
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+
+	classes := 0
+	for _, present := range []bool{lower, upper, digit, symbol} {
+		if present {
+			classes++
+		}
+	}
+	return classes
+}
+
+// containsPersonalInfo busca en la contraseña el email, su parte local y cada palabra del nombre.
+func containsPersonalInfo(user *models.User, password string) bool {
+	password = strings.ToLower(password)
+	email := NormalizeEmail(user.Email)
+	localPart, _, _ := strings.Cut(email, "@")
+
+	candidates := append([]string{email, localPart}, strings.Fields(strings.ToLower(user.Name))...)
+	for _, candidate := range candidates {
+		if utf8.RuneCountInString(candidate) >= minPersonalInfoLength && strings.Contains(password, candidate) {
+			return true
+		}
+	}
+	return false
+}
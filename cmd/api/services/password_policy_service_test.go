@@ -0,0 +1,140 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+func TestPasswordPolicyService(t *testing.T) {
+	user := &models.User{Name: "Ana María Pérez", Email: "ana.perez@example.com"}
+
+	testCases := []struct {
+		name              string
+		password          string
+		expectedViolation string
+	}{
+		{name: "valid password", password: "correct-horse-battery"},
+		{name: "too short", password: "a1-b2", expectedViolation: "at least 8 characters"},
+		{name: "too long", password: strings.Repeat("a1", 37), expectedViolation: "at most 72 bytes"},
+		{name: "single character class", password: "correcthorsebattery", expectedViolation: "at least 2 of"},
+		{name: "contains the name", password: "maría-2024-secret", expectedViolation: "name or email"},
+		{name: "contains the email", password: "Ana.Perez-secret", expectedViolation: "name or email"},
+		{name: "breached", password: "password123", expectedViolation: "breached"},
+	}
+
+	for _, tc := range testCases {
+		service := newTestPasswordPolicyService()
+
+		err := service.Validate(context.Background(), user, tc.password)
+
+		if tc.expectedViolation == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tc.name, err)
+			}
+			continue
+		}
+		var apiErr *utils.APIError
+		if !errors.As(err, &apiErr) || apiErr.Status != http.StatusBadRequest || apiErr.Code != "weak_password" {
+			t.Errorf("%s: expected weak password, got %v", tc.name, err)
+			continue
+		}
+		if !strings.Contains(apiErr.Message, tc.expectedViolation) {
+			t.Errorf("%s: expected %q in %q", tc.name, tc.expectedViolation, apiErr.Message)
+		}
+	}
+
+	t.Run("rejects the current and recent passwords", func(t *testing.T) {
+		service := newTestPasswordPolicyService()
+		user := &models.User{ID: 7, Email: "ana@example.com"}
+		for _, password := range []string{"first-passw0rd", "second-passw0rd", "third-passw0rd", "fourth-passw0rd"} {
+			if user.PasswordHash != "" {
+				if err := service.RecordChange(context.Background(), user.ID, user.PasswordHash); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+			user.PasswordHash, _ = newTestPasswordHasher().Hash(password)
+		}
+
+		for _, password := range []string{"fourth-passw0rd", "third-passw0rd", "second-passw0rd"} {
+			assertAPIStatus(t, service.Validate(context.Background(), user, password), http.StatusBadRequest)
+		}
+		if err := service.Validate(context.Background(), user, "first-passw0rd"); err != nil {
+			t.Errorf("expected passwords older than the history to be accepted, got %v", err)
+		}
+		if err := service.Validate(context.Background(), &models.User{Email: "new@example.com"}, "fourth-passw0rd"); err != nil {
+			t.Errorf("expected the history to be per user, got %v", err)
+		}
+	})
+}
+
+type fakePasswordHistoryRepository struct {
+	entries []models.PasswordHistory
+	nextID  uint
+}
+
+func (r *fakePasswordHistoryRepository) Create(_ context.Context, entry *models.PasswordHistory) error {
+	r.nextID++
+	entry.ID = r.nextID
+	r.entries = append(r.entries, *entry)
+	return nil
+}
+
+func (r *fakePasswordHistoryRepository) FindRecentByUserID(_ context.Context, userID uint, limit int) ([]models.PasswordHistory, error) {
+	var entries []models.PasswordHistory
+	for i := len(r.entries) - 1; i >= 0 && len(entries) < limit; i-- {
+		if r.entries[i].UserID == userID {
+			entries = append(entries, r.entries[i])
+		}
+	}
+	return entries, nil
+}
+
+func (r *fakePasswordHistoryRepository) Prune(ctx context.Context, userID uint, keep int) error {
+	recent, _ := r.FindRecentByUserID(ctx, userID, keep)
+	kept := r.entries[:0]
+	for _, entry := range r.entries {
+		if entry.UserID != userID || slices.ContainsFunc(recent, func(e models.PasswordHistory) bool { return e.ID == entry.ID }) {
+			kept = append(kept, entry)
+		}
+	}
+	r.entries = kept
+	return nil
+}
+
+type fakeBreachedPasswordRepository struct {
+	hashes []string
+}
+
+func (r *fakeBreachedPasswordRepository) FindSuffixes(_ context.Context, prefix string) ([]string, error) {
+	var suffixes []string
+	for _, hash := range r.hashes {
+		if strings.HasPrefix(hash, prefix) {
+			suffixes = append(suffixes, hash[len(prefix):])
+		}
+	}
+	return suffixes, nil
+}
+
+// newTestPasswordPolicyService rechaza "password123" como contraseña filtrada.
+func newTestPasswordPolicyService() *PasswordPolicyService {
+	service := NewPasswordPolicyService(
+		&fakePasswordHistoryRepository{},
+		&fakeBreachedPasswordRepository{hashes: []string{"CBFDAC6008F9CAB4083784CBD1874F76618D2A97"}},
+		config.PasswordPolicyConfig{
+			MinLength:            8,
+			MaxLength:            72,
+			MinCharacterClasses:  2,
+			DisallowPersonalInfo: true,
+			HistorySize:          2,
+		},
+	)
+	return service
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/mailer"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type IPasswordService interface {
+	ForgotPassword(ctx context.Context, request dto.ForgotPasswordRequest) error
+	ResetPassword(ctx context.Context, request dto.ResetPasswordRequest) error
+	ChangePassword(ctx context.Context, userID uint, request dto.ChangePasswordRequest) error
+}
+
+type PasswordService struct {
+	UserRepository        repositories.IUserRepository
+	Transactor            repositories.ITransactor
+	VerificationService   IVerificationService
+	RefreshTokenService   IRefreshTokenService
+	LockoutService        ILockoutService
+	PasswordPolicyService IPasswordPolicyService
+	PasswordHasher        IPasswordHasher
+	Mailer                mailer.IMailer
+	now                   func() time.Time
+}
+
+func NewPasswordService(
+	userRepository repositories.IUserRepository,
+	transactor repositories.ITransactor,
+	verificationService IVerificationService,
+	refreshTokenService IRefreshTokenService,
+	lockoutService ILockoutService,
+	passwordPolicyService IPasswordPolicyService,
+	passwordHasher IPasswordHasher,
+	mailer mailer.IMailer,
+) *PasswordService {
+	return &PasswordService{
+		UserRepository:        userRepository,
+		Transactor:            transactor,
+		VerificationService:   verificationService,
+		RefreshTokenService:   refreshTokenService,
+		LockoutService:        lockoutService,
+		PasswordPolicyService: passwordPolicyService,
+		PasswordHasher:        passwordHasher,
+		Mailer:                mailer,
+		now:                   time.Now,
+	}
+}
+
+// ForgotPassword siempre termina sin error para no revelar si el email está registrado.
+func (s *PasswordService) ForgotPassword(ctx context.Context, request dto.ForgotPasswordRequest) error {
+	user, err := s.UserRepository.FindByEmail(ctx, NormalizeEmail(request.Email))
+	if err != nil {
+		log.Error(ctx, "error finding user by email: ", err)
+		return nil
+	}
+	if user == nil {
+		return nil
+	}
+
+	// El token y el email se guardan juntos: si el email no se encola, el token tampoco queda vigente.
+	err = s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		ttl := time.Duration(constants.TimeCodeExpiration) * time.Hour
+		token, err := s.VerificationService.IssueToken(ctx, user.ID, models.CodePurposePasswordReset, ttl)
+		if err != nil {
+			return err
+		}
+
+		return s.Mailer.Send(ctx, mailer.Message{
+			To:       user.Email,
+			Locale:   user.Locale,
+			Template: constants.TemplatePasswordReset,
+			Data: map[string]string{
+				"name":  user.Name,
+				"token": token,
+			},
+		})
+	})
+	if err != nil {
+		log.Error(ctx, "error sending password reset email: ", err)
+	}
+	return nil
+}
+
+// ResetPassword valida la contraseña contra el usuario del token antes de canjearlo, para que una contraseña
+// rechazada no deje el enlace inservible. El canje y el cambio van en la misma transacción: si el cambio falla,
+// el token sigue vigente.
+func (s *PasswordService) ResetPassword(ctx context.Context, request dto.ResetPasswordRequest) error {
+	userID, err := s.VerificationService.FindToken(ctx, models.CodePurposePasswordReset, request.Token)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.UserRepository.FindByID(ctx, userID)
+	if err != nil || user == nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return utils.NewInternalServerError("could not reset password")
+	}
+	if err := s.PasswordPolicyService.Validate(ctx, user, request.Password); err != nil {
+		return err
+	}
+
+	err = s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if _, err := s.VerificationService.RedeemToken(ctx, models.CodePurposePasswordReset, request.Token); err != nil {
+			return err
+		}
+
+		// Recibir el enlace también demuestra que el usuario controla el email.
+		if !user.EmailVerified {
+			now := s.now()
+			user.EmailVerified = true
+			user.EmailVerifiedAt = &now
+		}
+		if err := s.updatePassword(ctx, user, request.Password); err != nil {
+			return utils.NewInternalServerError("could not reset password")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	// Quien recibió el enlace ya demostró ser el dueño de la cuenta: no debe seguir bloqueado.
+	s.LockoutService.Reset(ctx, user.Email)
+	return nil
+}
+
+// ChangePassword también cierra todas las sesiones, incluida la actual: el cliente debe volver a iniciar sesión.
+func (s *PasswordService) ChangePassword(ctx context.Context, userID uint, request dto.ChangePasswordRequest) error {
+	user, err := s.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return utils.NewInternalServerError("could not change password")
+	}
+	if user == nil {
+		return utils.NewNotFoundError("user not found")
+	}
+
+	if user.PasswordHash == "" || !utils.CheckPassword(user.PasswordHash, request.CurrentPassword) {
+		return utils.NewBadRequestError("current password is incorrect")
+	}
+	if err := s.PasswordPolicyService.Validate(ctx, user, request.NewPassword); err != nil {
+		return err
+	}
+
+	if err := s.updatePassword(ctx, user, request.NewPassword); err != nil {
+		return utils.NewInternalServerError("could not change password")
+	}
+	return nil
+}
+
+// updatePassword guarda la contraseña nueva y pasa la anterior al historial. PasswordChangedAt marca como
+// inválidas las credenciales emitidas antes del cambio y además se revocan los refresh tokens.
+func (s *PasswordService) updatePassword(ctx context.Context, user *models.User, password string) error {
+	passwordHash, err := s.PasswordHasher.Hash(password)
+	if err != nil {
+		log.Error(ctx, "error hashing password: ", err)
+		return err
+	}
+
+	now := s.now()
+	previousHash := user.PasswordHash
+	user.PasswordHash = passwordHash
+	user.PasswordChangedAt = &now
+	if err := s.UserRepository.Update(ctx, user); err != nil {
+		log.Error(ctx, "error updating user: ", err)
+		return err
+	}
+
+	if err := s.PasswordPolicyService.RecordChange(ctx, user.ID, previousHash); err != nil {
+		log.Error(ctx, "error recording password history: ", err)
+	}
+
+	if err := s.RefreshTokenService.RevokeAllForUser(ctx, user.ID); err != nil {
+		log.Error(ctx, "error revoking refresh tokens: ", err)
+		return err
+	}
+	return nil
+}
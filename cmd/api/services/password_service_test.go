@@ -0,0 +1,104 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+func TestPasswordService(t *testing.T) {
+
+	t.Run("reset password", func(t *testing.T) {
+		users := newFakeUserRepository()
+		mails := &fakeMailer{}
+		now := time.Now()
+		refreshTokens := newTestRefreshTokenService(newFakeRefreshTokenRepository(), &now)
+		service := NewPasswordService(users, fakeTransactor{}, NewVerificationService(users, newFakeVerificationCodeRepository(), fakeTransactor{}, mails), refreshTokens, newTestLockoutService(users, &now), newTestPasswordPolicyService(), newTestPasswordHasher(), mails)
+		hash, _ := newTestPasswordHasher().Hash("s3cret-password")
+		user := &models.User{Name: "Ana", Email: "ana@example.com", PasswordHash: hash}
+		_ = users.Create(context.Background(), user)
+		session, _ := refreshTokens.Issue(context.Background(), user.ID, "")
+
+		if err := service.ForgotPassword(context.Background(), dto.ForgotPasswordRequest{Email: "ANA@example.com"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		message := mails.last()
+		if message.Template != constants.TemplatePasswordReset || message.Data["token"] == "" {
+			t.Fatalf("unexpected message: %+v", message)
+		}
+		if mails.outsideTransaction != 0 {
+			t.Errorf("expected the reset email to be enqueued in the token transaction")
+		}
+
+		for _, password := range []string{"s3cret-password", "ana-password-1"} {
+			err := service.ResetPassword(context.Background(), dto.ResetPasswordRequest{Token: message.Data["token"], Password: password})
+			assertAPIStatus(t, err, http.StatusBadRequest)
+		}
+
+		request := dto.ResetPasswordRequest{Token: message.Data["token"], Password: "new-password"}
+		if err := service.ResetPassword(context.Background(), request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		updated, _ := users.FindByID(context.Background(), user.ID)
+		if !utils.CheckPassword(updated.PasswordHash, "new-password") || updated.PasswordChangedAt == nil {
+			t.Errorf("expected password to be rotated")
+		}
+
+		if _, _, err := refreshTokens.Rotate(context.Background(), session, ""); err == nil {
+			t.Errorf("expected existing sessions to be revoked")
+		}
+		if err := service.ResetPassword(context.Background(), request); err == nil {
+			t.Errorf("expected token to be single use")
+		}
+	})
+
+	t.Run("forgot password does not reveal unknown emails", func(t *testing.T) {
+		users := newFakeUserRepository()
+		mails := &fakeMailer{}
+		now := time.Now()
+		refreshTokens := newTestRefreshTokenService(newFakeRefreshTokenRepository(), &now)
+		service := NewPasswordService(users, fakeTransactor{}, NewVerificationService(users, newFakeVerificationCodeRepository(), fakeTransactor{}, mails), refreshTokens, newTestLockoutService(users, &now), newTestPasswordPolicyService(), newTestPasswordHasher(), mails)
+
+		err := service.ForgotPassword(context.Background(), dto.ForgotPasswordRequest{Email: "nobody@example.com"})
+		if err != nil || len(mails.messages) != 0 {
+			t.Errorf("unexpected result: err %v, messages %d", err, len(mails.messages))
+		}
+	})
+	t.Run("change password", func(t *testing.T) {
+		users := newFakeUserRepository()
+		mails := &fakeMailer{}
+		now := time.Now()
+		refreshTokens := newTestRefreshTokenService(newFakeRefreshTokenRepository(), &now)
+		service := NewPasswordService(users, fakeTransactor{}, NewVerificationService(users, newFakeVerificationCodeRepository(), fakeTransactor{}, mails), refreshTokens, newTestLockoutService(users, &now), newTestPasswordPolicyService(), newTestPasswordHasher(), mails)
+		hash, _ := newTestPasswordHasher().Hash("s3cret-password")
+		user := &models.User{Name: "Ana", Email: "ana@example.com", PasswordHash: hash}
+		_ = users.Create(context.Background(), user)
+		session, _ := refreshTokens.Issue(context.Background(), user.ID, "")
+
+		err := service.ChangePassword(context.Background(), user.ID, dto.ChangePasswordRequest{CurrentPassword: "wrong", NewPassword: "new-passw0rd"})
+		assertAPIStatus(t, err, http.StatusBadRequest)
+		err = service.ChangePassword(context.Background(), user.ID, dto.ChangePasswordRequest{CurrentPassword: "s3cret-password", NewPassword: "s3cret-password"})
+		assertAPIStatus(t, err, http.StatusBadRequest)
+
+		request := dto.ChangePasswordRequest{CurrentPassword: "s3cret-password", NewPassword: "new-passw0rd"}
+		if err := service.ChangePassword(context.Background(), user.ID, request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		updated, _ := users.FindByID(context.Background(), user.ID)
+		if !utils.CheckPassword(updated.PasswordHash, "new-passw0rd") || updated.PasswordChangedAt == nil {
+			t.Errorf("expected password to be changed")
+		}
+		if _, _, err := refreshTokens.Rotate(context.Background(), session, ""); err == nil {
+			t.Errorf("expected existing sessions to be revoked")
+		}
+
+		err = service.ChangePassword(context.Background(), user.ID, dto.ChangePasswordRequest{CurrentPassword: "new-passw0rd", NewPassword: "s3cret-password"})
+		assertAPIStatus(t, err, http.StatusBadRequest)
+	})
+}
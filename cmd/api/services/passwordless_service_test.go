@@ -0,0 +1,109 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+)
+
+func TestPasswordlessService(t *testing.T) {
+	newService := func(now *time.Time) (*PasswordlessService, *fakeUserRepository, *fakeMailer) {
+		users := newFakeUserRepository()
+		_ = users.Create(context.Background(), &models.User{Name: "Ana", Email: "ana@example.com"})
+		mails := &fakeMailer{}
+		verificationService := NewVerificationService(users, newFakeVerificationCodeRepository(), fakeTransactor{}, mails)
+		verificationService.now = func() time.Time { return *now }
+		return newTestPasswordlessService(users, verificationService, mails), users, mails
+	}
+
+	t.Run("magic link", func(t *testing.T) {
+		now := time.Now()
+		service, users, mails := newService(&now)
+
+		if err := service.SendLoginEmail(context.Background(), dto.PasswordlessStartRequest{Email: "ANA@example.com"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		message := mails.last()
+		if message.Template != constants.TemplateMagicLink || message.Data["token"] == "" || mails.outsideTransaction != 0 {
+			t.Fatalf("unexpected message: %+v", message)
+		}
+
+		request := dto.PasswordlessLoginRequest{Token: message.Data["token"]}
+		user, err := service.Redeem(context.Background(), request)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		stored, _ := users.FindByID(context.Background(), user.ID)
+		if !stored.EmailVerified || stored.EmailVerifiedAt == nil {
+			t.Errorf("expected email to be verified by the login link")
+		}
+
+		_, err = service.Redeem(context.Background(), request)
+		assertAPIStatus(t, err, http.StatusUnauthorized)
+	})
+
+	t.Run("one-time code", func(t *testing.T) {
+		now := time.Now()
+		service, _, mails := newService(&now)
+
+		request := dto.PasswordlessStartRequest{Email: "ana@example.com", Method: constants.PasswordlessMethodCode}
+		if err := service.SendLoginEmail(context.Background(), request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		message := mails.last()
+		if message.Template != constants.TemplateLoginCode || len(message.Data["code"]) != constants.VerificationCodeLength {
+			t.Fatalf("unexpected message: %+v", message)
+		}
+
+		wrong := "000000"
+		if message.Data["code"] == wrong {
+			wrong = "111111"
+		}
+		_, err := service.Redeem(context.Background(), dto.PasswordlessLoginRequest{Email: "ana@example.com", Code: wrong})
+		assertAPIStatus(t, err, http.StatusUnauthorized)
+
+		_, err = service.Redeem(context.Background(), dto.PasswordlessLoginRequest{Email: "bob@example.com", Code: message.Data["code"]})
+		assertAPIStatus(t, err, http.StatusUnauthorized)
+
+		user, err := service.Redeem(context.Background(), dto.PasswordlessLoginRequest{Email: "ana@example.com", Code: message.Data["code"]})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.Email != "ana@example.com" {
+			t.Errorf("unexpected user: %+v", user)
+		}
+	})
+
+	t.Run("expired link", func(t *testing.T) {
+		now := time.Now()
+		service, _, mails := newService(&now)
+
+		_ = service.SendLoginEmail(context.Background(), dto.PasswordlessStartRequest{Email: "ana@example.com"})
+		now = now.Add(time.Duration(constants.PasswordlessExpiration+1) * time.Minute)
+
+		_, err := service.Redeem(context.Background(), dto.PasswordlessLoginRequest{Token: mails.last().Data["token"]})
+		assertAPIStatus(t, err, http.StatusUnauthorized)
+	})
+
+	t.Run("does not reveal unknown emails", func(t *testing.T) {
+		now := time.Now()
+		service, _, mails := newService(&now)
+
+		err := service.SendLoginEmail(context.Background(), dto.PasswordlessStartRequest{Email: "nobody@example.com"})
+		if err != nil || len(mails.messages) != 0 {
+			t.Errorf("unexpected result: err %v, messages %d", err, len(mails.messages))
+		}
+	})
+}
+
+func newTestPasswordlessService(users *fakeUserRepository, verificationService *VerificationService, mails *fakeMailer) *PasswordlessService {
+	service := NewPasswordlessService(users, fakeTransactor{}, verificationService, mails, config.MessageDeliveryConfig{})
+	service.now = verificationService.now
+	return service
+}
@@ -0,0 +1,200 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/messaging"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+const phoneCodeThrottlePrefix = "phone_code:"
+
+type IPhoneService interface {
+	StartVerification(ctx context.Context, userID uint, request dto.PhoneCodeRequest) error
+	ConfirmVerification(ctx context.Context, userID uint, request dto.ConfirmPhoneRequest) (*models.User, error)
+	SendLoginCode(ctx context.Context, request dto.PhoneCodeRequest) error
+}
+
+// PhoneService verifica números de teléfono y envía códigos de un solo uso por SMS o WhatsApp. Cada número recibe
+// como máximo config.MessageDeliveryConfig.MaxCodesPerNumber códigos por ventana, sin importar quién los pida: cada
+// mensaje cuesta y el límite por IP no frena a quien reparte las peticiones entre muchas IPs.
+type PhoneService struct {
+	UserRepository      repositories.IUserRepository
+	VerificationService IVerificationService
+	RateLimitRepository repositories.IRateLimitRepository
+	Messenger           messaging.IMessenger
+	config              config.MessageDeliveryConfig
+	now                 func() time.Time
+}
+
+func NewPhoneService(
+	userRepository repositories.IUserRepository,
+	verificationService IVerificationService,
+	rateLimitRepository repositories.IRateLimitRepository,
+	messenger messaging.IMessenger,
+	messagingConfig config.MessageDeliveryConfig,
+) *PhoneService {
+	return &PhoneService{
+		UserRepository:      userRepository,
+		VerificationService: verificationService,
+		RateLimitRepository: rateLimitRepository,
+		Messenger:           messenger,
+		config:              messagingConfig,
+		now:                 time.Now,
+	}
+}
+
+// StartVerification deja el número como pendiente y le envía un código; el número verificado anterior sigue
+// vigente hasta confirmar el nuevo.
+func (s *PhoneService) StartVerification(ctx context.Context, userID uint, request dto.PhoneCodeRequest) error {
+	phone, err := s.normalize(request.Phone)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return utils.NewInternalServerError("could not verify phone number")
+	}
+	if user == nil {
+		return utils.NewNotFoundError("user not found")
+	}
+	if user.PhoneNumber == phone {
+		return utils.NewConflictError("phone number already verified")
+	}
+
+	owner, err := s.UserRepository.FindByPhoneNumber(ctx, phone)
+	if err != nil {
+		log.Error(ctx, "error finding user by phone number: ", err)
+		return utils.NewInternalServerError("could not verify phone number")
+	}
+	if owner != nil {
+		return utils.NewConflictError("phone number already registered")
+	}
+
+	if err := s.throttle(ctx, phone); err != nil {
+		return err
+	}
+
+	user.PendingPhoneNumber = phone
+	if err := s.UserRepository.Update(ctx, user); err != nil {
+		log.Error(ctx, "error updating user: ", err)
+		return utils.NewInternalServerError("could not verify phone number")
+	}
+	return s.sendCode(ctx, user, phone, request.Channel, models.CodePurposeVerifyPhone)
+}
+
+func (s *PhoneService) ConfirmVerification(ctx context.Context, userID uint, request dto.ConfirmPhoneRequest) (*models.User, error) {
+	user, err := s.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return nil, utils.NewInternalServerError("could not verify phone number")
+	}
+	if user == nil {
+		return nil, utils.NewNotFoundError("user not found")
+	}
+	if user.PendingPhoneNumber == "" {
+		return nil, utils.NewBadRequestError("invalid or expired code")
+	}
+
+	if err := s.VerificationService.RedeemCode(ctx, user.ID, models.CodePurposeVerifyPhone, request.Code); err != nil {
+		return nil, err
+	}
+
+	now := s.now()
+	user.PhoneNumber = user.PendingPhoneNumber
+	user.PhoneVerifiedAt = &now
+	user.PendingPhoneNumber = ""
+	if err := s.UserRepository.Update(ctx, user); err != nil {
+		if errors.Is(err, repositories.ErrDuplicatedPhoneNumber) {
+			return nil, utils.NewConflictError("phone number already registered")
+		}
+		log.Error(ctx, "error updating user: ", err)
+		return nil, utils.NewInternalServerError("could not verify phone number")
+	}
+	return user, nil
+}
+
+// SendLoginCode solo envía a números verificados y, salvo un número mal escrito o el límite de envíos, termina
+// sin error para no revelar qué números están registrados. El límite se aplica antes de buscar al usuario por
+// la misma razón.
+func (s *PhoneService) SendLoginCode(ctx context.Context, request dto.PhoneCodeRequest) error {
+	phone, err := s.normalize(request.Phone)
+	if err != nil {
+		return err
+	}
+	if err := s.throttle(ctx, phone); err != nil {
+		return err
+	}
+
+	user, err := s.UserRepository.FindByPhoneNumber(ctx, phone)
+	if err != nil {
+		log.Error(ctx, "error finding user by phone number: ", err)
+		return nil
+	}
+	if user == nil {
+		return nil
+	}
+
+	if err := s.sendCode(ctx, user, phone, request.Channel, models.CodePurposeLoginCode); err != nil {
+		log.Error(ctx, "error sending phone login code: ", err)
+	}
+	return nil
+}
+
+func (s *PhoneService) normalize(phone string) (string, error) {
+	normalized, err := utils.NormalizePhoneNumber(phone, s.config.DefaultCountryCode)
+	if err != nil {
+		return "", utils.NewBadRequestError("invalid phone number")
+	}
+	return normalized, nil
+}
+
+// throttle deja pasar el envío si el almacén de contadores falla, igual que el rate limiting de las rutas.
+func (s *PhoneService) throttle(ctx context.Context, phone string) error {
+	rate := float64(s.config.MaxCodesPerNumber) / s.config.CodeWindow.Seconds()
+	wait, err := s.RateLimitRepository.Take(ctx, phoneCodeThrottlePrefix+phone, rate, s.config.MaxCodesPerNumber, s.now())
+	if err != nil {
+		log.Error(ctx, "error taking phone code token: ", err)
+		return nil
+	}
+	if wait > 0 {
+		return utils.NewTooManyRequestsError("too many codes sent to this phone number, try again later", wait)
+	}
+	return nil
+}
+
+func (s *PhoneService) sendCode(ctx context.Context, user *models.User, phone, channel, purpose string) error {
+	if channel == "" {
+		channel = constants.MessageChannelSMS
+	}
+
+	code, err := s.VerificationService.IssueCode(ctx, user.ID, purpose, time.Duration(constants.PhoneCodeExpiration)*time.Minute)
+	if err != nil {
+		log.Error(ctx, "error issuing phone code: ", err)
+		return utils.NewInternalServerError("could not send code")
+	}
+
+	err = s.Messenger.Send(ctx, messaging.Message{
+		To:      phone,
+		Channel: channel,
+		Text:    messaging.CodeText(utils.RequestLocale(ctx, user.Locale), code, constants.PhoneCodeExpiration),
+	})
+	if errors.Is(err, messaging.ErrInvalidRecipient) {
+		return utils.NewBadRequestError("phone number cannot receive messages")
+	}
+	if err != nil {
+		log.Error(ctx, "error sending phone code: ", err)
+		return utils.NewBadGatewayError("could not send code")
+	}
+	return nil
+}
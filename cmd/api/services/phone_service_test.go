@@ -0,0 +1,146 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/messaging"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+)
+
+func TestPhoneService(t *testing.T) {
+	ctx := context.Background()
+	newService := func() (*PhoneService, *fakeUserRepository, *fakeMessenger, *time.Time) {
+		verificationService, users, _, now := newTestVerificationService()
+		messenger := &fakeMessenger{}
+		_ = users.Create(ctx, &models.User{Name: "Ana", Email: "ana@example.com", Locale: constants.LocaleEnglish})
+		return newTestPhoneService(users, verificationService, messenger), users, messenger, now
+	}
+
+	t.Run("verifies a national number in e164", func(t *testing.T) {
+		service, users, messenger, _ := newService()
+
+		if err := service.StartVerification(ctx, 1, dto.PhoneCodeRequest{Phone: "300 123 4567", Channel: constants.MessageChannelWhatsApp}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		message := messenger.messages[0]
+		if message.To != "+573001234567" || message.Channel != constants.MessageChannelWhatsApp || message.Text != messaging.CodeText(constants.LocaleEnglish, messenger.lastCode(), constants.PhoneCodeExpiration) {
+			t.Fatalf("unexpected message: %+v", message)
+		}
+		if pending, _ := users.FindByID(ctx, 1); pending.PhoneNumber != "" || pending.PendingPhoneNumber != "+573001234567" {
+			t.Fatalf("expected the number to be pending, got %+v", pending)
+		}
+
+		_, err := service.ConfirmVerification(ctx, 1, dto.ConfirmPhoneRequest{Code: "000000"})
+		assertAPIStatus(t, err, http.StatusBadRequest)
+
+		user, err := service.ConfirmVerification(ctx, 1, dto.ConfirmPhoneRequest{Code: messenger.lastCode()})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.PhoneNumber != "+573001234567" || user.PhoneVerifiedAt == nil || user.PendingPhoneNumber != "" {
+			t.Errorf("unexpected user: %+v", user)
+		}
+	})
+
+	t.Run("rejects invalid numbers and numbers of other users", func(t *testing.T) {
+		service, users, messenger, _ := newService()
+		_ = users.Create(ctx, &models.User{Email: "bob@example.com", PhoneNumber: "+573001234567"})
+
+		assertAPIStatus(t, service.StartVerification(ctx, 1, dto.PhoneCodeRequest{Phone: "12ab"}), http.StatusBadRequest)
+		assertAPIStatus(t, service.StartVerification(ctx, 1, dto.PhoneCodeRequest{Phone: "+57 300 123 4567"}), http.StatusConflict)
+		if len(messenger.messages) != 0 {
+			t.Errorf("expected no messages to be sent")
+		}
+	})
+
+	t.Run("throttles codes per number", func(t *testing.T) {
+		service, users, messenger, now := newService()
+		_ = users.Create(ctx, &models.User{Email: "bob@example.com"})
+
+		_ = service.StartVerification(ctx, 1, dto.PhoneCodeRequest{Phone: "3001234567"})
+		_ = service.StartVerification(ctx, 2, dto.PhoneCodeRequest{Phone: "+573001234567"})
+		err := service.StartVerification(ctx, 1, dto.PhoneCodeRequest{Phone: "3001234567"})
+		assertAPIStatus(t, err, http.StatusTooManyRequests)
+		if len(messenger.messages) != 2 {
+			t.Errorf("expected two messages, got %d", len(messenger.messages))
+		}
+
+		*now = now.Add(5 * time.Minute)
+		if err := service.StartVerification(ctx, 1, dto.PhoneCodeRequest{Phone: "3001234567"}); err != nil {
+			t.Errorf("expected a code after the window refills, got %v", err)
+		}
+	})
+
+	t.Run("reports numbers the provider rejects", func(t *testing.T) {
+		service, _, messenger, _ := newService()
+		messenger.err = messaging.ErrInvalidRecipient
+		assertAPIStatus(t, service.StartVerification(ctx, 1, dto.PhoneCodeRequest{Phone: "3001234567"}), http.StatusBadRequest)
+
+		messenger.err = errors.New("provider is down")
+		assertAPIStatus(t, service.StartVerification(ctx, 1, dto.PhoneCodeRequest{Phone: "3001234568"}), http.StatusBadGateway)
+	})
+
+	t.Run("logs in with a code sent to a verified number", func(t *testing.T) {
+		service, users, messenger, _ := newService()
+		user, _ := users.FindByID(ctx, 1)
+		user.PhoneNumber = "+573001234567"
+		_ = users.Update(ctx, user)
+		passwordless := newTestPasswordlessService(users, service.VerificationService.(*VerificationService), &fakeMailer{})
+		passwordless.defaultCountryCode = "57"
+
+		if err := service.SendLoginCode(ctx, dto.PhoneCodeRequest{Phone: "+573009999999"}); err != nil || len(messenger.messages) != 0 {
+			t.Fatalf("expected unknown numbers to be ignored silently, got %v", err)
+		}
+		if err := service.SendLoginCode(ctx, dto.PhoneCodeRequest{Phone: "300-123-4567"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		loggedIn, err := passwordless.Redeem(ctx, dto.PasswordlessLoginRequest{Phone: "3001234567", Code: messenger.lastCode()})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if loggedIn.ID != 1 || loggedIn.EmailVerified {
+			t.Errorf("expected the phone login not to verify the email, got %+v", loggedIn)
+		}
+	})
+}
+
+type fakeMessenger struct {
+	messages []messaging.Message
+	err      error
+}
+
+func (m *fakeMessenger) Send(_ context.Context, message messaging.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+// lastCode saca el código de 6 dígitos del último mensaje.
+func (m *fakeMessenger) lastCode() string {
+	text := m.messages[len(m.messages)-1].Text
+	for i := 0; i+6 <= len(text); i++ {
+		if code := text[i : i+6]; strings.Trim(code, "0123456789") == "" {
+			return code
+		}
+	}
+	return ""
+}
+
+func newTestPhoneService(users *fakeUserRepository, verificationService *VerificationService, messenger *fakeMessenger) *PhoneService {
+	service := NewPhoneService(users, verificationService, repositories.NewMemoryRateLimitRepository(), messenger,
+		config.MessageDeliveryConfig{DefaultCountryCode: "57", MaxCodesPerNumber: 2, CodeWindow: 10 * time.Minute})
+	service.now = verificationService.now
+	return service
+}
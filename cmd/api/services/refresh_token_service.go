@@ -0,0 +1,255 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+const (
+	refreshTokenSize   = 32
+	maxUserAgentLength = 512
+)
+
+type IRefreshTokenService interface {
+	Issue(ctx context.Context, userID uint, familyID string) (string, error)
+	IssueForClient(ctx context.Context, userID uint, familyID, clientID, scope string) (string, error)
+	StartSession(ctx context.Context, userID uint, familyID, clientID string) error
+	SessionRevoked(ctx context.Context, familyID string) (bool, error)
+	Rotate(ctx context.Context, token, clientID string) (*models.RefreshToken, string, error)
+	Find(ctx context.Context, token string) (*models.RefreshToken, error)
+	Revoke(ctx context.Context, token string) error
+	RevokeFamily(ctx context.Context, familyID string) error
+	RevokeAllForUser(ctx context.Context, userID uint) error
+}
+
+// RefreshTokenService también lleva las sesiones: cada familia de refresh tokens es una sesión, que se crea con el
+// primer token, se actualiza en cada rotación y se revoca junto con la familia.
+type RefreshTokenService struct {
+	RefreshTokenRepository repositories.IRefreshTokenRepository
+	SessionRepository      repositories.ISessionRepository
+	Transactor             repositories.ITransactor
+	RefreshTokenTTL        time.Duration
+	now                    func() time.Time
+}
+
+func NewRefreshTokenService(
+	refreshTokenRepository repositories.IRefreshTokenRepository,
+	sessionRepository repositories.ISessionRepository,
+	transactor repositories.ITransactor,
+	authConfig config.AuthTokenConfig,
+) *RefreshTokenService {
+	return &RefreshTokenService{
+		RefreshTokenRepository: refreshTokenRepository,
+		SessionRepository:      sessionRepository,
+		Transactor:             transactor,
+		RefreshTokenTTL:        authConfig.RefreshTokenTTL,
+		now:                    time.Now,
+	}
+}
+
+// Issue guarda un nuevo refresh token; con familyID vacío el token inicia una familia y una sesión nuevas.
+func (s *RefreshTokenService) Issue(ctx context.Context, userID uint, familyID string) (string, error) {
+	return s.IssueForClient(ctx, userID, familyID, "", "")
+}
+
+// IssueForClient es como Issue pero ata el token a un cliente OAuth y a los scopes que se le concedieron.
+func (s *RefreshTokenService) IssueForClient(ctx context.Context, userID uint, familyID, clientID, scope string) (string, error) {
+	token, err := utils.GenerateToken(refreshTokenSize)
+	if err != nil {
+		return "", err
+	}
+	now := s.now()
+	if familyID == "" {
+		if familyID, err = utils.GenerateToken(16); err != nil {
+			return "", err
+		}
+		if _, err = s.createSession(ctx, userID, familyID, clientID, now); err != nil {
+			return "", err
+		}
+	}
+
+	err = s.RefreshTokenRepository.Create(ctx, &models.RefreshToken{
+		UserID:    userID,
+		FamilyID:  familyID,
+		ClientID:  clientID,
+		Scope:     scope,
+		TokenHash: utils.HashToken(token),
+		ExpiresAt: now.Add(s.RefreshTokenTTL),
+	})
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// StartSession crea la sesión de una familia nueva antes de su primer refresh token, para que el access token
+// que la acompaña ya quede atado a una sesión que se puede revocar.
+func (s *RefreshTokenService) StartSession(ctx context.Context, userID uint, familyID, clientID string) error {
+	_, err := s.createSession(ctx, userID, familyID, clientID, s.now())
+	return err
+}
+
+// SessionRevoked indica si la sesión de la familia fue revocada. Las familias sin sesión, anteriores a las
+// sesiones, no cuentan como revocadas.
+func (s *RefreshTokenService) SessionRevoked(ctx context.Context, familyID string) (bool, error) {
+	session, err := s.SessionRepository.FindByFamilyID(ctx, familyID)
+	if err != nil || session == nil {
+		return false, err
+	}
+	return session.RevokedAt != nil, nil
+}
+
+// Rotate canjea un refresh token por uno nuevo de la misma familia y devuelve el registro canjeado. Presentar
+// un token ya rotado o revocado se trata como robo y revoca toda la familia. clientID es el cliente OAuth que
+// presenta el token, vacío para el login propio; un token de otro cliente se rechaza sin rotarlo. Un token de una
+// sesión revocada tampoco se rota.
+func (s *RefreshTokenService) Rotate(ctx context.Context, token, clientID string) (*models.RefreshToken, string, error) {
+	now := s.now()
+	stored, err := s.RefreshTokenRepository.FindByHash(ctx, utils.HashToken(token))
+	if err != nil {
+		log.Error(ctx, "error finding refresh token: ", err)
+		return nil, "", utils.NewInternalServerError("could not refresh token")
+	}
+	if stored == nil || stored.ClientID != clientID {
+		return nil, "", utils.NewUnauthorizedError("invalid refresh token")
+	}
+
+	if stored.RotatedAt != nil || stored.RevokedAt != nil {
+		s.revokeFamily(ctx, stored, now)
+		return nil, "", utils.NewUnauthorizedError("invalid refresh token")
+	}
+	if !stored.IsActive(now) {
+		return nil, "", utils.NewUnauthorizedError("invalid refresh token")
+	}
+
+	session, err := s.findSession(ctx, stored, now)
+	if err != nil {
+		log.Error(ctx, "error finding session: ", err)
+		return nil, "", utils.NewInternalServerError("could not refresh token")
+	}
+	if session.RevokedAt != nil {
+		return nil, "", utils.NewUnauthorizedError("invalid refresh token")
+	}
+
+	// Marcar el token y emitir el siguiente van en la misma transacción: si la emisión falla, el token
+	// presentado sigue vigente y el cliente puede reintentar sin que se detecte un falso reuso.
+	var next string
+	err = s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.RefreshTokenRepository.MarkRotated(ctx, stored.ID, now); err != nil {
+			return err
+		}
+		next, err = s.IssueForClient(ctx, stored.UserID, stored.FamilyID, stored.ClientID, stored.Scope)
+		return err
+	})
+	if errors.Is(err, repositories.ErrTokenNotActive) {
+		s.revokeFamily(ctx, stored, now)
+		return nil, "", utils.NewUnauthorizedError("invalid refresh token")
+	}
+	if err != nil {
+		log.Error(ctx, "error rotating refresh token: ", err)
+		return nil, "", utils.NewInternalServerError("could not refresh token")
+	}
+
+	s.touchSession(ctx, session, now)
+	if err := s.SessionRepository.UpdateActivity(ctx, session); err != nil {
+		log.Error(ctx, "error updating session: ", err)
+	}
+	return stored, next, nil
+}
+
+// Find devuelve nil, nil cuando el token no existe.
+func (s *RefreshTokenService) Find(ctx context.Context, token string) (*models.RefreshToken, error) {
+	return s.RefreshTokenRepository.FindByHash(ctx, utils.HashToken(token))
+}
+
+// Revoke revoca la familia del token, es decir, la sesión completa; un token desconocido se ignora.
+func (s *RefreshTokenService) Revoke(ctx context.Context, token string) error {
+	stored, err := s.Find(ctx, token)
+	if err != nil || stored == nil {
+		return err
+	}
+	return s.RevokeFamily(ctx, stored.FamilyID)
+}
+
+func (s *RefreshTokenService) RevokeFamily(ctx context.Context, familyID string) error {
+	now := s.now()
+	if err := s.SessionRepository.RevokeByFamilyID(ctx, familyID, now); err != nil {
+		return err
+	}
+	return s.RefreshTokenRepository.RevokeFamily(ctx, familyID, now)
+}
+
+func (s *RefreshTokenService) RevokeAllForUser(ctx context.Context, userID uint) error {
+	now := s.now()
+	if err := s.SessionRepository.RevokeAllForUser(ctx, userID, now); err != nil {
+		return err
+	}
+	return s.RefreshTokenRepository.RevokeAllForUser(ctx, userID, now)
+}
+
+func (s *RefreshTokenService) revokeFamily(ctx context.Context, token *models.RefreshToken, now time.Time) {
+	log.Warn(ctx, fmt.Sprintf("refresh token reuse detected, user: %v, family: %v", token.UserID, token.FamilyID))
+	if err := s.SessionRepository.RevokeByFamilyID(ctx, token.FamilyID, now); err != nil {
+		log.Error(ctx, "error revoking session: ", err)
+	}
+	if err := s.RefreshTokenRepository.RevokeFamily(ctx, token.FamilyID, now); err != nil {
+		log.Error(ctx, "error revoking refresh token family: ", err)
+	}
+}
+
+func (s *RefreshTokenService) createSession(ctx context.Context, userID uint, familyID, clientID string, now time.Time) (*models.Session, error) {
+	session := &models.Session{
+		UserID:    userID,
+		FamilyID:  familyID,
+		ClientID:  clientID,
+		CreatedAt: now,
+	}
+	s.touchSession(ctx, session, now)
+	if err := s.SessionRepository.Create(ctx, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// findSession crea la sesión de las familias emitidas antes de que existieran las sesiones.
+func (s *RefreshTokenService) findSession(ctx context.Context, token *models.RefreshToken, now time.Time) (*models.Session, error) {
+	session, err := s.SessionRepository.FindByFamilyID(ctx, token.FamilyID)
+	if err != nil || session != nil {
+		return session, err
+	}
+	return s.createSession(ctx, token.UserID, token.FamilyID, token.ClientID, now)
+}
+
+// touchSession registra el uso de la sesión con el dispositivo de la petición actual.
+func (s *RefreshTokenService) touchSession(ctx context.Context, session *models.Session, now time.Time) {
+	info := utils.ClientInfoFromContext(ctx)
+	session.LastSeenAt = now
+	session.ExpiresAt = now.Add(s.RefreshTokenTTL)
+	if info.UserAgent != "" || session.DeviceName == "" {
+		session.UserAgent = truncate(info.UserAgent, maxUserAgentLength)
+		session.DeviceName = utils.DeviceName(info.UserAgent)
+	}
+	if info.IPAddress != "" {
+		session.IPAddress = info.IPAddress
+	}
+}
+
+// truncate corta value a limit bytes sin partir un carácter UTF-8.
+func truncate(value string, limit int) string {
+	if len(value) <= limit {
+		return value
+	}
+	for limit > 0 && !utf8.RuneStart(value[limit]) {
+		limit--
+	}
+	return value[:limit]
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+)
+
+func TestRefreshTokenService(t *testing.T) {
+
+	t.Run("rotate", func(t *testing.T) {
+		now := time.Now()
+		repository := newFakeRefreshTokenRepository()
+		service := newTestRefreshTokenService(repository, &now)
+
+		first, _ := service.Issue(context.Background(), 7, "")
+		rotated, second, err := service.Rotate(context.Background(), first, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if rotated.UserID != 7 || second == "" || second == first {
+			t.Errorf("unexpected rotation: %+v, %q", rotated, second)
+		}
+		if repository.outsideTransaction != 0 {
+			t.Errorf("expected the rotation to run in a transaction")
+		}
+
+		if _, _, err := service.Rotate(context.Background(), second, ""); err != nil {
+			t.Errorf("unexpected error rotating the new token: %v", err)
+		}
+	})
+
+	t.Run("reuse revokes the family", func(t *testing.T) {
+		now := time.Now()
+		repository := newFakeRefreshTokenRepository()
+		service := newTestRefreshTokenService(repository, &now)
+
+		first, _ := service.Issue(context.Background(), 7, "")
+		other, _ := service.Issue(context.Background(), 7, "")
+		_, second, _ := service.Rotate(context.Background(), first, "")
+
+		if _, _, err := service.Rotate(context.Background(), first, ""); err == nil {
+			t.Errorf("expected reused token to be rejected")
+		}
+		if _, _, err := service.Rotate(context.Background(), second, ""); err == nil {
+			t.Errorf("expected every token of the family to be revoked")
+		}
+		if _, _, err := service.Rotate(context.Background(), other, ""); err != nil {
+			t.Errorf("unexpected error for a token of another family: %v", err)
+		}
+	})
+
+	t.Run("rejects expired and revoked tokens", func(t *testing.T) {
+		now := time.Now()
+		service := newTestRefreshTokenService(newFakeRefreshTokenRepository(), &now)
+
+		expired, _ := service.Issue(context.Background(), 7, "")
+		now = now.Add(25 * time.Hour)
+		if _, _, err := service.Rotate(context.Background(), expired, ""); err == nil {
+			t.Errorf("expected expired token to be rejected")
+		}
+
+		revoked, _ := service.Issue(context.Background(), 7, "")
+		_ = service.RevokeAllForUser(context.Background(), 7)
+		if _, _, err := service.Rotate(context.Background(), revoked, ""); err == nil {
+			t.Errorf("expected revoked token to be rejected")
+		}
+	})
+
+	t.Run("tokens stay bound to their client", func(t *testing.T) {
+		now := time.Now()
+		service := newTestRefreshTokenService(newFakeRefreshTokenRepository(), &now)
+
+		token, _ := service.IssueForClient(context.Background(), 7, "", "partner", "tasks:read")
+		if _, _, err := service.Rotate(context.Background(), token, ""); err == nil {
+			t.Errorf("expected a client token to be rejected by the first-party refresh")
+		}
+		if _, _, err := service.Rotate(context.Background(), token, "other"); err == nil {
+			t.Errorf("expected a token of another client to be rejected")
+		}
+
+		rotated, next, err := service.Rotate(context.Background(), token, "partner")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		stored, _ := service.Find(context.Background(), next)
+		if rotated.Scope != "tasks:read" || stored.ClientID != "partner" || stored.Scope != "tasks:read" {
+			t.Errorf("expected client and scope to carry over: %+v", stored)
+		}
+	})
+}
+
+type fakeRefreshTokenRepository struct {
+	tokens []*models.RefreshToken
+	nextID uint
+	// outsideTransaction cuenta las rotaciones marcadas fuera de una transacción.
+	outsideTransaction int
+}
+
+func newFakeRefreshTokenRepository() *fakeRefreshTokenRepository {
+	return &fakeRefreshTokenRepository{nextID: 1}
+}
+
+func (r *fakeRefreshTokenRepository) Create(_ context.Context, token *models.RefreshToken) error {
+	token.ID = r.nextID
+	token.CreatedAt = time.Now()
+	r.nextID++
+	r.tokens = append(r.tokens, token)
+	return nil
+}
+
+func (r *fakeRefreshTokenRepository) FindByHash(_ context.Context, tokenHash string) (*models.RefreshToken, error) {
+	for _, token := range r.tokens {
+		if token.TokenHash == tokenHash {
+			found := *token
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRefreshTokenRepository) MarkRotated(ctx context.Context, id uint, rotatedAt time.Time) error {
+	if !inFakeTransaction(ctx) {
+		r.outsideTransaction++
+	}
+	for _, token := range r.tokens {
+		if token.ID == id {
+			if token.RotatedAt != nil || token.RevokedAt != nil {
+				return repositories.ErrTokenNotActive
+			}
+			token.RotatedAt = &rotatedAt
+		}
+	}
+	return nil
+}
+
+func (r *fakeRefreshTokenRepository) RevokeFamily(_ context.Context, familyID string, revokedAt time.Time) error {
+	for _, token := range r.tokens {
+		if token.FamilyID == familyID && token.RevokedAt == nil {
+			token.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+func (r *fakeRefreshTokenRepository) RevokeAllForUser(_ context.Context, userID uint, revokedAt time.Time) error {
+	for _, token := range r.tokens {
+		if token.UserID == userID && token.RevokedAt == nil {
+			token.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+func newTestRefreshTokenService(repository *fakeRefreshTokenRepository, now *time.Time) *RefreshTokenService {
+	service := NewRefreshTokenService(repository, &fakeSessionRepository{}, fakeTransactor{}, config.AuthTokenConfig{RefreshTokenTTL: 24 * time.Hour})
+	service.now = func() time.Time { return *now }
+	return service
+}
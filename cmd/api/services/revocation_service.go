@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/karlseguin/ccache/v3"
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+const (
+	revocationCacheSize = 100000
+	// notRevokedCacheTTL acota cuánto tarda una instancia en ver una revocación hecha por otra.
+	notRevokedCacheTTL = 30 * time.Second
+)
+
+type IRevocationService interface {
+	IsRevoked(ctx context.Context, jti string) (bool, error)
+	RevokeAccessToken(ctx context.Context, claims *AccessTokenClaims) error
+	Revoke(ctx context.Context, clientID string, request dto.RevocationRequest) error
+}
+
+type RevocationService struct {
+	RevokedTokenRepository repositories.IRevokedTokenRepository
+	TokenService           ITokenService
+	RefreshTokenService    IRefreshTokenService
+	AccessTokenTTL         time.Duration
+	cache                  *ccache.Cache[bool]
+	now                    func() time.Time
+}
+
+func NewRevocationService(
+	revokedTokenRepository repositories.IRevokedTokenRepository,
+	tokenService ITokenService,
+	refreshTokenService IRefreshTokenService,
+	authConfig config.AuthTokenConfig,
+) *RevocationService {
+	return &RevocationService{
+		RevokedTokenRepository: revokedTokenRepository,
+		TokenService:           tokenService,
+		RefreshTokenService:    refreshTokenService,
+		AccessTokenTTL:         authConfig.AccessTokenTTL,
+		cache:                  ccache.New(ccache.Configure[bool]().MaxSize(revocationCacheSize)),
+		now:                    time.Now,
+	}
+}
+
+// IsRevoked consulta la lista de revocación. Los jti revocados se recuerdan hasta que el token expira y los no
+// revocados durante notRevokedCacheTTL.
+func (s *RevocationService) IsRevoked(ctx context.Context, jti string) (bool, error) {
+	if item := s.cache.Get(jti); item != nil && !item.Expired() {
+		return item.Value(), nil
+	}
+
+	revoked, err := s.RevokedTokenRepository.Exists(ctx, jti)
+	if err != nil {
+		return false, err
+	}
+
+	ttl := notRevokedCacheTTL
+	if revoked {
+		ttl = s.AccessTokenTTL
+	}
+	s.cache.Set(jti, revoked, ttl)
+	return revoked, nil
+}
+
+func (s *RevocationService) RevokeAccessToken(ctx context.Context, claims *AccessTokenClaims) error {
+	userID, _ := claims.UserID()
+	err := s.RevokedTokenRepository.Create(ctx, &models.RevokedToken{
+		JTI:       claims.ID,
+		UserID:    userID,
+		ExpiresAt: claims.ExpiresAt.Time,
+		RevokedAt: s.now(),
+	})
+	if err != nil {
+		return err
+	}
+
+	s.cache.Set(claims.ID, true, claims.ExpiresAt.Sub(s.now()))
+	return nil
+}
+
+// Revoke implementa RFC 7009 para el cliente ya autenticado: los tokens desconocidos, expirados o ya revocados no
+// producen error y los emitidos a otro cliente se rechazan con unauthorized_client. El hint solo decide qué tipo
+// de token se prueba primero.
+func (s *RevocationService) Revoke(ctx context.Context, clientID string, request dto.RevocationRequest) error {
+	if request.TokenTypeHint == dto.TokenTypeHintRefreshToken {
+		revoked, err := s.revokeRefreshToken(ctx, clientID, request.Token)
+		if revoked || err != nil {
+			return err
+		}
+		return s.revokeAccessToken(ctx, clientID, request.Token)
+	}
+
+	if claims, err := s.TokenService.ParseAccessToken(ctx, request.Token); err == nil {
+		return s.revokeClientAccessToken(ctx, clientID, claims)
+	}
+	_, err := s.revokeRefreshToken(ctx, clientID, request.Token)
+	return err
+}
+
+// PurgeExpired elimina de la lista los tokens que ya expiraron.
+func (s *RevocationService) PurgeExpired(ctx context.Context) error {
+	return s.RevokedTokenRepository.DeleteExpired(ctx, s.now())
+}
+
+// Run ejecuta PurgeExpired cada interval hasta que se cancele ctx.
+func (s *RevocationService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.PurgeExpired(ctx); err != nil {
+				log.Error(ctx, "error purging revoked tokens: ", err)
+			}
+		}
+	}
+}
+
+func (s *RevocationService) revokeAccessToken(ctx context.Context, clientID, token string) error {
+	claims, err := s.TokenService.ParseAccessToken(ctx, token)
+	if err != nil {
+		return nil
+	}
+	return s.revokeClientAccessToken(ctx, clientID, claims)
+}
+
+func (s *RevocationService) revokeClientAccessToken(ctx context.Context, clientID string, claims *AccessTokenClaims) error {
+	if claims.ClientID != clientID {
+		return utils.NewOAuthError("unauthorized_client", "the token was not issued to this client")
+	}
+	return s.RevokeAccessToken(ctx, claims)
+}
+
+func (s *RevocationService) revokeRefreshToken(ctx context.Context, clientID, token string) (bool, error) {
+	stored, err := s.RefreshTokenService.Find(ctx, token)
+	if err != nil || stored == nil {
+		return false, err
+	}
+	if stored.ClientID != clientID {
+		return true, utils.NewOAuthError("unauthorized_client", "the token was not issued to this client")
+	}
+	return true, s.RefreshTokenService.Revoke(ctx, token)
+}
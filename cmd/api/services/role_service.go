@@ -0,0 +1,229 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type IRoleService interface {
+	ListRoles(ctx context.Context) ([]models.Role, error)
+	CreateRole(ctx context.Context, request dto.CreateRoleRequest) (*models.Role, error)
+	UpdateRole(ctx context.Context, request dto.UpdateRoleRequest) (*models.Role, error)
+	DeleteRole(ctx context.Context, id uint) error
+	ListPermissions(ctx context.Context) ([]models.Permission, error)
+	CreatePermission(ctx context.Context, request dto.CreatePermissionRequest) (*models.Permission, error)
+	UserAuthorization(ctx context.Context, userID uint) (*dto.UserRolesResponse, error)
+	AssignRole(ctx context.Context, userID uint, roleName string) error
+	RemoveRole(ctx context.Context, userID uint, roleName string) error
+}
+
+type RoleService struct {
+	RoleRepository       repositories.IRoleRepository
+	PermissionRepository repositories.IPermissionRepository
+	UserRoleRepository   repositories.IUserRoleRepository
+	UserRepository       repositories.IUserRepository
+}
+
+func NewRoleService(
+	roleRepository repositories.IRoleRepository,
+	permissionRepository repositories.IPermissionRepository,
+	userRoleRepository repositories.IUserRoleRepository,
+	userRepository repositories.IUserRepository,
+) *RoleService {
+	return &RoleService{
+		RoleRepository:       roleRepository,
+		PermissionRepository: permissionRepository,
+		UserRoleRepository:   userRoleRepository,
+		UserRepository:       userRepository,
+	}
+}
+
+func (s *RoleService) ListRoles(ctx context.Context) ([]models.Role, error) {
+	roles, err := s.RoleRepository.FindAll(ctx)
+	if err != nil {
+		log.Error(ctx, "error listing roles: ", err)
+		return nil, utils.NewInternalServerError("could not list roles")
+	}
+	return roles, nil
+}
+
+func (s *RoleService) CreateRole(ctx context.Context, request dto.CreateRoleRequest) (*models.Role, error) {
+	permissions, err := s.findPermissions(ctx, request.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role := &models.Role{
+		Name:        strings.ToLower(strings.TrimSpace(request.Name)),
+		Description: request.Description,
+		Permissions: permissions,
+	}
+	if err := s.RoleRepository.Create(ctx, role); err != nil {
+		if errors.Is(err, repositories.ErrDuplicatedName) {
+			return nil, utils.NewConflictError("role already exists")
+		}
+		log.Error(ctx, "error creating role: ", err)
+		return nil, utils.NewInternalServerError("could not create role")
+	}
+	return role, nil
+}
+
+func (s *RoleService) UpdateRole(ctx context.Context, request dto.UpdateRoleRequest) (*models.Role, error) {
+	role, err := s.findRole(ctx, func() (*models.Role, error) { return s.RoleRepository.FindByID(ctx, request.ID) })
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := s.findPermissions(ctx, request.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	role.Description = request.Description
+	role.Permissions = permissions
+	if err := s.RoleRepository.Update(ctx, role); err != nil {
+		log.Error(ctx, "error updating role: ", err)
+		return nil, utils.NewInternalServerError("could not update role")
+	}
+	return role, nil
+}
+
+// DeleteRole no permite borrar los roles de los que dependen el registro y el panel de administración.
+func (s *RoleService) DeleteRole(ctx context.Context, id uint) error {
+	role, err := s.findRole(ctx, func() (*models.Role, error) { return s.RoleRepository.FindByID(ctx, id) })
+	if err != nil {
+		return err
+	}
+	if role.Name == constants.RoleAdmin || role.Name == constants.DefaultSignupRole {
+		return utils.NewConflictError("built-in roles cannot be deleted")
+	}
+
+	if err := s.RoleRepository.Delete(ctx, id); err != nil {
+		log.Error(ctx, "error deleting role: ", err)
+		return utils.NewInternalServerError("could not delete role")
+	}
+	return nil
+}
+
+func (s *RoleService) ListPermissions(ctx context.Context) ([]models.Permission, error) {
+	permissions, err := s.PermissionRepository.FindAll(ctx)
+	if err != nil {
+		log.Error(ctx, "error listing permissions: ", err)
+		return nil, utils.NewInternalServerError("could not list permissions")
+	}
+	return permissions, nil
+}
+
+func (s *RoleService) CreatePermission(ctx context.Context, request dto.CreatePermissionRequest) (*models.Permission, error) {
+	permission := &models.Permission{
+		Name:        strings.ToLower(strings.TrimSpace(request.Name)),
+		Description: request.Description,
+	}
+	if err := s.PermissionRepository.Create(ctx, permission); err != nil {
+		if errors.Is(err, repositories.ErrDuplicatedName) {
+			return nil, utils.NewConflictError("permission already exists")
+		}
+		log.Error(ctx, "error creating permission: ", err)
+		return nil, utils.NewInternalServerError("could not create permission")
+	}
+	return permission, nil
+}
+
+// UserAuthorization devuelve los roles del usuario y la unión ordenada de sus permisos.
+func (s *RoleService) UserAuthorization(ctx context.Context, userID uint) (*dto.UserRolesResponse, error) {
+	roles, err := s.UserRoleRepository.FindRolesByUserID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding user roles: ", err)
+		return nil, utils.NewInternalServerError("could not get user roles")
+	}
+
+	response := &dto.UserRolesResponse{UserID: userID, Roles: []string{}, Permissions: []string{}}
+	seen := map[string]bool{}
+	for _, role := range roles {
+		response.Roles = append(response.Roles, role.Name)
+		for _, permission := range role.Permissions {
+			if !seen[permission.Name] {
+				seen[permission.Name] = true
+				response.Permissions = append(response.Permissions, permission.Name)
+			}
+		}
+	}
+	sort.Strings(response.Permissions)
+	return response, nil
+}
+
+func (s *RoleService) AssignRole(ctx context.Context, userID uint, roleName string) error {
+	user, err := s.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return utils.NewInternalServerError("could not assign role")
+	}
+	if user == nil {
+		return utils.NewNotFoundError("user not found")
+	}
+
+	role, err := s.findRole(ctx, func() (*models.Role, error) { return s.RoleRepository.FindByName(ctx, roleName) })
+	if err != nil {
+		return err
+	}
+
+	if err := s.UserRoleRepository.Assign(ctx, userID, role.ID); err != nil {
+		log.Error(ctx, "error assigning role: ", err)
+		return utils.NewInternalServerError("could not assign role")
+	}
+	return nil
+}
+
+func (s *RoleService) RemoveRole(ctx context.Context, userID uint, roleName string) error {
+	role, err := s.findRole(ctx, func() (*models.Role, error) { return s.RoleRepository.FindByName(ctx, roleName) })
+	if err != nil {
+		return err
+	}
+
+	if err := s.UserRoleRepository.Remove(ctx, userID, role.ID); err != nil {
+		log.Error(ctx, "error removing role: ", err)
+		return utils.NewInternalServerError("could not remove role")
+	}
+	return nil
+}
+
+func (s *RoleService) findRole(ctx context.Context, find func() (*models.Role, error)) (*models.Role, error) {
+	role, err := find()
+	if err != nil {
+		log.Error(ctx, "error finding role: ", err)
+		return nil, utils.NewInternalServerError("could not find role")
+	}
+	if role == nil {
+		return nil, utils.NewNotFoundError("role not found")
+	}
+	return role, nil
+}
+
+// findPermissions exige que todos los permisos pedidos existan.
+func (s *RoleService) findPermissions(ctx context.Context, names []string) ([]models.Permission, error) {
+	permissions, err := s.PermissionRepository.FindByNames(ctx, names)
+	if err != nil {
+		log.Error(ctx, "error finding permissions: ", err)
+		return nil, utils.NewInternalServerError("could not find permissions")
+	}
+
+	found := map[string]bool{}
+	for _, permission := range permissions {
+		found[permission.Name] = true
+	}
+	for _, name := range names {
+		if !found[name] {
+			return nil, utils.NewBadRequestError("unknown permission: " + name)
+		}
+	}
+	return permissions, nil
+}
@@ -0,0 +1,306 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+func TestRoleService(t *testing.T) {
+
+	t.Run("assign roles and merge permissions", func(t *testing.T) {
+		users := newFakeUserRepository()
+		_ = users.Create(context.Background(), &models.User{Email: "ana@example.com"})
+		service := newTestRoleService(users)
+
+		for _, role := range []string{constants.RoleClient, constants.RoleAdmin, constants.RoleClient} {
+			if err := service.AssignRole(context.Background(), 1, role); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		}
+
+		authorization, err := service.UserAuthorization(context.Background(), 1)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expectedRoles := []string{constants.RoleClient, constants.RoleAdmin}
+		expectedPermissions := []string{constants.PermissionRolesManage, constants.PermissionTasksRead, constants.PermissionTasksWrite}
+		if !reflect.DeepEqual(authorization.Roles, expectedRoles) {
+			t.Errorf("unexpected roles: got %v, want %v", authorization.Roles, expectedRoles)
+		}
+		if !reflect.DeepEqual(authorization.Permissions, expectedPermissions) {
+			t.Errorf("unexpected permissions: got %v, want %v", authorization.Permissions, expectedPermissions)
+		}
+
+		if err := service.RemoveRole(context.Background(), 1, constants.RoleAdmin); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		authorization, _ = service.UserAuthorization(context.Background(), 1)
+		if !reflect.DeepEqual(authorization.Roles, []string{constants.RoleClient}) {
+			t.Errorf("unexpected roles after removal: %v", authorization.Roles)
+		}
+	})
+
+	testCases := []struct {
+		name           string
+		run            func(service *RoleService) error
+		expectedStatus int
+	}{
+		{
+			name: "assign role to unknown user",
+			run: func(service *RoleService) error {
+				return service.AssignRole(context.Background(), 99, constants.RoleClient)
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "assign unknown role",
+			run: func(service *RoleService) error {
+				return service.AssignRole(context.Background(), 1, "superuser")
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "create duplicated role",
+			run: func(service *RoleService) error {
+				_, err := service.CreateRole(context.Background(), dto.CreateRoleRequest{Name: " Admin "})
+				return err
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "create role with unknown permission",
+			run: func(service *RoleService) error {
+				_, err := service.CreateRole(context.Background(), dto.CreateRoleRequest{
+					Name:        "support",
+					Permissions: []string{constants.PermissionTasksRead, "billing:refund"},
+				})
+				return err
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "delete built-in role",
+			run: func(service *RoleService) error {
+				return service.DeleteRole(context.Background(), 1)
+			},
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name: "update unknown role",
+			run: func(service *RoleService) error {
+				_, err := service.UpdateRole(context.Background(), dto.UpdateRoleRequest{ID: 42})
+				return err
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			users := newFakeUserRepository()
+			_ = users.Create(context.Background(), &models.User{Email: "ana@example.com"})
+			service := newTestRoleService(users)
+
+			err := tc.run(service)
+
+			var apiErr *utils.APIError
+			if !errors.As(err, &apiErr) || apiErr.Status != tc.expectedStatus {
+				t.Errorf("unexpected error: got %v, want status %d", err, tc.expectedStatus)
+			}
+		})
+	}
+
+	t.Run("create, update and delete custom role", func(t *testing.T) {
+		service := newTestRoleService(newFakeUserRepository())
+
+		role, err := service.CreateRole(context.Background(), dto.CreateRoleRequest{
+			Name:        " Support ",
+			Permissions: []string{constants.PermissionTasksRead},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if role.Name != "support" {
+			t.Errorf("expected normalized name, got %q", role.Name)
+		}
+
+		role, err = service.UpdateRole(context.Background(), dto.UpdateRoleRequest{
+			ID:          role.ID,
+			Description: "Customer support",
+			Permissions: []string{constants.PermissionTasksRead, constants.PermissionTasksWrite},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(role.Permissions) != 2 || role.Description != "Customer support" {
+			t.Errorf("unexpected role: %+v", role)
+		}
+
+		if err := service.DeleteRole(context.Background(), role.ID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		roles, _ := service.ListRoles(context.Background())
+		if len(roles) != 2 {
+			t.Errorf("expected only built-in roles, got %d", len(roles))
+		}
+	})
+}
+
+type fakeRoleRepository struct {
+	roles  map[uint]*models.Role
+	nextID uint
+}
+
+func newFakeRoleRepository() *fakeRoleRepository {
+	return &fakeRoleRepository{roles: map[uint]*models.Role{}, nextID: 1}
+}
+
+func (r *fakeRoleRepository) FindAll(_ context.Context) ([]models.Role, error) {
+	var roles []models.Role
+	for id := uint(1); id < r.nextID; id++ {
+		if role, ok := r.roles[id]; ok {
+			roles = append(roles, *role)
+		}
+	}
+	return roles, nil
+}
+
+func (r *fakeRoleRepository) FindByID(_ context.Context, id uint) (*models.Role, error) {
+	role, ok := r.roles[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *role
+	return &found, nil
+}
+
+func (r *fakeRoleRepository) FindByName(_ context.Context, name string) (*models.Role, error) {
+	for _, role := range r.roles {
+		if role.Name == name {
+			found := *role
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeRoleRepository) Create(_ context.Context, role *models.Role) error {
+	for _, existing := range r.roles {
+		if existing.Name == role.Name {
+			return repositories.ErrDuplicatedName
+		}
+	}
+	role.ID = r.nextID
+	r.nextID++
+	stored := *role
+	r.roles[role.ID] = &stored
+	return nil
+}
+
+func (r *fakeRoleRepository) Update(_ context.Context, role *models.Role) error {
+	stored := *role
+	r.roles[role.ID] = &stored
+	return nil
+}
+
+func (r *fakeRoleRepository) Delete(_ context.Context, id uint) error {
+	delete(r.roles, id)
+	return nil
+}
+
+type fakePermissionRepository struct {
+	permissions []models.Permission
+}
+
+func (r *fakePermissionRepository) FindAll(_ context.Context) ([]models.Permission, error) {
+	return r.permissions, nil
+}
+
+func (r *fakePermissionRepository) FindByNames(_ context.Context, names []string) ([]models.Permission, error) {
+	var permissions []models.Permission
+	for _, permission := range r.permissions {
+		for _, name := range names {
+			if permission.Name == name {
+				permissions = append(permissions, permission)
+			}
+		}
+	}
+	return permissions, nil
+}
+
+func (r *fakePermissionRepository) Create(_ context.Context, permission *models.Permission) error {
+	for _, existing := range r.permissions {
+		if existing.Name == permission.Name {
+			return repositories.ErrDuplicatedName
+		}
+	}
+	permission.ID = uint(len(r.permissions) + 1)
+	r.permissions = append(r.permissions, *permission)
+	return nil
+}
+
+type fakeUserRoleRepository struct {
+	roles       *fakeRoleRepository
+	assignments map[uint][]uint
+}
+
+func (r *fakeUserRoleRepository) Assign(_ context.Context, userID, roleID uint) error {
+	for _, id := range r.assignments[userID] {
+		if id == roleID {
+			return nil
+		}
+	}
+	r.assignments[userID] = append(r.assignments[userID], roleID)
+	return nil
+}
+
+func (r *fakeUserRoleRepository) Remove(_ context.Context, userID, roleID uint) error {
+	var roleIDs []uint
+	for _, id := range r.assignments[userID] {
+		if id != roleID {
+			roleIDs = append(roleIDs, id)
+		}
+	}
+	r.assignments[userID] = roleIDs
+	return nil
+}
+
+func (r *fakeUserRoleRepository) FindRolesByUserID(_ context.Context, userID uint) ([]models.Role, error) {
+	var roles []models.Role
+	for _, id := range r.assignments[userID] {
+		if role, ok := r.roles.roles[id]; ok {
+			roles = append(roles, *role)
+		}
+	}
+	return roles, nil
+}
+
+// newTestRoleService siembra los roles admin y client con un subconjunto de los permisos reales.
+func newTestRoleService(userRepository *fakeUserRepository) *RoleService {
+	permissions := &fakePermissionRepository{}
+	for _, name := range []string{constants.PermissionRolesManage, constants.PermissionTasksRead, constants.PermissionTasksWrite} {
+		_ = permissions.Create(context.Background(), &models.Permission{Name: name})
+	}
+
+	roles := newFakeRoleRepository()
+	seed := map[string][]string{
+		constants.RoleAdmin:  {constants.PermissionRolesManage, constants.PermissionTasksRead},
+		constants.RoleClient: {constants.PermissionTasksRead, constants.PermissionTasksWrite},
+	}
+	for _, name := range []string{constants.RoleAdmin, constants.RoleClient} {
+		granted, _ := permissions.FindByNames(context.Background(), seed[name])
+		_ = roles.Create(context.Background(), &models.Role{Name: name, Permissions: granted})
+	}
+
+	userRoles := &fakeUserRoleRepository{roles: roles, assignments: map[uint][]uint{}}
+	return NewRoleService(roles, permissions, userRoles, userRepository)
+}
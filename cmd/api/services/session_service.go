@@ -0,0 +1,73 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type ISessionService interface {
+	ListSessions(ctx context.Context, userID uint) ([]dto.SessionResponse, error)
+	RevokeSession(ctx context.Context, userID, sessionID uint) error
+	RevokeAllSessions(ctx context.Context, userID uint) error
+}
+
+// SessionService expone las sesiones al usuario. Revocar una sesión impide rotar sus refresh tokens e invalida los
+// access tokens que llevan su sid.
+type SessionService struct {
+	SessionRepository   repositories.ISessionRepository
+	RefreshTokenService IRefreshTokenService
+	now                 func() time.Time
+}
+
+func NewSessionService(sessionRepository repositories.ISessionRepository, refreshTokenService IRefreshTokenService) *SessionService {
+	return &SessionService{
+		SessionRepository:   sessionRepository,
+		RefreshTokenService: refreshTokenService,
+		now:                 time.Now,
+	}
+}
+
+func (s *SessionService) ListSessions(ctx context.Context, userID uint) ([]dto.SessionResponse, error) {
+	sessions, err := s.SessionRepository.FindActiveByUserID(ctx, userID, s.now())
+	if err != nil {
+		log.Error(ctx, "error listing sessions: ", err)
+		return nil, utils.NewInternalServerError("could not list sessions")
+	}
+
+	response := make([]dto.SessionResponse, 0, len(sessions))
+	for i := range sessions {
+		response = append(response, dto.NewSessionResponse(&sessions[i]))
+	}
+	return response, nil
+}
+
+// RevokeSession responde 404 también para sesiones de otro usuario, para no revelar que existen.
+func (s *SessionService) RevokeSession(ctx context.Context, userID, sessionID uint) error {
+	session, err := s.SessionRepository.FindByID(ctx, sessionID)
+	if err != nil {
+		log.Error(ctx, "error finding session: ", err)
+		return utils.NewInternalServerError("could not revoke session")
+	}
+	if session == nil || session.UserID != userID || !session.IsActive(s.now()) {
+		return utils.NewNotFoundError("session not found")
+	}
+
+	if err := s.RefreshTokenService.RevokeFamily(ctx, session.FamilyID); err != nil {
+		log.Error(ctx, "error revoking session: ", err)
+		return utils.NewInternalServerError("could not revoke session")
+	}
+	return nil
+}
+
+func (s *SessionService) RevokeAllSessions(ctx context.Context, userID uint) error {
+	if err := s.RefreshTokenService.RevokeAllForUser(ctx, userID); err != nil {
+		log.Error(ctx, "error revoking sessions: ", err)
+		return utils.NewInternalServerError("could not revoke sessions")
+	}
+	return nil
+}
@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+const testUserAgent = "Mozilla/5.0 (Macintosh; Intel Mac OS X 14_4) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Safari/605.1.15"
+
+func newTestSessionService(now *time.Time) *SessionService {
+	refreshTokenService := newTestRefreshTokenService(newFakeRefreshTokenRepository(), now)
+	service := NewSessionService(refreshTokenService.SessionRepository, refreshTokenService)
+	service.now = func() time.Time { return *now }
+	return service
+}
+
+func TestSessionService(t *testing.T) {
+
+	t.Run("records the device and activity", func(t *testing.T) {
+		now := time.Now()
+		service := newTestSessionService(&now)
+		refreshTokenService := service.RefreshTokenService.(*RefreshTokenService)
+
+		laptop := utils.WithClientInfo(context.Background(), utils.ClientInfo{UserAgent: testUserAgent, IPAddress: "190.0.2.10"})
+		first, _ := refreshTokenService.Issue(laptop, 7, "")
+		_, _ = refreshTokenService.Issue(context.Background(), 7, "")
+		_, _ = refreshTokenService.Issue(laptop, 8, "")
+
+		now = now.Add(time.Hour)
+		phone := utils.WithClientInfo(context.Background(), utils.ClientInfo{UserAgent: "okhttp/4.12.0", IPAddress: "190.0.2.20"})
+		if _, _, err := refreshTokenService.Rotate(phone, first, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		sessions, err := service.ListSessions(context.Background(), 7)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(sessions) != 2 {
+			t.Fatalf("expected the two sessions of the user, got %+v", sessions)
+		}
+		if sessions[0].DeviceName != "Android app" || sessions[0].IPAddress != "190.0.2.20" || !sessions[0].LastSeenAt.Equal(now) {
+			t.Errorf("expected the rotated session first with its last activity, got %+v", sessions[0])
+		}
+		if sessions[1].DeviceName != "Unknown device" || sessions[1].IPAddress != "" {
+			t.Errorf("unexpected session without client info: %+v", sessions[1])
+		}
+	})
+
+	t.Run("revoked sessions cannot refresh", func(t *testing.T) {
+		now := time.Now()
+		service := newTestSessionService(&now)
+		refreshTokenService := service.RefreshTokenService.(*RefreshTokenService)
+
+		revoked, _ := refreshTokenService.Issue(context.Background(), 7, "")
+		kept, _ := refreshTokenService.Issue(context.Background(), 7, "")
+		sessions, _ := service.ListSessions(context.Background(), 7)
+
+		assertAPIStatus(t, service.RevokeSession(context.Background(), 8, sessions[0].ID), http.StatusNotFound)
+		if err := service.RevokeSession(context.Background(), 7, sessions[0].ID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		assertAPIStatus(t, service.RevokeSession(context.Background(), 7, sessions[0].ID), http.StatusNotFound)
+
+		_, _, err := refreshTokenService.Rotate(context.Background(), revoked, "")
+		assertAPIStatus(t, err, http.StatusUnauthorized)
+		if _, _, err := refreshTokenService.Rotate(context.Background(), kept, ""); err != nil {
+			t.Errorf("unexpected error for the other session: %v", err)
+		}
+
+		if err := service.RevokeAllSessions(context.Background(), 7); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sessions, _ := service.ListSessions(context.Background(), 7); len(sessions) != 0 {
+			t.Errorf("expected no active sessions, got %+v", sessions)
+		}
+	})
+
+	t.Run("a session revoked without its tokens still blocks them", func(t *testing.T) {
+		now := time.Now()
+		service := newTestSessionService(&now)
+		refreshTokenService := service.RefreshTokenService.(*RefreshTokenService)
+
+		token, _ := refreshTokenService.Issue(context.Background(), 7, "")
+		stored, _ := refreshTokenService.Find(context.Background(), token)
+		_ = service.SessionRepository.RevokeByFamilyID(context.Background(), stored.FamilyID, now)
+
+		_, _, err := refreshTokenService.Rotate(context.Background(), token, "")
+		assertAPIStatus(t, err, http.StatusUnauthorized)
+	})
+
+	t.Run("families issued before sessions get one on rotation", func(t *testing.T) {
+		now := time.Now()
+		service := newTestSessionService(&now)
+		refreshTokenService := service.RefreshTokenService.(*RefreshTokenService)
+
+		token, _ := refreshTokenService.Issue(context.Background(), 7, "")
+		service.SessionRepository.(*fakeSessionRepository).sessions = nil
+
+		if _, _, err := refreshTokenService.Rotate(context.Background(), token, ""); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sessions, _ := service.ListSessions(context.Background(), 7); len(sessions) != 1 {
+			t.Errorf("expected the legacy family to get a session, got %+v", sessions)
+		}
+	})
+}
+
+type fakeSessionRepository struct {
+	sessions []*models.Session
+	nextID   uint
+}
+
+func (r *fakeSessionRepository) Create(_ context.Context, session *models.Session) error {
+	r.nextID++
+	session.ID = r.nextID
+	stored := *session
+	r.sessions = append(r.sessions, &stored)
+	return nil
+}
+
+func (r *fakeSessionRepository) FindByID(_ context.Context, id uint) (*models.Session, error) {
+	for _, session := range r.sessions {
+		if session.ID == id {
+			found := *session
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeSessionRepository) FindByFamilyID(_ context.Context, familyID string) (*models.Session, error) {
+	for _, session := range r.sessions {
+		if session.FamilyID == familyID {
+			found := *session
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeSessionRepository) FindActiveByUserID(_ context.Context, userID uint, now time.Time) ([]models.Session, error) {
+	var sessions []models.Session
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.IsActive(now) {
+			sessions = append(sessions, *session)
+		}
+	}
+	sort.SliceStable(sessions, func(i, j int) bool { return sessions[i].LastSeenAt.After(sessions[j].LastSeenAt) })
+	return sessions, nil
+}
+
+func (r *fakeSessionRepository) UpdateActivity(_ context.Context, session *models.Session) error {
+	for i, stored := range r.sessions {
+		if stored.ID == session.ID {
+			updated := *session
+			updated.RevokedAt = stored.RevokedAt
+			r.sessions[i] = &updated
+		}
+	}
+	return nil
+}
+
+func (r *fakeSessionRepository) RevokeByFamilyID(_ context.Context, familyID string, revokedAt time.Time) error {
+	for _, session := range r.sessions {
+		if session.FamilyID == familyID && session.RevokedAt == nil {
+			session.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
+
+func (r *fakeSessionRepository) RevokeAllForUser(_ context.Context, userID uint, revokedAt time.Time) error {
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.RevokedAt == nil {
+			session.RevokedAt = &revokedAt
+		}
+	}
+	return nil
+}
@@ -0,0 +1,245 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+const TokenTypeBearer = "Bearer"
+
+// accessTokenType es el typ de los access tokens (RFC 9068 §2.1); distingue un access token de un ID token
+// firmado con las mismas claves.
+const accessTokenType = "at+jwt"
+
+var ErrInvalidToken = errors.New("invalid token")
+
+type AccessTokenClaims struct {
+	Email string `json:"email,omitempty"`
+	// Scope es la lista de scopes separada por espacios, como en RFC 9068.
+	Scope       string   `json:"scope,omitempty"`
+	Roles       []string `json:"roles,omitempty"`
+	Permissions []string `json:"permissions,omitempty"`
+	// ClientID es el cliente OAuth al que se emitió el token (RFC 9068); vacío en el login propio.
+	ClientID string `json:"client_id,omitempty"`
+	// FirstParty marca los tokens de las apps propias emitidos por OAuth; los del login propio no lo necesitan.
+	FirstParty bool `json:"first_party,omitempty"`
+	// SessionID es la familia de refresh tokens con la que se emitió el token; revocar esa sesión invalida el token.
+	SessionID string `json:"sid,omitempty"`
+	jwt.RegisteredClaims
+}
+
+func (c *AccessTokenClaims) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// IsClientToken indica si el token se emitió con client_credentials: el sujeto es el propio cliente.
+func (c *AccessTokenClaims) IsClientToken() bool {
+	return c.ClientID != "" && c.Subject == c.ClientID
+}
+
+// IsFirstParty indica si el token lo obtuvo el login propio o una app propia. Solo esos tokens pueden gestionar
+// la cuenta: un cliente de terceros actúa en nombre del usuario únicamente dentro de los scopes concedidos.
+func (c *AccessTokenClaims) IsFirstParty() bool {
+	return !c.IsClientToken() && (c.ClientID == "" || c.FirstParty)
+}
+
+// UserID devuelve el sub de los claims como ID de usuario.
+func (c *AccessTokenClaims) UserID() (uint, error) {
+	id, err := strconv.ParseUint(c.Subject, 10, 64)
+	if err != nil {
+		return 0, ErrInvalidToken
+	}
+	return uint(id), nil
+}
+
+// IDTokenClaims son los claims del ID token de OpenID Connect; aud y azp son el client_id del cliente.
+type IDTokenClaims struct {
+	Nonce           string `json:"nonce,omitempty"`
+	AccessTokenHash string `json:"at_hash,omitempty"`
+	AuthorizedParty string `json:"azp,omitempty"`
+	dto.StandardClaims
+	jwt.RegisteredClaims
+}
+
+type ITokenService interface {
+	IssueAccessToken(ctx context.Context, user *models.User, sessionID string) (string, *AccessTokenClaims, error)
+	IssueOAuthAccessToken(ctx context.Context, user *models.User, client *models.OAuthClient, scope, sessionID string) (string, *AccessTokenClaims, error)
+	IssueIDToken(ctx context.Context, user *models.User, clientID, nonce, accessToken string, scopes []string) (string, error)
+	ParseAccessToken(ctx context.Context, token string) (*AccessTokenClaims, error)
+}
+
+// TokenService firma los access tokens con Issuer y los ID tokens con IDTokenIssuer, que OpenID Connect exige
+// que sea la URL publicada en el discovery.
+type TokenService struct {
+	KeyService     IKeyService
+	RoleService    IRoleService
+	Issuer         string
+	IDTokenIssuer  string
+	AccessTokenTTL time.Duration
+	now            func() time.Time
+}
+
+func NewTokenService(keyService IKeyService, roleService IRoleService, authConfig config.AuthTokenConfig) *TokenService {
+	return &TokenService{
+		KeyService:     keyService,
+		RoleService:    roleService,
+		Issuer:         authConfig.Issuer,
+		IDTokenIssuer:  authConfig.PublicURL,
+		AccessTokenTTL: authConfig.AccessTokenTTL,
+		now:            time.Now,
+	}
+}
+
+// IssueAccessToken incluye en el token los roles y permisos vigentes del usuario; los cambios de rol se
+// reflejan en el siguiente token emitido. sessionID es la sesión que emitió el token.
+func (s *TokenService) IssueAccessToken(ctx context.Context, user *models.User, sessionID string) (string, *AccessTokenClaims, error) {
+	claims, err := s.userClaims(ctx, user)
+	if err != nil {
+		return "", nil, err
+	}
+	claims.SessionID = sessionID
+	return s.issue(ctx, claims)
+}
+
+// IssueOAuthAccessToken emite un token para un cliente OAuth con los scopes concedidos. Sin usuario
+// (client_credentials) el sujeto es el cliente. Solo los clientes propios reciben roles y permisos, para que una
+// app de terceros no pueda usar los endpoints de administración en nombre del usuario.
+func (s *TokenService) IssueOAuthAccessToken(ctx context.Context, user *models.User, client *models.OAuthClient, scope, sessionID string) (string, *AccessTokenClaims, error) {
+	claims := &AccessTokenClaims{RegisteredClaims: jwt.RegisteredClaims{Subject: client.ClientID}}
+	if user != nil && client.FirstParty {
+		var err error
+		if claims, err = s.userClaims(ctx, user); err != nil {
+			return "", nil, err
+		}
+	} else if user != nil {
+		claims.Email = user.Email
+		claims.Subject = strconv.FormatUint(uint64(user.ID), 10)
+	}
+
+	claims.ClientID = client.ClientID
+	claims.FirstParty = user != nil && client.FirstParty
+	claims.Scope = scope
+	claims.SessionID = sessionID
+	return s.issue(ctx, claims)
+}
+
+// IssueIDToken emite el ID token de OpenID Connect para el cliente, con los claims estándar que liberan los
+// scopes concedidos y el at_hash del access token que lo acompaña.
+func (s *TokenService) IssueIDToken(ctx context.Context, user *models.User, clientID, nonce, accessToken string, scopes []string) (string, error) {
+	now := s.now()
+	claims := &IDTokenClaims{
+		Nonce:           nonce,
+		AccessTokenHash: accessTokenHash(accessToken),
+		AuthorizedParty: clientID,
+		StandardClaims:  dto.NewStandardClaims(user, scopes),
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    s.IDTokenIssuer,
+			Subject:   strconv.FormatUint(uint64(user.ID), 10),
+			Audience:  jwt.ClaimStrings{clientID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(now.Add(s.AccessTokenTTL)),
+		},
+	}
+	return s.sign(ctx, claims, "JWT")
+}
+
+func (s *TokenService) userClaims(ctx context.Context, user *models.User) (*AccessTokenClaims, error) {
+	authorization, err := s.RoleService.UserAuthorization(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &AccessTokenClaims{
+		Email:       user.Email,
+		Roles:       authorization.Roles,
+		Permissions: authorization.Permissions,
+		RegisteredClaims: jwt.RegisteredClaims{
+			Subject: strconv.FormatUint(uint64(user.ID), 10),
+		},
+	}, nil
+}
+
+// issue completa los claims registrados (jti, emisor y vigencia) y firma el token.
+func (s *TokenService) issue(ctx context.Context, claims *AccessTokenClaims) (string, *AccessTokenClaims, error) {
+	jti, err := utils.GenerateToken(16)
+	if err != nil {
+		return "", nil, err
+	}
+
+	now := s.now()
+	claims.ID = jti
+	claims.Issuer = s.Issuer
+	claims.IssuedAt = jwt.NewNumericDate(now)
+	claims.ExpiresAt = jwt.NewNumericDate(now.Add(s.AccessTokenTTL))
+
+	token, err := s.sign(ctx, claims, accessTokenType)
+	if err != nil {
+		return "", nil, err
+	}
+	return token, claims, nil
+}
+
+// ParseAccessToken verifica tipo, firma, emisor y vigencia; cualquier fallo se reporta como ErrInvalidToken.
+func (s *TokenService) ParseAccessToken(ctx context.Context, token string) (*AccessTokenClaims, error) {
+	claims := &AccessTokenClaims{}
+	if err := s.parse(ctx, token, accessTokenType, claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// sign firma los claims con la clave activa e incluye su kid y el typ en la cabecera.
+func (s *TokenService) sign(ctx context.Context, claims jwt.Claims, typ string) (string, error) {
+	key, err := s.KeyService.SigningKey(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Algorithm), claims)
+	token.Header["kid"] = key.KID
+	token.Header["typ"] = typ
+	return token.SignedString(key.Signer)
+}
+
+// accessTokenHash calcula at_hash (OpenID Connect Core 3.1.3.6): la mitad izquierda del SHA-256 del access
+// token, que es el hash de RS256 y ES256.
+func accessTokenHash(accessToken string) string {
+	sum := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(sum[:len(sum)/2])
+}
+
+// parse exige el typ esperado antes de buscar la clave, para que un ID token no pase por access token aunque
+// ambos compartan emisor.
+func (s *TokenService) parse(ctx context.Context, token, typ string, claims jwt.Claims) error {
+	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		if headerType, _ := token.Header["typ"].(string); !strings.EqualFold(headerType, typ) {
+			return nil, ErrInvalidToken
+		}
+		kid, _ := token.Header["kid"].(string)
+		key, err := s.KeyService.VerificationKey(ctx, kid)
+		if err != nil {
+			return nil, err
+		}
+		if token.Method.Alg() != key.Algorithm {
+			return nil, ErrInvalidToken
+		}
+		return key.Signer.Public(), nil
+	},
+		jwt.WithValidMethods([]string{utils.AlgorithmRS256, utils.AlgorithmES256}),
+		jwt.WithIssuer(s.Issuer),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(s.now),
+	)
+	return err
+}
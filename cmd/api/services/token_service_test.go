@@ -0,0 +1,208 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+)
+
+func newTestTokenService(now *time.Time) *TokenService {
+	keyService := newTestKeyService(newFakeSigningKeyRepository(), now)
+	_ = keyService.RotateIfDue(context.Background())
+
+	return &TokenService{
+		KeyService:     keyService,
+		RoleService:    newTestRoleService(newFakeUserRepository()),
+		Issuer:         "tareaya",
+		IDTokenIssuer:  "https://auth.tareaya.test",
+		AccessTokenTTL: time.Hour,
+		now:            func() time.Time { return *now },
+	}
+}
+
+func TestTokenService(t *testing.T) {
+
+	t.Run("issue and parse access token", func(t *testing.T) {
+		now := time.Now()
+		service := newTestTokenService(&now)
+
+		token, issued, err := service.IssueAccessToken(context.Background(), &models.User{ID: 7, Email: "ana@example.com"}, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		claims, err := service.ParseAccessToken(context.Background(), token)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		userID, _ := claims.UserID()
+		if userID != 7 || claims.Issuer != "tareaya" || claims.ID == "" || claims.ID != issued.ID {
+			t.Errorf("unexpected claims: %+v", claims)
+		}
+	})
+
+	t.Run("embeds roles and permissions", func(t *testing.T) {
+		now := time.Now()
+		users := newFakeUserRepository()
+		_ = users.Create(context.Background(), &models.User{Email: "ana@example.com"})
+		service := newTestTokenService(&now)
+		service.RoleService = newTestRoleService(users)
+		_ = service.RoleService.AssignRole(context.Background(), 1, constants.RoleAdmin)
+
+		token, _, err := service.IssueAccessToken(context.Background(), &models.User{ID: 1, Email: "ana@example.com"}, "")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		claims, err := service.ParseAccessToken(context.Background(), token)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(claims.Roles) != 1 || claims.Roles[0] != constants.RoleAdmin {
+			t.Errorf("unexpected roles: %v", claims.Roles)
+		}
+		if len(claims.Permissions) != 2 || claims.Permissions[0] != constants.PermissionRolesManage {
+			t.Errorf("unexpected permissions: %v", claims.Permissions)
+		}
+	})
+
+	t.Run("rejects invalid tokens", func(t *testing.T) {
+		now := time.Now()
+		service := newTestTokenService(&now)
+		token, _, _ := service.IssueAccessToken(context.Background(), &models.User{ID: 7}, "")
+		sameIssuer := *service
+		sameIssuer.IDTokenIssuer = sameIssuer.Issuer
+		idToken, _ := sameIssuer.IssueIDToken(context.Background(), &models.User{ID: 7}, "partner", "", "", []string{"openid"})
+
+		testCases := []struct {
+			name    string
+			token   string
+			service func() *TokenService
+		}{
+			{
+				name:  "expired",
+				token: token,
+				service: func() *TokenService {
+					later := *service
+					later.now = func() time.Time { return now.Add(2 * time.Hour) }
+					return &later
+				},
+			},
+			{
+				name:  "unknown key",
+				token: token,
+				service: func() *TokenService {
+					return newTestTokenService(&now)
+				},
+			},
+			{
+				name:  "wrong issuer",
+				token: token,
+				service: func() *TokenService {
+					other := *service
+					other.Issuer = "other"
+					return &other
+				},
+			},
+			{
+				name:    "id token with the same issuer",
+				token:   idToken,
+				service: func() *TokenService { return &sameIssuer },
+			},
+			{
+				name:    "malformed",
+				token:   "not-a-token",
+				service: func() *TokenService { return service },
+			},
+		}
+
+		for _, tc := range testCases {
+			if _, err := tc.service().ParseAccessToken(context.Background(), tc.token); err != ErrInvalidToken {
+				t.Errorf("%s: unexpected error: got %v, want %v", tc.name, err, ErrInvalidToken)
+			}
+		}
+	})
+}
+
+func TestTokenServiceKeyRotation(t *testing.T) {
+	now := time.Now()
+	service := newTestTokenService(&now)
+	token, _, _ := service.IssueAccessToken(context.Background(), &models.User{ID: 7}, "")
+
+	now = now.Add(30 * time.Minute)
+	if err := service.KeyService.Rotate(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	now = now.Add(15 * time.Minute)
+
+	if _, err := service.ParseAccessToken(context.Background(), token); err != nil {
+		t.Errorf("expected token signed by the rotated key to stay valid: %v", err)
+	}
+}
+
+// parseTestIDToken verifica la firma, el issuer y la audiencia del ID token como lo haría un relying party.
+func parseTestIDToken(t *testing.T, service *TokenService, token, clientID string) *IDTokenClaims {
+	t.Helper()
+
+	claims := &IDTokenClaims{}
+	_, err := jwt.ParseWithClaims(token, claims, func(token *jwt.Token) (interface{}, error) {
+		kid, _ := token.Header["kid"].(string)
+		key, err := service.KeyService.VerificationKey(context.Background(), kid)
+		if err != nil {
+			return nil, err
+		}
+		return key.Signer.Public(), nil
+	},
+		jwt.WithIssuer(service.IDTokenIssuer),
+		jwt.WithAudience(clientID),
+		jwt.WithTimeFunc(service.now),
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return claims
+}
+
+func TestTokenServiceIDToken(t *testing.T) {
+	now := time.Now()
+	service := newTestTokenService(&now)
+	user := &models.User{ID: 7, Name: "Ana", Email: "ana@example.com"}
+
+	testCases := []struct {
+		name          string
+		scopes        []string
+		expectedName  string
+		expectedEmail string
+	}{
+		{name: "openid only", scopes: []string{"openid"}},
+		{name: "profile", scopes: []string{"openid", "profile"}, expectedName: "Ana"},
+		{name: "email", scopes: []string{"openid", "email"}, expectedEmail: "ana@example.com"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			token, err := service.IssueIDToken(context.Background(), user, "partner", "n-0S6_WzA2Mj", "access-token", tc.scopes)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			claims := parseTestIDToken(t, service, token, "partner")
+			if claims.Subject != "7" || claims.Nonce != "n-0S6_WzA2Mj" || claims.AuthorizedParty != "partner" {
+				t.Errorf("unexpected claims: %+v", claims)
+			}
+			if claims.AccessTokenHash != accessTokenHash("access-token") || len(claims.AccessTokenHash) != 22 {
+				t.Errorf("unexpected at_hash: %q", claims.AccessTokenHash)
+			}
+			if claims.Name != tc.expectedName || claims.Email != tc.expectedEmail {
+				t.Errorf("unexpected standard claims: %+v", claims.StandardClaims)
+			}
+			if (tc.expectedEmail != "") != (claims.EmailVerified != nil) {
+				t.Errorf("expected email_verified only with the email scope")
+			}
+		})
+	}
+}
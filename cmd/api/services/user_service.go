@@ -0,0 +1,123 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type IUserService interface {
+	Signup(ctx context.Context, request dto.SignupRequest) (*models.User, error)
+	GetByID(ctx context.Context, id uint) (*models.User, error)
+	Update(ctx context.Context, id uint, request dto.UpdateUserRequest) (*models.User, error)
+}
+
+type UserService struct {
+	UserRepository        repositories.IUserRepository
+	Transactor            repositories.ITransactor
+	VerificationService   IVerificationService
+	RoleService           IRoleService
+	PasswordPolicyService IPasswordPolicyService
+	PasswordHasher        IPasswordHasher
+}
+
+func NewUserService(
+	userRepository repositories.IUserRepository,
+	transactor repositories.ITransactor,
+	verificationService IVerificationService,
+	roleService IRoleService,
+	passwordPolicyService IPasswordPolicyService,
+	passwordHasher IPasswordHasher,
+) *UserService {
+	return &UserService{
+		UserRepository:        userRepository,
+		Transactor:            transactor,
+		VerificationService:   verificationService,
+		RoleService:           roleService,
+		PasswordPolicyService: passwordPolicyService,
+		PasswordHasher:        passwordHasher,
+	}
+}
+
+func (s *UserService) Signup(ctx context.Context, request dto.SignupRequest) (*models.User, error) {
+	email := NormalizeEmail(request.Email)
+
+	existing, err := s.UserRepository.FindByEmail(ctx, email)
+	if err != nil {
+		log.Error(ctx, "error finding user by email: ", err)
+		return nil, utils.NewInternalServerError("could not create user")
+	}
+	if existing != nil {
+		return nil, utils.NewConflictError("email already registered")
+	}
+
+	user := &models.User{Name: strings.TrimSpace(request.Name), Email: email, Locale: request.Locale}
+	if err := s.PasswordPolicyService.Validate(ctx, user, request.Password); err != nil {
+		return nil, err
+	}
+
+	passwordHash, err := s.PasswordHasher.Hash(request.Password)
+	if err != nil {
+		log.Error(ctx, "error hashing password: ", err)
+		return nil, utils.NewInternalServerError("could not create user")
+	}
+
+	user.PasswordHash = passwordHash
+	// El rol por defecto y el email de verificación van en la misma transacción que el usuario: no hay alta sin
+	// rol ni email, ni email de un alta que se deshizo.
+	err = s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := s.UserRepository.Create(ctx, user); err != nil {
+			return err
+		}
+		if err := s.RoleService.AssignRole(ctx, user.ID, constants.DefaultSignupRole); err != nil {
+			return err
+		}
+		return s.VerificationService.SendEmailVerification(ctx, user)
+	})
+	if errors.Is(err, repositories.ErrDuplicatedEmail) {
+		return nil, utils.NewConflictError("email already registered")
+	}
+	if err != nil {
+		log.Error(ctx, "error creating user: ", err)
+		return nil, utils.NewInternalServerError("could not create user")
+	}
+
+	return user, nil
+}
+
+func (s *UserService) GetByID(ctx context.Context, id uint) (*models.User, error) {
+	user, err := s.UserRepository.FindByID(ctx, id)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return nil, utils.NewInternalServerError("could not get user")
+	}
+	if user == nil {
+		return nil, utils.NewNotFoundError("user not found")
+	}
+	return user, nil
+}
+
+func (s *UserService) Update(ctx context.Context, id uint, request dto.UpdateUserRequest) (*models.User, error) {
+	user, err := s.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Locale = request.Locale
+	if err := s.UserRepository.Update(ctx, user); err != nil {
+		log.Error(ctx, "error updating user: ", err)
+		return nil, utils.NewInternalServerError("could not update user")
+	}
+	return user, nil
+}
+
+func NormalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
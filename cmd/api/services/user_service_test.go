@@ -0,0 +1,272 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+func TestUserService(t *testing.T) {
+
+	t.Run("signup", func(t *testing.T) {
+		repository := newFakeUserRepository()
+		roleService := newTestRoleService(repository)
+		mails := &fakeMailer{}
+		service := NewUserService(repository, fakeTransactor{}, NewVerificationService(repository, newFakeVerificationCodeRepository(), fakeTransactor{}, mails), roleService, newTestPasswordPolicyService(), newTestPasswordHasher())
+
+		user, err := service.Signup(context.Background(), dto.SignupRequest{
+			Name:     " Ana ",
+			Email:    " Ana@Example.com ",
+			Password: "s3cret-password",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.ID == 0 {
+			t.Errorf("expected user to be persisted")
+		}
+		if len(mails.messages) != 1 || mails.outsideTransaction != 0 {
+			t.Errorf("expected the verification email to be enqueued in the signup transaction")
+		}
+		if user.Email != "ana@example.com" || user.Name != "Ana" {
+			t.Errorf("unexpected user data: %+v", user)
+		}
+		if user.PasswordHash == "s3cret-password" || !utils.CheckPassword(user.PasswordHash, "s3cret-password") {
+			t.Errorf("expected password to be hashed")
+		}
+
+		authorization, _ := roleService.UserAuthorization(context.Background(), user.ID)
+		if len(authorization.Roles) != 1 || authorization.Roles[0] != constants.DefaultSignupRole {
+			t.Errorf("expected default role, got %v", authorization.Roles)
+		}
+	})
+
+	t.Run("signup duplicated email", func(t *testing.T) {
+		repository := newFakeUserRepository()
+		service := NewUserService(repository, fakeTransactor{}, NewVerificationService(repository, newFakeVerificationCodeRepository(), fakeTransactor{}, &fakeMailer{}), newTestRoleService(repository), newTestPasswordPolicyService(), newTestPasswordHasher())
+		request := dto.SignupRequest{Name: "Ana", Email: "ana@example.com", Password: "s3cret-password"}
+
+		if _, err := service.Signup(context.Background(), request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		request.Email = "ANA@example.com"
+		_, err := service.Signup(context.Background(), request)
+
+		var apiErr *utils.APIError
+		if !errors.As(err, &apiErr) || apiErr.Status != http.StatusConflict {
+			t.Errorf("unexpected error: got %v, want conflict", err)
+		}
+	})
+	t.Run("signup fails when the default role cannot be assigned", func(t *testing.T) {
+		repository := newFakeUserRepository()
+		roleService := newTestRoleService(repository)
+		roleService.RoleRepository = newFakeRoleRepository()
+		mails := &fakeMailer{}
+		service := NewUserService(repository, fakeTransactor{}, NewVerificationService(repository, newFakeVerificationCodeRepository(), fakeTransactor{}, mails), roleService, newTestPasswordPolicyService(), newTestPasswordHasher())
+
+		_, err := service.Signup(context.Background(), dto.SignupRequest{Name: "Ana", Email: "ana@example.com", Password: "s3cret-password"})
+
+		var apiErr *utils.APIError
+		if !errors.As(err, &apiErr) || apiErr.Status != http.StatusInternalServerError {
+			t.Errorf("unexpected error: got %v, want internal error", err)
+		}
+		if len(mails.messages) != 0 {
+			t.Errorf("expected no verification email for a failed signup")
+		}
+	})
+	t.Run("signup rejects weak passwords", func(t *testing.T) {
+		repository := newFakeUserRepository()
+		service := NewUserService(repository, fakeTransactor{}, NewVerificationService(repository, newFakeVerificationCodeRepository(), fakeTransactor{}, &fakeMailer{}), newTestRoleService(repository), newTestPasswordPolicyService(), newTestPasswordHasher())
+
+		_, err := service.Signup(context.Background(), dto.SignupRequest{Name: "Ana", Email: "ana@example.com", Password: "password123"})
+
+		var apiErr *utils.APIError
+		if !errors.As(err, &apiErr) || apiErr.Code != "weak_password" {
+			t.Fatalf("unexpected error: got %v, want weak password", err)
+		}
+		if user, _ := repository.FindByEmail(context.Background(), "ana@example.com"); user != nil {
+			t.Errorf("expected no user to be created")
+		}
+	})
+	t.Run("update locale", func(t *testing.T) {
+		repository := newFakeUserRepository()
+		mails := &fakeMailer{}
+		service := NewUserService(repository, fakeTransactor{}, NewVerificationService(repository, newFakeVerificationCodeRepository(), fakeTransactor{}, mails), newTestRoleService(repository), newTestPasswordPolicyService(), newTestPasswordHasher())
+
+		user, err := service.Signup(context.Background(), dto.SignupRequest{Name: "Ana", Email: "ana@example.com", Password: "s3cret-password", Locale: constants.LocaleEnglish})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.Locale != constants.LocaleEnglish || mails.last().Locale != constants.LocaleEnglish {
+			t.Errorf("expected the signup locale to be stored and used, got %q and %q", user.Locale, mails.last().Locale)
+		}
+
+		updated, err := service.Update(context.Background(), user.ID, dto.UpdateUserRequest{Locale: constants.LocalePortuguese})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if stored, _ := repository.FindByID(context.Background(), user.ID); updated.Locale != constants.LocalePortuguese || stored.Locale != constants.LocalePortuguese {
+			t.Errorf("expected the locale to be updated, got %+v", stored)
+		}
+
+		_, err = service.Update(context.Background(), 99, dto.UpdateUserRequest{Locale: constants.LocaleEnglish})
+		var apiErr *utils.APIError
+		if !errors.As(err, &apiErr) || apiErr.Status != http.StatusNotFound {
+			t.Errorf("unexpected error: got %v, want not found", err)
+		}
+	})
+}
+
+type fakeUserRepository struct {
+	users  map[uint]*models.User
+	nextID uint
+}
+
+func newFakeUserRepository() *fakeUserRepository {
+	return &fakeUserRepository{users: map[uint]*models.User{}, nextID: 1}
+}
+
+func (r *fakeUserRepository) Create(_ context.Context, user *models.User) error {
+	for _, existing := range r.users {
+		if existing.Email == user.Email {
+			return repositories.ErrDuplicatedEmail
+		}
+	}
+	user.ID = r.nextID
+	user.CreatedAt = time.Now()
+	user.UpdatedAt = user.CreatedAt
+	r.nextID++
+	stored := *user
+	r.users[user.ID] = &stored
+	return nil
+}
+
+func (r *fakeUserRepository) FindByID(_ context.Context, id uint) (*models.User, error) {
+	user, ok := r.users[id]
+	if !ok {
+		return nil, nil
+	}
+	found := *user
+	return &found, nil
+}
+
+func (r *fakeUserRepository) FindByEmail(_ context.Context, email string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.Email == email {
+			found := *user
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeUserRepository) FindByPhoneNumber(_ context.Context, phoneNumber string) (*models.User, error) {
+	for _, user := range r.users {
+		if user.PhoneNumber == phoneNumber {
+			found := *user
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeUserRepository) Update(_ context.Context, user *models.User) error {
+	for _, existing := range r.users {
+		if user.PhoneNumber != "" && existing.ID != user.ID && existing.PhoneNumber == user.PhoneNumber {
+			return repositories.ErrDuplicatedPhoneNumber
+		}
+	}
+	stored := *user
+	r.users[user.ID] = &stored
+	return nil
+}
+
+type fakeVerificationCodeRepository struct {
+	codes  []*models.VerificationCode
+	nextID uint
+}
+
+func newFakeVerificationCodeRepository() *fakeVerificationCodeRepository {
+	return &fakeVerificationCodeRepository{nextID: 1}
+}
+
+func (r *fakeVerificationCodeRepository) Create(_ context.Context, code *models.VerificationCode) error {
+	code.ID = r.nextID
+	code.CreatedAt = time.Now()
+	r.nextID++
+	r.codes = append(r.codes, code)
+	return nil
+}
+
+func (r *fakeVerificationCodeRepository) FindLatestActive(_ context.Context, userID uint, purpose string, now time.Time) (*models.VerificationCode, error) {
+	for i := len(r.codes) - 1; i >= 0; i-- {
+		code := r.codes[i]
+		if code.UserID == userID && code.Purpose == purpose && code.IsActive(now) {
+			found := *code
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeVerificationCodeRepository) FindActiveByHash(_ context.Context, purpose, codeHash string, now time.Time) (*models.VerificationCode, error) {
+	for _, code := range r.codes {
+		if code.Purpose == purpose && code.CodeHash == codeHash && code.IsActive(now) {
+			found := *code
+			return &found, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeVerificationCodeRepository) IncrementAttempts(_ context.Context, id uint) error {
+	for _, code := range r.codes {
+		if code.ID == id {
+			code.Attempts++
+		}
+	}
+	return nil
+}
+
+func (r *fakeVerificationCodeRepository) MarkUsed(_ context.Context, id uint, usedAt time.Time) error {
+	for _, code := range r.codes {
+		if code.ID == id {
+			if code.UsedAt != nil {
+				return repositories.ErrCodeAlreadyUsed
+			}
+			code.UsedAt = &usedAt
+		}
+	}
+	return nil
+}
+
+func (r *fakeVerificationCodeRepository) InvalidateAll(_ context.Context, userID uint, purpose string, usedAt time.Time) error {
+	for _, code := range r.codes {
+		if code.UserID == userID && code.Purpose == purpose && code.UsedAt == nil {
+			code.UsedAt = &usedAt
+		}
+	}
+	return nil
+}
+
+type fakeTransactionKey struct{}
+
+// fakeTransactor no abre transacciones reales: marca el contexto para que los fakes sepan que corren dentro de una.
+type fakeTransactor struct{}
+
+func (fakeTransactor) WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error {
+	return fn(context.WithValue(ctx, fakeTransactionKey{}, true))
+}
+
+func inFakeTransaction(ctx context.Context) bool {
+	return ctx.Value(fakeTransactionKey{}) != nil
+}
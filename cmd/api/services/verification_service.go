@@ -0,0 +1,205 @@
+package services
+
+import (
+	"context"
+	"crypto/subtle"
+	"time"
+
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/mailer"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type IVerificationService interface {
+	IssueCode(ctx context.Context, userID uint, purpose string, ttl time.Duration) (string, error)
+	RedeemCode(ctx context.Context, userID uint, purpose, code string) error
+	IssueToken(ctx context.Context, userID uint, purpose string, ttl time.Duration) (string, error)
+	FindToken(ctx context.Context, purpose, token string) (uint, error)
+	RedeemToken(ctx context.Context, purpose, token string) (uint, error)
+	SendEmailVerification(ctx context.Context, user *models.User) error
+	ConfirmEmail(ctx context.Context, request dto.VerifyEmailRequest) error
+	ResendEmailVerification(ctx context.Context, request dto.ResendVerificationRequest) error
+}
+
+type VerificationService struct {
+	UserRepository             repositories.IUserRepository
+	VerificationCodeRepository repositories.IVerificationCodeRepository
+	Transactor                 repositories.ITransactor
+	Mailer                     mailer.IMailer
+	now                        func() time.Time
+}
+
+func NewVerificationService(
+	userRepository repositories.IUserRepository,
+	verificationCodeRepository repositories.IVerificationCodeRepository,
+	transactor repositories.ITransactor,
+	mailer mailer.IMailer,
+) *VerificationService {
+	return &VerificationService{
+		UserRepository:             userRepository,
+		VerificationCodeRepository: verificationCodeRepository,
+		Transactor:                 transactor,
+		Mailer:                     mailer,
+		now:                        time.Now,
+	}
+}
+
+// IssueCode invalida los códigos vigentes del mismo propósito y guarda uno nuevo; devuelve el código en claro
+// para que el llamador lo envíe.
+func (s *VerificationService) IssueCode(ctx context.Context, userID uint, purpose string, ttl time.Duration) (string, error) {
+	code, err := utils.GenerateNumericCode(constants.VerificationCodeLength)
+	if err != nil {
+		return "", err
+	}
+	return code, s.store(ctx, userID, purpose, code, ttl)
+}
+
+// IssueToken es como IssueCode pero con un token opaco, pensado para enlaces que no incluyen el email.
+func (s *VerificationService) IssueToken(ctx context.Context, userID uint, purpose string, ttl time.Duration) (string, error) {
+	token, err := utils.GenerateToken(constants.VerificationTokenSize)
+	if err != nil {
+		return "", err
+	}
+	return token, s.store(ctx, userID, purpose, token, ttl)
+}
+
+func (s *VerificationService) store(ctx context.Context, userID uint, purpose, secret string, ttl time.Duration) error {
+	now := s.now()
+	if err := s.VerificationCodeRepository.InvalidateAll(ctx, userID, purpose, now); err != nil {
+		return err
+	}
+
+	return s.VerificationCodeRepository.Create(ctx, &models.VerificationCode{
+		UserID:    userID,
+		Purpose:   purpose,
+		CodeHash:  utils.HashToken(secret),
+		ExpiresAt: now.Add(ttl),
+	})
+}
+
+// RedeemCode consume el código vigente si coincide; tras MaxVerificationCodeAttempts fallos el código se invalida.
+func (s *VerificationService) RedeemCode(ctx context.Context, userID uint, purpose, code string) error {
+	now := s.now()
+	stored, err := s.VerificationCodeRepository.FindLatestActive(ctx, userID, purpose, now)
+	if err != nil {
+		log.Error(ctx, "error finding verification code: ", err)
+		return utils.NewInternalServerError("could not verify code")
+	}
+	if stored == nil || stored.Attempts >= constants.MaxVerificationCodeAttempts {
+		return utils.NewBadRequestError("invalid or expired code")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(stored.CodeHash), []byte(utils.HashToken(code))) != 1 {
+		if err := s.VerificationCodeRepository.IncrementAttempts(ctx, stored.ID); err != nil {
+			log.Error(ctx, "error incrementing verification code attempts: ", err)
+		}
+		return utils.NewBadRequestError("invalid or expired code")
+	}
+
+	if err := s.VerificationCodeRepository.MarkUsed(ctx, stored.ID, now); err != nil {
+		return utils.NewBadRequestError("invalid or expired code")
+	}
+	return nil
+}
+
+// FindToken devuelve el usuario del token vigente sin consumirlo, para validar antes de canjearlo.
+func (s *VerificationService) FindToken(ctx context.Context, purpose, token string) (uint, error) {
+	stored, err := s.findToken(ctx, purpose, token)
+	if err != nil {
+		return 0, err
+	}
+	return stored.UserID, nil
+}
+
+// RedeemToken consume el token vigente y devuelve el usuario al que pertenece.
+func (s *VerificationService) RedeemToken(ctx context.Context, purpose, token string) (uint, error) {
+	stored, err := s.findToken(ctx, purpose, token)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := s.VerificationCodeRepository.MarkUsed(ctx, stored.ID, s.now()); err != nil {
+		return 0, utils.NewBadRequestError("invalid or expired token")
+	}
+	return stored.UserID, nil
+}
+
+func (s *VerificationService) findToken(ctx context.Context, purpose, token string) (*models.VerificationCode, error) {
+	stored, err := s.VerificationCodeRepository.FindActiveByHash(ctx, purpose, utils.HashToken(token), s.now())
+	if err != nil {
+		log.Error(ctx, "error finding verification token: ", err)
+		return nil, utils.NewInternalServerError("could not verify token")
+	}
+	if stored == nil {
+		return nil, utils.NewBadRequestError("invalid or expired token")
+	}
+	return stored, nil
+}
+
+// SendEmailVerification guarda el código y encola el email en la misma transacción; dentro del alta se suma a la
+// transacción que crea el usuario.
+func (s *VerificationService) SendEmailVerification(ctx context.Context, user *models.User) error {
+	return s.Transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		ttl := time.Duration(constants.TimeCodeExpiration) * time.Hour
+		code, err := s.IssueCode(ctx, user.ID, models.CodePurposeVerifyEmail, ttl)
+		if err != nil {
+			return err
+		}
+
+		return s.Mailer.Send(ctx, mailer.Message{
+			To:       user.Email,
+			Locale:   user.Locale,
+			Template: constants.TemplateVerifyEmail,
+			Data: map[string]string{
+				"name": user.Name,
+				"code": code,
+			},
+		})
+	})
+}
+
+func (s *VerificationService) ConfirmEmail(ctx context.Context, request dto.VerifyEmailRequest) error {
+	user, err := s.UserRepository.FindByEmail(ctx, NormalizeEmail(request.Email))
+	if err != nil {
+		log.Error(ctx, "error finding user by email: ", err)
+		return utils.NewInternalServerError("could not verify email")
+	}
+	if user == nil || user.EmailVerified {
+		return utils.NewBadRequestError("invalid or expired code")
+	}
+
+	if err := s.RedeemCode(ctx, user.ID, models.CodePurposeVerifyEmail, request.Code); err != nil {
+		return err
+	}
+
+	verifiedAt := s.now()
+	user.EmailVerified = true
+	user.EmailVerifiedAt = &verifiedAt
+	if err := s.UserRepository.Update(ctx, user); err != nil {
+		log.Error(ctx, "error updating user: ", err)
+		return utils.NewInternalServerError("could not verify email")
+	}
+	return nil
+}
+
+// ResendEmailVerification no indica si el email existe o ya está verificado.
+func (s *VerificationService) ResendEmailVerification(ctx context.Context, request dto.ResendVerificationRequest) error {
+	user, err := s.UserRepository.FindByEmail(ctx, NormalizeEmail(request.Email))
+	if err != nil {
+		log.Error(ctx, "error finding user by email: ", err)
+		return utils.NewInternalServerError("could not resend verification code")
+	}
+	if user == nil || user.EmailVerified {
+		return nil
+	}
+
+	if err := s.SendEmailVerification(ctx, user); err != nil {
+		log.Error(ctx, "error sending verification email: ", err)
+		return utils.NewInternalServerError("could not resend verification code")
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/mailer"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+)
+
+func newTestVerificationService() (*VerificationService, *fakeUserRepository, *fakeMailer, *time.Time) {
+	users := newFakeUserRepository()
+	mails := &fakeMailer{}
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	service := NewVerificationService(users, newFakeVerificationCodeRepository(), fakeTransactor{}, mails)
+	service.now = func() time.Time { return now }
+	return service, users, mails, &now
+}
+
+func TestVerificationService(t *testing.T) {
+
+	t.Run("confirm email", func(t *testing.T) {
+		service, users, mails, _ := newTestVerificationService()
+		user := &models.User{Name: "Ana", Email: "ana@example.com", Locale: constants.LocalePortuguese}
+		_ = users.Create(context.Background(), user)
+
+		if err := service.SendEmailVerification(context.Background(), user); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		message := mails.last()
+		if message.Template != constants.TemplateVerifyEmail || message.Locale != constants.LocalePortuguese {
+			t.Errorf("unexpected message: %+v", message)
+		}
+
+		request := dto.VerifyEmailRequest{Email: "ana@example.com", Code: message.Data["code"]}
+		if err := service.ConfirmEmail(context.Background(), request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		verified, _ := users.FindByID(context.Background(), user.ID)
+		if !verified.EmailVerified || verified.EmailVerifiedAt == nil {
+			t.Errorf("expected user to be verified")
+		}
+	})
+
+	t.Run("codes are single use and expire", func(t *testing.T) {
+		testCases := []struct {
+			name    string
+			prepare func(service *VerificationService, now *time.Time, code string)
+		}{
+			{
+				name: "expired code",
+				prepare: func(_ *VerificationService, now *time.Time, _ string) {
+					*now = now.Add(time.Duration(constants.TimeCodeExpiration)*time.Hour + time.Second)
+				},
+			},
+			{
+				name: "used code",
+				prepare: func(service *VerificationService, _ *time.Time, code string) {
+					_ = service.RedeemCode(context.Background(), 1, models.CodePurposeVerifyEmail, code)
+				},
+			},
+			{
+				name: "superseded code",
+				prepare: func(service *VerificationService, _ *time.Time, _ string) {
+					_, _ = service.IssueCode(context.Background(), 1, models.CodePurposeVerifyEmail, time.Hour)
+				},
+			},
+			{
+				name: "too many attempts",
+				prepare: func(service *VerificationService, _ *time.Time, _ string) {
+					for i := 0; i < constants.MaxVerificationCodeAttempts; i++ {
+						_ = service.RedeemCode(context.Background(), 1, models.CodePurposeVerifyEmail, "000000x")
+					}
+				},
+			},
+		}
+
+		for _, tc := range testCases {
+			service, _, _, now := newTestVerificationService()
+			code, err := service.IssueCode(context.Background(), 1, models.CodePurposeVerifyEmail, time.Hour)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tc.name, err)
+			}
+
+			tc.prepare(service, now, code)
+
+			if err := service.RedeemCode(context.Background(), 1, models.CodePurposeVerifyEmail, code); err == nil {
+				t.Errorf("%s: expected code to be rejected", tc.name)
+			}
+		}
+	})
+
+	t.Run("resend does not reveal unknown emails", func(t *testing.T) {
+		service, _, mails, _ := newTestVerificationService()
+
+		err := service.ResendEmailVerification(context.Background(), dto.ResendVerificationRequest{Email: "nobody@example.com"})
+		if err != nil || len(mails.messages) != 0 {
+			t.Errorf("unexpected result: err %v, messages %d", err, len(mails.messages))
+		}
+	})
+}
+
+type fakeMailer struct {
+	messages []mailer.Message
+	// outsideTransaction cuenta los emails encolados fuera de una transacción.
+	outsideTransaction int
+}
+
+func (m *fakeMailer) Send(ctx context.Context, message mailer.Message) error {
+	if !inFakeTransaction(ctx) {
+		m.outsideTransaction++
+	}
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func (m *fakeMailer) last() mailer.Message {
+	return m.messages[len(m.messages)-1]
+}
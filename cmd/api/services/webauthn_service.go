@@ -0,0 +1,363 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/labstack/gommon/log"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/utils"
+)
+
+type IWebAuthnService interface {
+	BeginRegistration(ctx context.Context, userID uint) (*dto.WebAuthnBeginResponse, error)
+	FinishRegistration(ctx context.Context, userID uint, request dto.WebAuthnRegistrationRequest) (*models.WebAuthnCredential, error)
+	ListCredentials(ctx context.Context, userID uint) ([]models.WebAuthnCredential, error)
+	DeleteCredential(ctx context.Context, userID, id uint) error
+	BeginLogin(ctx context.Context) (*dto.WebAuthnBeginResponse, error)
+	FinishLogin(ctx context.Context, request dto.WebAuthnLoginRequest) (*models.User, error)
+}
+
+type WebAuthnService struct {
+	WebAuthn                     *webauthn.WebAuthn
+	UserRepository               repositories.IUserRepository
+	WebAuthnCredentialRepository repositories.IWebAuthnCredentialRepository
+	WebAuthnSessionRepository    repositories.IWebAuthnSessionRepository
+	SessionTTL                   time.Duration
+	now                          func() time.Time
+}
+
+func NewWebAuthnService(
+	webAuthn *webauthn.WebAuthn,
+	userRepository repositories.IUserRepository,
+	webAuthnCredentialRepository repositories.IWebAuthnCredentialRepository,
+	webAuthnSessionRepository repositories.IWebAuthnSessionRepository,
+	webAuthnConfig config.WebAuthnRelyingPartyConfig,
+) *WebAuthnService {
+	return &WebAuthnService{
+		WebAuthn:                     webAuthn,
+		UserRepository:               userRepository,
+		WebAuthnCredentialRepository: webAuthnCredentialRepository,
+		WebAuthnSessionRepository:    webAuthnSessionRepository,
+		SessionTTL:                   webAuthnConfig.Timeout,
+		now:                          time.Now,
+	}
+}
+
+// BeginRegistration pide una passkey detectable con verificación de usuario y excluye las que el usuario ya tiene.
+func (s *WebAuthnService) BeginRegistration(ctx context.Context, userID uint) (*dto.WebAuthnBeginResponse, error) {
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, utils.NewNotFoundError("user not found")
+	}
+
+	creation, session, err := s.WebAuthn.BeginRegistration(user,
+		webauthn.WithExclusions(webauthn.Credentials(user.WebAuthnCredentials()).CredentialDescriptors()),
+	)
+	if err != nil {
+		log.Error(ctx, "error beginning webauthn registration: ", err)
+		return nil, utils.NewInternalServerError("could not begin passkey registration")
+	}
+
+	sessionID, err := s.saveSession(ctx, models.WebAuthnCeremonyRegistration, &userID, session)
+	if err != nil {
+		log.Error(ctx, "error saving webauthn session: ", err)
+		return nil, utils.NewInternalServerError("could not begin passkey registration")
+	}
+
+	return &dto.WebAuthnBeginResponse{SessionID: sessionID, Options: creation}, nil
+}
+
+func (s *WebAuthnService) FinishRegistration(ctx context.Context, userID uint, request dto.WebAuthnRegistrationRequest) (*models.WebAuthnCredential, error) {
+	stored, session, err := s.consumeSession(ctx, models.WebAuthnCeremonyRegistration, request.SessionID)
+	if err != nil {
+		return nil, err
+	}
+	if stored.UserID == nil || *stored.UserID != userID {
+		return nil, utils.NewBadRequestError("invalid or expired session")
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(request.Credential)
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid passkey credential")
+	}
+
+	user, err := s.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, utils.NewNotFoundError("user not found")
+	}
+
+	credential, err := s.WebAuthn.CreateCredential(user, *session, parsed)
+	if err != nil {
+		log.Debug(ctx, "webauthn registration rejected: ", err)
+		return nil, utils.NewBadRequestError("invalid passkey credential")
+	}
+
+	created := newWebAuthnCredentialModel(userID, strings.TrimSpace(request.Name), credential)
+	if err := s.WebAuthnCredentialRepository.Create(ctx, created); err != nil {
+		if errors.Is(err, repositories.ErrDuplicatedCredential) {
+			return nil, utils.NewConflictError("passkey already registered")
+		}
+		log.Error(ctx, "error saving webauthn credential: ", err)
+		return nil, utils.NewInternalServerError("could not register passkey")
+	}
+	return created, nil
+}
+
+func (s *WebAuthnService) ListCredentials(ctx context.Context, userID uint) ([]models.WebAuthnCredential, error) {
+	credentials, err := s.WebAuthnCredentialRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error listing webauthn credentials: ", err)
+		return nil, utils.NewInternalServerError("could not list passkeys")
+	}
+	return credentials, nil
+}
+
+func (s *WebAuthnService) DeleteCredential(ctx context.Context, userID, id uint) error {
+	if err := s.WebAuthnCredentialRepository.Delete(ctx, userID, id); err != nil {
+		if errors.Is(err, repositories.ErrNotFound) {
+			return utils.NewNotFoundError("passkey not found")
+		}
+		log.Error(ctx, "error deleting webauthn credential: ", err)
+		return utils.NewInternalServerError("could not delete passkey")
+	}
+	return nil
+}
+
+// BeginLogin arranca un login sin usuario conocido: el autenticador elige la passkey y devuelve el user handle.
+func (s *WebAuthnService) BeginLogin(ctx context.Context) (*dto.WebAuthnBeginResponse, error) {
+	assertion, session, err := s.WebAuthn.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		log.Error(ctx, "error beginning webauthn login: ", err)
+		return nil, utils.NewInternalServerError("could not begin passkey login")
+	}
+
+	sessionID, err := s.saveSession(ctx, models.WebAuthnCeremonyLogin, nil, session)
+	if err != nil {
+		log.Error(ctx, "error saving webauthn session: ", err)
+		return nil, utils.NewInternalServerError("could not begin passkey login")
+	}
+
+	return &dto.WebAuthnBeginResponse{SessionID: sessionID, Options: assertion}, nil
+}
+
+// FinishLogin valida la firma de la passkey y devuelve su dueño. Un contador de firmas que retrocede indica una
+// posible copia de la clave, así que ese login se rechaza.
+func (s *WebAuthnService) FinishLogin(ctx context.Context, request dto.WebAuthnLoginRequest) (*models.User, error) {
+	_, session, err := s.consumeSession(ctx, models.WebAuthnCeremonyLogin, request.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(request.Credential)
+	if err != nil {
+		return nil, utils.NewBadRequestError("invalid passkey credential")
+	}
+
+	var owner *webAuthnUser
+	handler := func(_, userHandle []byte) (webauthn.User, error) {
+		userID, err := strconv.ParseUint(string(userHandle), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		owner, err = s.findUser(ctx, uint(userID))
+		if err != nil {
+			return nil, err
+		}
+		if owner == nil {
+			return nil, errors.New("unknown user handle")
+		}
+		return owner, nil
+	}
+
+	credential, err := s.WebAuthn.ValidateDiscoverableLogin(handler, *session, parsed)
+	if err != nil {
+		log.Debug(ctx, "webauthn login rejected: ", err)
+		return nil, utils.NewUnauthorizedError("invalid passkey")
+	}
+
+	stored := owner.credential(credential.ID)
+	stored.SignCount = credential.Authenticator.SignCount
+	stored.CloneWarning = credential.Authenticator.CloneWarning
+	stored.Flags = credentialFlags(credential.Flags)
+	now := s.now()
+	stored.LastUsedAt = &now
+	if err := s.WebAuthnCredentialRepository.UpdateAfterLogin(ctx, stored); err != nil {
+		log.Error(ctx, "error updating webauthn credential: ", err)
+		return nil, utils.NewInternalServerError("could not log in")
+	}
+
+	if credential.Authenticator.CloneWarning {
+		log.Warn(ctx, "webauthn sign counter went backwards, possible cloned credential: ", stored.ID)
+		return nil, utils.NewUnauthorizedError("invalid passkey")
+	}
+	return owner.user, nil
+}
+
+// findUser devuelve nil, nil cuando el usuario no existe.
+func (s *WebAuthnService) findUser(ctx context.Context, userID uint) (*webAuthnUser, error) {
+	user, err := s.UserRepository.FindByID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error finding user by id: ", err)
+		return nil, utils.NewInternalServerError("could not find user")
+	}
+	if user == nil {
+		return nil, nil
+	}
+
+	credentials, err := s.WebAuthnCredentialRepository.FindByUserID(ctx, userID)
+	if err != nil {
+		log.Error(ctx, "error listing webauthn credentials: ", err)
+		return nil, utils.NewInternalServerError("could not find user")
+	}
+	return &webAuthnUser{user: user, credentials: credentials}, nil
+}
+
+// saveSession guarda el estado de la ceremonia y devuelve el token opaco que lo identifica.
+func (s *WebAuthnService) saveSession(ctx context.Context, ceremony string, userID *uint, session *webauthn.SessionData) (string, error) {
+	now := s.now()
+	if err := s.WebAuthnSessionRepository.DeleteExpired(ctx, now); err != nil {
+		log.Error(ctx, "error deleting expired webauthn sessions: ", err)
+	}
+
+	data, err := json.Marshal(session)
+	if err != nil {
+		return "", err
+	}
+	token, err := utils.GenerateToken(32)
+	if err != nil {
+		return "", err
+	}
+
+	err = s.WebAuthnSessionRepository.Create(ctx, &models.WebAuthnSession{
+		TokenHash: utils.HashToken(token),
+		Ceremony:  ceremony,
+		UserID:    userID,
+		Data:      string(data),
+		ExpiresAt: now.Add(s.SessionTTL),
+	})
+	return token, err
+}
+
+func (s *WebAuthnService) consumeSession(ctx context.Context, ceremony, token string) (*models.WebAuthnSession, *webauthn.SessionData, error) {
+	stored, err := s.WebAuthnSessionRepository.Consume(ctx, ceremony, utils.HashToken(token), s.now())
+	if err != nil {
+		log.Error(ctx, "error finding webauthn session: ", err)
+		return nil, nil, utils.NewInternalServerError("could not verify passkey")
+	}
+	if stored == nil {
+		return nil, nil, utils.NewBadRequestError("invalid or expired session")
+	}
+
+	var session webauthn.SessionData
+	if err := json.Unmarshal([]byte(stored.Data), &session); err != nil {
+		log.Error(ctx, "error decoding webauthn session: ", err)
+		return nil, nil, utils.NewInternalServerError("could not verify passkey")
+	}
+	return stored, &session, nil
+}
+
+// webAuthnUser adapta un usuario y sus passkeys a webauthn.User. El user handle es el ID en decimal.
+type webAuthnUser struct {
+	user        *models.User
+	credentials []models.WebAuthnCredential
+}
+
+func (u *webAuthnUser) WebAuthnID() []byte {
+	return []byte(strconv.FormatUint(uint64(u.user.ID), 10))
+}
+
+func (u *webAuthnUser) WebAuthnName() string {
+	return u.user.Email
+}
+
+func (u *webAuthnUser) WebAuthnDisplayName() string {
+	return u.user.Name
+}
+
+func (u *webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	credentials := make([]webauthn.Credential, 0, len(u.credentials))
+	for _, stored := range u.credentials {
+		var transports []protocol.AuthenticatorTransport
+		for _, transport := range strings.Split(stored.Transports, ",") {
+			if transport != "" {
+				transports = append(transports, protocol.AuthenticatorTransport(transport))
+			}
+		}
+
+		credentials = append(credentials, webauthn.Credential{
+			ID:              stored.CredentialID,
+			PublicKey:       stored.PublicKey,
+			AttestationType: stored.AttestationType,
+			Transport:       transports,
+			Flags:           webauthn.NewCredentialFlags(protocol.AuthenticatorFlags(stored.Flags)),
+			Authenticator: webauthn.Authenticator{
+				AAGUID:       stored.AAGUID,
+				SignCount:    stored.SignCount,
+				CloneWarning: stored.CloneWarning,
+			},
+		})
+	}
+	return credentials
+}
+
+func (u *webAuthnUser) credential(id []byte) *models.WebAuthnCredential {
+	for i := range u.credentials {
+		if bytes.Equal(u.credentials[i].CredentialID, id) {
+			return &u.credentials[i]
+		}
+	}
+	return nil
+}
+
+func newWebAuthnCredentialModel(userID uint, name string, credential *webauthn.Credential) *models.WebAuthnCredential {
+	transports := make([]string, 0, len(credential.Transport))
+	for _, transport := range credential.Transport {
+		transports = append(transports, string(transport))
+	}
+
+	return &models.WebAuthnCredential{
+		UserID:          userID,
+		CredentialID:    credential.ID,
+		PublicKey:       credential.PublicKey,
+		AttestationType: credential.AttestationType,
+		AAGUID:          credential.Authenticator.AAGUID,
+		SignCount:       credential.Authenticator.SignCount,
+		Transports:      strings.Join(transports, ","),
+		Flags:           credentialFlags(credential.Flags),
+		Name:            name,
+	}
+}
+
+func credentialFlags(flags webauthn.CredentialFlags) uint8 {
+	var raw protocol.AuthenticatorFlags
+	if flags.UserPresent {
+		raw |= protocol.FlagUserPresent
+	}
+	if flags.UserVerified {
+		raw |= protocol.FlagUserVerified
+	}
+	if flags.BackupEligible {
+		raw |= protocol.FlagBackupEligible
+	}
+	if flags.BackupState {
+		raw |= protocol.FlagBackupState
+	}
+	return uint8(raw)
+}
@@ -0,0 +1,396 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/config"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/dto"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/models"
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/repositories"
+)
+
+const (
+	testRPID     = "tareaya.test"
+	testRPOrigin = "https://tareaya.test"
+)
+
+// softAuthenticator es un autenticador de plataforma en software: genera una clave P-256, responde al registro
+// con atestación "none" y firma las aserciones como lo haría un navegador.
+type softAuthenticator struct {
+	key          *ecdsa.PrivateKey
+	credentialID []byte
+	userHandle   []byte
+	signCount    uint32
+	origin       string
+}
+
+func newSoftAuthenticator(t *testing.T) *softAuthenticator {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	credentialID := make([]byte, 16)
+	_, _ = rand.Read(credentialID)
+	return &softAuthenticator{key: key, credentialID: credentialID, origin: testRPOrigin}
+}
+
+func (a *softAuthenticator) register(t *testing.T, options interface{}) json.RawMessage {
+	t.Helper()
+
+	creation := options.(*protocol.CredentialCreation)
+	a.userHandle = creation.Response.User.ID.(protocol.URLEncodedBase64)
+
+	publicKey, _ := cbor.Marshal(map[int]interface{}{
+		1:  2,
+		3:  -7,
+		-1: 1,
+		-2: a.key.PublicKey.X.FillBytes(make([]byte, 32)),
+		-3: a.key.PublicKey.Y.FillBytes(make([]byte, 32)),
+	})
+	attested := make([]byte, 16, 16+2+len(a.credentialID)+len(publicKey))
+	attested = binary.BigEndian.AppendUint16(attested, uint16(len(a.credentialID)))
+	attested = append(attested, a.credentialID...)
+	attested = append(attested, publicKey...)
+
+	authData := a.authenticatorData(protocol.FlagUserPresent|protocol.FlagUserVerified|protocol.FlagAttestedCredentialData, attested)
+	attestation, _ := cbor.Marshal(map[string]interface{}{
+		"fmt":      "none",
+		"attStmt":  map[string]interface{}{},
+		"authData": authData,
+	})
+
+	return a.credential(map[string]interface{}{
+		"clientDataJSON":    a.clientData("webauthn.create", creation.Response.Challenge.String()),
+		"attestationObject": encodeBase64(attestation),
+		"transports":        []string{"internal", "hybrid"},
+	})
+}
+
+func (a *softAuthenticator) login(t *testing.T, options interface{}) json.RawMessage {
+	t.Helper()
+
+	assertion := options.(*protocol.CredentialAssertion)
+	a.signCount++
+
+	authData := a.authenticatorData(protocol.FlagUserPresent|protocol.FlagUserVerified, nil)
+	clientData := a.clientData("webauthn.get", assertion.Response.Challenge.String())
+	clientDataJSON, _ := base64.RawURLEncoding.DecodeString(clientData)
+	clientDataHash := sha256.Sum256(clientDataJSON)
+	digest := sha256.Sum256(append(authData, clientDataHash[:]...))
+	signature, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	return a.credential(map[string]interface{}{
+		"clientDataJSON":    clientData,
+		"authenticatorData": encodeBase64(authData),
+		"signature":         encodeBase64(signature),
+		"userHandle":        encodeBase64(a.userHandle),
+	})
+}
+
+func (a *softAuthenticator) authenticatorData(flags protocol.AuthenticatorFlags, attested []byte) []byte {
+	rpIDHash := sha256.Sum256([]byte(testRPID))
+	data := append(rpIDHash[:], byte(flags))
+	data = binary.BigEndian.AppendUint32(data, a.signCount)
+	return append(data, attested...)
+}
+
+func (a *softAuthenticator) clientData(ceremony, challenge string) string {
+	data, _ := json.Marshal(map[string]string{"type": ceremony, "challenge": challenge, "origin": a.origin})
+	return encodeBase64(data)
+}
+
+func (a *softAuthenticator) credential(response map[string]interface{}) json.RawMessage {
+	data, _ := json.Marshal(map[string]interface{}{
+		"id":       encodeBase64(a.credentialID),
+		"rawId":    encodeBase64(a.credentialID),
+		"type":     "public-key",
+		"response": response,
+	})
+	return data
+}
+
+func encodeBase64(data []byte) string {
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// registerTestPasskey registra una passkey para el usuario 1 y devuelve el autenticador que la guarda.
+func registerTestPasskey(t *testing.T, service *WebAuthnService) *softAuthenticator {
+	t.Helper()
+
+	begin, err := service.BeginRegistration(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	authenticator := newSoftAuthenticator(t)
+	_, err = service.FinishRegistration(context.Background(), 1, dto.WebAuthnRegistrationRequest{
+		SessionID:  begin.SessionID,
+		Name:       "Laptop",
+		Credential: authenticator.register(t, begin.Options),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return authenticator
+}
+
+func loginWithPasskey(t *testing.T, service *WebAuthnService, authenticator *softAuthenticator) (*models.User, error) {
+	t.Helper()
+
+	begin, err := service.BeginLogin(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return service.FinishLogin(context.Background(), dto.WebAuthnLoginRequest{
+		SessionID:  begin.SessionID,
+		Credential: authenticator.login(t, begin.Options),
+	})
+}
+
+func TestWebAuthnService(t *testing.T) {
+
+	t.Run("register and log in with a passkey", func(t *testing.T) {
+		now := time.Now()
+		users := newFakeUserRepository()
+		_ = users.Create(context.Background(), &models.User{Name: "Ana", Email: "ana@example.com"})
+		service := newTestWebAuthnService(users, &now)
+		authenticator := registerTestPasskey(t, service)
+
+		credentials, _ := service.ListCredentials(context.Background(), 1)
+		if len(credentials) != 1 || credentials[0].Name != "Laptop" || credentials[0].Transports != "internal,hybrid" {
+			t.Fatalf("unexpected credentials: %+v", credentials)
+		}
+
+		user, err := loginWithPasskey(t, service, authenticator)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if user.ID != 1 {
+			t.Errorf("unexpected user: %+v", user)
+		}
+
+		credentials, _ = service.ListCredentials(context.Background(), 1)
+		if credentials[0].SignCount != 1 || credentials[0].LastUsedAt == nil {
+			t.Errorf("expected sign count and last use to be stored: %+v", credentials[0])
+		}
+	})
+
+	t.Run("registration session is single use and bound to the user", func(t *testing.T) {
+		now := time.Now()
+		users := newFakeUserRepository()
+		_ = users.Create(context.Background(), &models.User{Email: "ana@example.com"})
+		_ = users.Create(context.Background(), &models.User{Email: "bob@example.com"})
+		service := newTestWebAuthnService(users, &now)
+
+		begin, _ := service.BeginRegistration(context.Background(), 1)
+		credential := newSoftAuthenticator(t).register(t, begin.Options)
+		request := dto.WebAuthnRegistrationRequest{SessionID: begin.SessionID, Credential: credential}
+
+		_, err := service.FinishRegistration(context.Background(), 2, request)
+		assertAPIStatus(t, err, http.StatusBadRequest)
+
+		begin, _ = service.BeginRegistration(context.Background(), 1)
+		request = dto.WebAuthnRegistrationRequest{SessionID: begin.SessionID, Credential: newSoftAuthenticator(t).register(t, begin.Options)}
+		if _, err := service.FinishRegistration(context.Background(), 1, request); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		_, err = service.FinishRegistration(context.Background(), 1, request)
+		assertAPIStatus(t, err, http.StatusBadRequest)
+	})
+
+	t.Run("expired session", func(t *testing.T) {
+		now := time.Now()
+		users := newFakeUserRepository()
+		_ = users.Create(context.Background(), &models.User{Email: "ana@example.com"})
+		service := newTestWebAuthnService(users, &now)
+		authenticator := registerTestPasskey(t, service)
+
+		begin, _ := service.BeginLogin(context.Background())
+		now = now.Add(10 * time.Minute)
+		_, err := service.FinishLogin(context.Background(), dto.WebAuthnLoginRequest{
+			SessionID:  begin.SessionID,
+			Credential: authenticator.login(t, begin.Options),
+		})
+		assertAPIStatus(t, err, http.StatusBadRequest)
+	})
+
+	testCases := []struct {
+		name   string
+		tamper func(authenticator *softAuthenticator)
+	}{
+		{
+			name:   "wrong origin",
+			tamper: func(authenticator *softAuthenticator) { authenticator.origin = "https://evil.example" },
+		},
+		{
+			name: "wrong key",
+			tamper: func(authenticator *softAuthenticator) {
+				authenticator.key = newSoftAuthenticator(t).key
+			},
+		},
+		{
+			name:   "sign counter went backwards",
+			tamper: func(authenticator *softAuthenticator) { authenticator.signCount = 0 },
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			now := time.Now()
+			users := newFakeUserRepository()
+			_ = users.Create(context.Background(), &models.User{Email: "ana@example.com"})
+			service := newTestWebAuthnService(users, &now)
+			authenticator := registerTestPasskey(t, service)
+			authenticator.signCount = 5
+			if _, err := loginWithPasskey(t, service, authenticator); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			tc.tamper(authenticator)
+			_, err := loginWithPasskey(t, service, authenticator)
+			assertAPIStatus(t, err, http.StatusUnauthorized)
+		})
+	}
+
+	t.Run("delete only own passkeys", func(t *testing.T) {
+		now := time.Now()
+		users := newFakeUserRepository()
+		_ = users.Create(context.Background(), &models.User{Email: "ana@example.com"})
+		service := newTestWebAuthnService(users, &now)
+		registerTestPasskey(t, service)
+
+		assertAPIStatus(t, service.DeleteCredential(context.Background(), 2, 1), http.StatusNotFound)
+		if err := service.DeleteCredential(context.Background(), 1, 1); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		credentials, _ := service.ListCredentials(context.Background(), 1)
+		if len(credentials) != 0 {
+			t.Errorf("expected passkey to be deleted")
+		}
+	})
+}
+
+type fakeWebAuthnCredentialRepository struct {
+	credentials []*models.WebAuthnCredential
+	nextID      uint
+}
+
+func newFakeWebAuthnCredentialRepository() *fakeWebAuthnCredentialRepository {
+	return &fakeWebAuthnCredentialRepository{nextID: 1}
+}
+
+func (r *fakeWebAuthnCredentialRepository) Create(_ context.Context, credential *models.WebAuthnCredential) error {
+	for _, existing := range r.credentials {
+		if bytes.Equal(existing.CredentialID, credential.CredentialID) {
+			return repositories.ErrDuplicatedCredential
+		}
+	}
+	credential.ID = r.nextID
+	credential.CreatedAt = time.Now()
+	r.nextID++
+	stored := *credential
+	r.credentials = append(r.credentials, &stored)
+	return nil
+}
+
+func (r *fakeWebAuthnCredentialRepository) FindByUserID(_ context.Context, userID uint) ([]models.WebAuthnCredential, error) {
+	var credentials []models.WebAuthnCredential
+	for _, credential := range r.credentials {
+		if credential.UserID == userID {
+			credentials = append(credentials, *credential)
+		}
+	}
+	return credentials, nil
+}
+
+func (r *fakeWebAuthnCredentialRepository) UpdateAfterLogin(_ context.Context, credential *models.WebAuthnCredential) error {
+	for _, stored := range r.credentials {
+		if stored.ID == credential.ID {
+			stored.SignCount = credential.SignCount
+			stored.CloneWarning = credential.CloneWarning
+			stored.Flags = credential.Flags
+			stored.LastUsedAt = credential.LastUsedAt
+		}
+	}
+	return nil
+}
+
+func (r *fakeWebAuthnCredentialRepository) Delete(_ context.Context, userID, id uint) error {
+	for i, credential := range r.credentials {
+		if credential.ID == id && credential.UserID == userID {
+			r.credentials = append(r.credentials[:i], r.credentials[i+1:]...)
+			return nil
+		}
+	}
+	return repositories.ErrNotFound
+}
+
+type fakeWebAuthnSessionRepository struct {
+	sessions map[string]*models.WebAuthnSession
+}
+
+func newFakeWebAuthnSessionRepository() *fakeWebAuthnSessionRepository {
+	return &fakeWebAuthnSessionRepository{sessions: map[string]*models.WebAuthnSession{}}
+}
+
+func (r *fakeWebAuthnSessionRepository) Create(_ context.Context, session *models.WebAuthnSession) error {
+	r.sessions[session.TokenHash] = session
+	return nil
+}
+
+func (r *fakeWebAuthnSessionRepository) Consume(_ context.Context, ceremony, tokenHash string, now time.Time) (*models.WebAuthnSession, error) {
+	session, ok := r.sessions[tokenHash]
+	if !ok || session.Ceremony != ceremony || !now.Before(session.ExpiresAt) {
+		return nil, nil
+	}
+	delete(r.sessions, tokenHash)
+	return session, nil
+}
+
+func (r *fakeWebAuthnSessionRepository) DeleteExpired(_ context.Context, now time.Time) error {
+	for hash, session := range r.sessions {
+		if !now.Before(session.ExpiresAt) {
+			delete(r.sessions, hash)
+		}
+	}
+	return nil
+}
+
+// newTestWebAuthnService usa un relying party real con el origen que firma softAuthenticator.
+func newTestWebAuthnService(users *fakeUserRepository, now *time.Time) *WebAuthnService {
+	relyingParty, err := webauthn.New(&webauthn.Config{
+		RPID:          testRPID,
+		RPDisplayName: "Tareaya",
+		RPOrigins:     []string{testRPOrigin},
+		AuthenticatorSelection: protocol.AuthenticatorSelection{
+			ResidentKey:      protocol.ResidentKeyRequirementRequired,
+			UserVerification: protocol.VerificationRequired,
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	service := NewWebAuthnService(relyingParty, users, newFakeWebAuthnCredentialRepository(), newFakeWebAuthnSessionRepository(),
+		config.WebAuthnRelyingPartyConfig{Timeout: 5 * time.Minute})
+	service.now = func() time.Time { return *now }
+	return service
+}
@@ -0,0 +1,94 @@
+package utils
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// APIError es el error que los servicios devuelven para que el router lo traduzca a una respuesta HTTP.
+// RetryAfter, si es positivo, se publica en el header Retry-After.
+type APIError struct {
+	Status     int           `json:"status"`
+	Code       string        `json:"code"`
+	Message    string        `json:"message"`
+	RetryAfter time.Duration `json:"-"`
+}
+
+func (e *APIError) Error() string {
+	return e.Message
+}
+
+func NewAPIError(status int, code, message string) *APIError {
+	return &APIError{Status: status, Code: code, Message: message}
+}
+
+func NewBadRequestError(message string) *APIError {
+	return NewAPIError(http.StatusBadRequest, "bad_request", message)
+}
+
+func NewUnauthorizedError(message string) *APIError {
+	return NewAPIError(http.StatusUnauthorized, "unauthorized", message)
+}
+
+func NewForbiddenError(message string) *APIError {
+	return NewAPIError(http.StatusForbidden, "forbidden", message)
+}
+
+func NewNotFoundError(message string) *APIError {
+	return NewAPIError(http.StatusNotFound, "not_found", message)
+}
+
+func NewConflictError(message string) *APIError {
+	return NewAPIError(http.StatusConflict, "conflict", message)
+}
+
+func NewInternalServerError(message string) *APIError {
+	return NewAPIError(http.StatusInternalServerError, "internal_server_error", message)
+}
+
+// NewTooManyRequestsError indica cuánto debe esperar el cliente antes de reintentar.
+func NewTooManyRequestsError(message string, retryAfter time.Duration) *APIError {
+	apiErr := NewAPIError(http.StatusTooManyRequests, "too_many_requests", message)
+	apiErr.RetryAfter = retryAfter
+	return apiErr
+}
+
+func NewBadGatewayError(message string) *APIError {
+	return NewAPIError(http.StatusBadGateway, "bad_gateway", message)
+}
+
+// HTTPErrorHandler renderiza los APIError como JSON y delega el resto al handler por defecto de Echo.
+func HTTPErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.RetryAfter > 0 {
+			seconds := int(math.Ceil(apiErr.RetryAfter.Seconds()))
+			c.Response().Header().Set(echo.HeaderRetryAfter, strconv.Itoa(seconds))
+		}
+		if c.Request().Method == http.MethodHead {
+			_ = c.NoContent(apiErr.Status)
+			return
+		}
+		_ = c.JSON(apiErr.Status, apiErr)
+		return
+	}
+
+	c.Echo().DefaultHTTPErrorHandler(err, c)
+}
+
+// NewOAuthError crea un error con un código de RFC 6749 5.2; invalid_client responde 401 y el resto 400.
+func NewOAuthError(code, description string) *APIError {
+	if code == "invalid_client" {
+		return NewAPIError(http.StatusUnauthorized, code, description)
+	}
+	return NewAPIError(http.StatusBadRequest, code, description)
+}
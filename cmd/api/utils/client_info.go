@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"context"
+	"strings"
+)
+
+type clientInfoKey struct{}
+
+// ClientInfo identifica desde dónde llega la petición; las sesiones lo registran al emitir y rotar tokens.
+// AcceptLanguage elige el idioma de los emails de usuarios sin idioma preferido.
+type ClientInfo struct {
+	UserAgent      string
+	IPAddress      string
+	AcceptLanguage string
+}
+
+func WithClientInfo(ctx context.Context, info ClientInfo) context.Context {
+	return context.WithValue(ctx, clientInfoKey{}, info)
+}
+
+// ClientInfoFromContext devuelve un ClientInfo vacío si la petición no pasó por el middleware.
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	info, _ := ctx.Value(clientInfoKey{}).(ClientInfo)
+	return info
+}
+
+// DeviceName resume un user agent como "<navegador> on <sistema>" para mostrarlo en la lista de sesiones.
+func DeviceName(userAgent string) string {
+	if userAgent == "" {
+		return "Unknown device"
+	}
+
+	browser := firstMatch(userAgent, [][2]string{
+		{"Edg/", "Edge"},
+		{"OPR/", "Opera"},
+		{"SamsungBrowser/", "Samsung Internet"},
+		{"Firefox/", "Firefox"},
+		{"CriOS/", "Chrome"},
+		{"Chrome/", "Chrome"},
+		{"Safari/", "Safari"},
+		{"okhttp/", "Android app"},
+		{"Dart/", "Tareaya app"},
+		{"CFNetwork/", "iOS app"},
+	})
+	system := firstMatch(userAgent, [][2]string{
+		{"iPhone", "iOS"},
+		{"iPad", "iPadOS"},
+		{"Android", "Android"},
+		{"Windows", "Windows"},
+		{"Mac OS X", "macOS"},
+		{"Darwin", "iOS"},
+		{"CrOS", "ChromeOS"},
+		{"Linux", "Linux"},
+	})
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return system
+	}
+	return "Unknown device"
+}
+
+func firstMatch(value string, candidates [][2]string) string {
+	for _, candidate := range candidates {
+		if strings.Contains(value, candidate[0]) {
+			return candidate[1]
+		}
+	}
+	return ""
+}
@@ -0,0 +1,45 @@
+package utils
+
+import "testing"
+
+func TestDeviceName(t *testing.T) {
+	testCases := []struct {
+		name      string
+		userAgent string
+		expected  string
+	}{
+		{
+			name:      "chrome on windows",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36",
+			expected:  "Chrome on Windows",
+		},
+		{
+			name:      "edge is not reported as chrome",
+			userAgent: "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/124.0.0.0 Safari/537.36 Edg/124.0.2478.51",
+			expected:  "Edge on Windows",
+		},
+		{
+			name:      "safari on iphone",
+			userAgent: "Mozilla/5.0 (iPhone; CPU iPhone OS 17_4 like Mac OS X) AppleWebKit/605.1.15 (KHTML, like Gecko) Version/17.4 Mobile/15E148 Safari/604.1",
+			expected:  "Safari on iOS",
+		},
+		{
+			name:      "android app",
+			userAgent: "okhttp/4.12.0",
+			expected:  "Android app",
+		},
+		{
+			name:      "unknown",
+			userAgent: "",
+			expected:  "Unknown device",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if name := DeviceName(tc.userAgent); name != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, name)
+			}
+		})
+	}
+}
@@ -0,0 +1,64 @@
+package utils
+
+import (
+	"testing"
+)
+
+func TestKeys(t *testing.T) {
+
+	t.Run("round trip and jwk", func(t *testing.T) {
+		testCases := []struct {
+			algorithm   string
+			expectedKty string
+		}{
+			{algorithm: AlgorithmRS256, expectedKty: "RSA"},
+			{algorithm: AlgorithmES256, expectedKty: "EC"},
+		}
+
+		for _, tc := range testCases {
+			key, err := GenerateSigningKey(tc.algorithm)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tc.algorithm, err)
+			}
+
+			encoded, err := EncodePrivateKey(key)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tc.algorithm, err)
+			}
+			parsed, err := ParsePrivateKey(encoded)
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tc.algorithm, err)
+			}
+
+			if algorithm, err := SigningAlgorithmOf(parsed); err != nil || algorithm != tc.algorithm {
+				t.Errorf("%s: unexpected algorithm %q: %v", tc.algorithm, algorithm, err)
+			}
+			original, _ := KeyThumbprint(key.Public())
+			if thumbprint, err := KeyThumbprint(parsed.Public()); err != nil || thumbprint != original {
+				t.Errorf("%s: expected a stable thumbprint, got %q and %q: %v", tc.algorithm, original, thumbprint, err)
+			}
+
+			jwk, err := NewJWK("kid-1", tc.algorithm, parsed.Public())
+			if err != nil {
+				t.Fatalf("%s: unexpected error: %v", tc.algorithm, err)
+			}
+			if jwk.Kty != tc.expectedKty || jwk.Kid != "kid-1" || jwk.Alg != tc.algorithm || jwk.Use != "sig" {
+				t.Errorf("%s: unexpected jwk: %+v", tc.algorithm, jwk)
+			}
+		}
+	})
+
+	t.Run("ec coordinates", func(t *testing.T) {
+		key, _ := GenerateSigningKey(AlgorithmES256)
+		jwk, _ := NewJWK("kid-1", AlgorithmES256, key.Public())
+		if jwk.Crv != "P-256" || len(jwk.X) != 43 || len(jwk.Y) != 43 {
+			t.Errorf("unexpected jwk: %+v", jwk)
+		}
+	})
+
+	t.Run("unsupported algorithm", func(t *testing.T) {
+		if _, err := GenerateSigningKey("HS256"); err == nil {
+			t.Errorf("expected error")
+		}
+	})
+}
@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+)
+
+// SupportedLocale reduce una etiqueta de idioma a uno de los locales soportados ("pt-BR" → "pt"); devuelve "" si
+// el idioma no está soportado.
+func SupportedLocale(tag string) string {
+	language, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+	switch language = strings.ToLower(language); language {
+	case constants.LocaleSpanish, constants.LocaleEnglish, constants.LocalePortuguese:
+		return language
+	default:
+		return ""
+	}
+}
+
+// MatchLocale elige de un header Accept-Language el locale soportado con mayor peso; a igual peso gana el primero.
+// Devuelve "" si ninguno está soportado.
+func MatchLocale(acceptLanguage string) string {
+	best, bestWeight := "", 0.0
+	for _, entry := range strings.Split(acceptLanguage, ",") {
+		tag, params, _ := strings.Cut(entry, ";")
+		weight := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			weight = parsed
+		}
+
+		if locale := SupportedLocale(tag); locale != "" && weight > bestWeight {
+			best, bestWeight = locale, weight
+		}
+	}
+	return best
+}
+
+// RequestLocale prefiere el idioma del usuario, después el Accept-Language de la petición y por último
+// constants.DefaultLocale.
+func RequestLocale(ctx context.Context, preferred string) string {
+	if locale := SupportedLocale(preferred); locale != "" {
+		return locale
+	}
+	if locale := MatchLocale(ClientInfoFromContext(ctx).AcceptLanguage); locale != "" {
+		return locale
+	}
+	return constants.DefaultLocale
+}
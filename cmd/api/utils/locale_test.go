@@ -0,0 +1,27 @@
+package utils
+
+import "testing"
+
+func TestMatchLocale(t *testing.T) {
+	testCases := []struct {
+		name           string
+		acceptLanguage string
+		expected       string
+	}{
+		{name: "empty header", acceptLanguage: "", expected: ""},
+		{name: "regional variant", acceptLanguage: "pt-BR", expected: "pt"},
+		{name: "first supported in order", acceptLanguage: "fr-FR, en-US, es", expected: "en"},
+		{name: "highest weight wins", acceptLanguage: "en;q=0.5, es-AR;q=0.9, pt;q=0.7", expected: "es"},
+		{name: "zero weight is never chosen", acceptLanguage: "en;q=0, fr", expected: ""},
+		{name: "wildcards are ignored", acceptLanguage: "*, de", expected: ""},
+		{name: "malformed weights are skipped", acceptLanguage: "en;q=abc, PT", expected: "pt"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if locale := MatchLocale(tc.acceptLanguage); locale != tc.expected {
+				t.Errorf("expected %q, got %q", tc.expected, locale)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/taskalataminfo2026/taska-auth-me-go/cmd/api/constants"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestPasswordHashing(t *testing.T) {
+	argon2Hash, err := HashArgon2id("s3cret-password", Argon2Params{Memory: 64, Iterations: 1, Parallelism: 1, SaltLength: 16, KeyLength: 32})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(argon2Hash, "$argon2id$v=19$m=64,t=1,p=1$") {
+		t.Errorf("unexpected argon2id hash format: %q", argon2Hash)
+	}
+	bcryptHash, err := HashBcrypt("s3cret-password", bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	testCases := []struct {
+		name              string
+		hash              string
+		password          string
+		expectedAlgorithm string
+		expected          bool
+	}{
+		{name: "argon2id", hash: argon2Hash, password: "s3cret-password", expectedAlgorithm: constants.PasswordHashArgon2id, expected: true},
+		{name: "argon2id wrong password", hash: argon2Hash, password: "other-password", expectedAlgorithm: constants.PasswordHashArgon2id},
+		{name: "bcrypt", hash: bcryptHash, password: "s3cret-password", expectedAlgorithm: constants.PasswordHashBcrypt, expected: true},
+		{name: "bcrypt wrong password", hash: bcryptHash, password: "other-password", expectedAlgorithm: constants.PasswordHashBcrypt},
+		{
+			// Vector generado con la implementación de referencia de argon2.
+			name:              "argon2id reference vector",
+			hash:              "$argon2id$v=19$m=65536,t=2,p=1$c29tZXNhbHQ$CTFhFdXPJO1aFaMaO6Mm5c8y7cJHAph8ArZWb2GRPPc",
+			password:          "password",
+			expectedAlgorithm: constants.PasswordHashArgon2id,
+			expected:          true,
+		},
+		{name: "malformed argon2id", hash: "$argon2id$v=19$m=0,t=1,p=1$c29tZXNhbHQ$CTFh", password: "password", expectedAlgorithm: constants.PasswordHashArgon2id},
+		{name: "other argon2 version", hash: "$argon2id$v=16$m=64,t=1,p=1$c29tZXNhbHQ$CTFh", password: "password", expectedAlgorithm: constants.PasswordHashArgon2id},
+		{name: "unknown format", hash: "5f4dcc3b5aa765d61d8327deb882cf99", password: "password"},
+		{name: "empty hash", hash: "", password: ""},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := PasswordHashAlgorithm(tc.hash); got != tc.expectedAlgorithm {
+				t.Errorf("unexpected algorithm: got %q, want %q", got, tc.expectedAlgorithm)
+			}
+			if got := CheckPassword(tc.hash, tc.password); got != tc.expected {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package utils
+
+import (
+	"errors"
+	"strings"
+)
+
+// minPhoneDigits y maxPhoneDigits cuentan el código de país; E.164 permite hasta 15 dígitos.
+const (
+	minPhoneDigits = 8
+	maxPhoneDigits = 15
+)
+
+var ErrInvalidPhoneNumber = errors.New("invalid phone number")
+
+// NormalizePhoneNumber lleva un número a E.164 (+573001234567). Acepta espacios, guiones, puntos y paréntesis,
+// y el prefijo internacional como "+" o "00". Un número sin prefijo se completa con defaultCountryCode, quitando
+// el 0 de marcación nacional; si defaultCountryCode está vacío el prefijo es obligatorio.
+func NormalizePhoneNumber(raw, defaultCountryCode string) (string, error) {
+	number := strings.Map(func(r rune) rune {
+		switch r {
+		case ' ', '-', '.', '(', ')', '/':
+			return -1
+		}
+		return r
+	}, strings.TrimSpace(raw))
+
+	switch {
+	case strings.HasPrefix(number, "+"):
+		number = number[1:]
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+	case defaultCountryCode != "":
+		number = strings.TrimPrefix(defaultCountryCode, "+") + strings.TrimLeft(number, "0")
+	default:
+		return "", ErrInvalidPhoneNumber
+	}
+
+	if len(number) < minPhoneDigits || len(number) > maxPhoneDigits || number[0] == '0' {
+		return "", ErrInvalidPhoneNumber
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return "", ErrInvalidPhoneNumber
+		}
+	}
+	return "+" + number, nil
+}
@@ -0,0 +1,42 @@
+package utils
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNormalizePhoneNumber(t *testing.T) {
+	testCases := []struct {
+		name               string
+		raw                string
+		defaultCountryCode string
+		expected           string
+	}{
+		{name: "already e164", raw: "+573001234567", expected: "+573001234567"},
+		{name: "separators", raw: " +57 (300) 123-45.67 ", expected: "+573001234567"},
+		{name: "international prefix", raw: "0054 9 11 2345 6789", expected: "+5491123456789"},
+		{name: "national number", raw: "300 123 4567", defaultCountryCode: "57", expected: "+573001234567"},
+		{name: "national trunk prefix", raw: "011 2345 6789", defaultCountryCode: "+54", expected: "+541123456789"},
+		{name: "national number without default", raw: "3001234567"},
+		{name: "letters", raw: "+57300ABC4567"},
+		{name: "too short", raw: "+5712345"},
+		{name: "too long", raw: "+5730012345678901"},
+		{name: "country code starting with zero", raw: "+0573001234567"},
+		{name: "empty", raw: "", defaultCountryCode: "57"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			number, err := NormalizePhoneNumber(tc.raw, tc.defaultCountryCode)
+			if tc.expected == "" {
+				if !errors.Is(err, ErrInvalidPhoneNumber) {
+					t.Errorf("expected an invalid phone number error, got %q, %v", number, err)
+				}
+				return
+			}
+			if err != nil || number != tc.expected {
+				t.Errorf("expected %q, got %q, %v", tc.expected, number, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package utils
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+)
+
+// S256CodeChallenge calcula el code_challenge S256 de RFC 7636: BASE64URL(SHA256(code_verifier)).
+func S256CodeChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// VerifyCodeChallenge comprueba que el verifier tenga el formato de RFC 7636 4.1 y corresponda al challenge.
+func VerifyCodeChallenge(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	for _, char := range verifier {
+		if !isUnreserved(char) {
+			return false
+		}
+	}
+	return subtle.ConstantTimeCompare([]byte(S256CodeChallenge(verifier)), []byte(challenge)) == 1
+}
+
+func isUnreserved(char rune) bool {
+	return (char >= 'a' && char <= 'z') || (char >= 'A' && char <= 'Z') || (char >= '0' && char <= '9') ||
+		char == '-' || char == '.' || char == '_' || char == '~'
+}
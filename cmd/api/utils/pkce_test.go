@@ -0,0 +1,36 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPKCE(t *testing.T) {
+	// Vector del apéndice B de RFC 7636.
+	const verifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	const challenge = "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	if got := S256CodeChallenge(verifier); got != challenge {
+		t.Fatalf("unexpected challenge: got %q, want %q", got, challenge)
+	}
+
+	testCases := []struct {
+		name     string
+		verifier string
+		expected bool
+	}{
+		{name: "matching verifier", verifier: verifier, expected: true},
+		{name: "other verifier", verifier: strings.Repeat("a", 43), expected: false},
+		{name: "too short", verifier: verifier[:42], expected: false},
+		{name: "too long", verifier: strings.Repeat("a", 129), expected: false},
+		{name: "invalid characters", verifier: strings.Repeat("a", 42) + "+", expected: false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := VerifyCodeChallenge(tc.verifier, challenge); got != tc.expected {
+				t.Errorf("unexpected result: got %v, want %v", got, tc.expected)
+			}
+		})
+	}
+}
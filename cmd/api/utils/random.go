@@ -0,0 +1,55 @@
+package utils
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
+	"math/big"
+	"strings"
+)
+
+// GenerateNumericCode devuelve un código aleatorio de length dígitos.
+func GenerateNumericCode(length int) (string, error) {
+	var builder strings.Builder
+	for i := 0; i < length; i++ {
+		digit, err := rand.Int(rand.Reader, big.NewInt(10))
+		if err != nil {
+			return "", err
+		}
+		builder.WriteByte(byte('0' + digit.Int64()))
+	}
+	return builder.String(), nil
+}
+
+// GenerateToken devuelve un token opaco URL-safe con size bytes de entropía.
+func GenerateToken(size int) (string, error) {
+	buffer := make([]byte, size)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buffer), nil
+}
+
+// HashToken devuelve el SHA-256 en hexadecimal de un código o token para guardarlo en base de datos.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// GenerateRecoveryCode devuelve un código de recuperación legible con el formato xxxxx-xxxxx.
+func GenerateRecoveryCode() (string, error) {
+	buffer := make([]byte, 7)
+	if _, err := rand.Read(buffer); err != nil {
+		return "", err
+	}
+	code := strings.ToLower(base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(buffer))[:10]
+	return code[:5] + "-" + code[5:], nil
+}
+
+// NormalizeRecoveryCode quita separadores y mayúsculas para que el usuario pueda escribir el código como quiera.
+func NormalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	return strings.NewReplacer("-", "", " ", "").Replace(code)
+}
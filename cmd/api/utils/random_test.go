@@ -0,0 +1,54 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"unicode"
+)
+
+func TestRandom(t *testing.T) {
+
+	t.Run("numeric code", func(t *testing.T) {
+		code, err := GenerateNumericCode(6)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(code) != 6 {
+			t.Errorf("unexpected length: got %d, want 6", len(code))
+		}
+		for _, r := range code {
+			if !unicode.IsDigit(r) {
+				t.Errorf("unexpected character %q in %q", r, code)
+			}
+		}
+	})
+
+	t.Run("token", func(t *testing.T) {
+		first, _ := GenerateToken(32)
+		second, _ := GenerateToken(32)
+		if first == second {
+			t.Errorf("expected different tokens")
+		}
+	})
+
+	t.Run("hash token", func(t *testing.T) {
+		if HashToken("123456") != HashToken("123456") {
+			t.Errorf("expected deterministic hash")
+		}
+		if HashToken("123456") == HashToken("654321") {
+			t.Errorf("expected different hashes")
+		}
+	})
+	t.Run("recovery code", func(t *testing.T) {
+		code, err := GenerateRecoveryCode()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(code) != 11 || code[5] != '-' {
+			t.Errorf("unexpected format: %q", code)
+		}
+		if NormalizeRecoveryCode(" "+strings.ToUpper(code)+" ") != strings.ReplaceAll(code, "-", "") {
+			t.Errorf("unexpected normalization of %q", code)
+		}
+	})
+}
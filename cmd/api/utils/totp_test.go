@@ -0,0 +1,75 @@
+package utils
+
+import (
+	"net/url"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret es la clave "12345678901234567890" de los vectores de prueba del RFC 6238 en base32.
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTP(t *testing.T) {
+
+	testCases := []struct {
+		name     string
+		unix     int64
+		expected string
+	}{
+		{name: "t=59", unix: 59, expected: "287082"},
+		{name: "t=1111111109", unix: 1111111109, expected: "081804"},
+		{name: "t=1234567890", unix: 1234567890, expected: "005924"},
+		{name: "t=2000000000", unix: 2000000000, expected: "279037"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			code, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tc.unix, 0)))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if code != tc.expected {
+				t.Errorf("unexpected code: got %s, want %s", code, tc.expected)
+			}
+		})
+	}
+
+	t.Run("validate with skew", func(t *testing.T) {
+		now := time.Unix(1111111109, 0)
+		previous, _ := TOTPCode(rfc6238Secret, TOTPStep(now)-1)
+
+		step, ok := ValidateTOTP(rfc6238Secret, previous, now, 1)
+		if !ok || step != TOTPStep(now)-1 {
+			t.Errorf("expected previous step to be accepted, got %d %v", step, ok)
+		}
+		if _, ok := ValidateTOTP(rfc6238Secret, previous, now.Add(time.Minute), 1); ok {
+			t.Errorf("expected code outside the window to be rejected")
+		}
+		if _, ok := ValidateTOTP(rfc6238Secret, "12345", now, 1); ok {
+			t.Errorf("expected short code to be rejected")
+		}
+	})
+
+	t.Run("generated secret", func(t *testing.T) {
+		secret, err := GenerateTOTPSecret()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if _, err := TOTPCode(secret, 1); err != nil {
+			t.Errorf("expected generated secret to decode: %v", err)
+		}
+	})
+
+	t.Run("otpauth uri", func(t *testing.T) {
+		uri, err := url.Parse(TOTPURI("tareaya", "ana@example.com", rfc6238Secret))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if uri.Scheme != "otpauth" || uri.Host != "totp" || uri.Path != "/tareaya:ana@example.com" {
+			t.Errorf("unexpected uri: %s", uri)
+		}
+		if uri.Query().Get("secret") != rfc6238Secret || uri.Query().Get("issuer") != "tareaya" {
+			t.Errorf("unexpected query: %s", uri.RawQuery)
+		}
+	})
+}